@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"os"
 	"strings"
+
+	"github.com/teamwork/desksdkgo/client"
 )
 
 // Service defines the interface that all service types must implement
@@ -17,8 +19,9 @@ type Service[T any, R any, L any] interface {
 	Update(ctx context.Context, id int, item *T) (*R, error)
 }
 
-// Call is a generic function to handle any resource type
-func Call[T any, R any, L any](ctx context.Context, service Service[T, R, L], action string, id int, createItem func() *T) {
+// Call is a generic function to handle any resource type. The create action
+// creates count items, in parallel when the service supports it.
+func Call[T any, R any, L any](ctx context.Context, service Service[T, R, L], action string, id int, count int, createItem func() *T) {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 
@@ -43,14 +46,31 @@ func Call[T any, R any, L any](ctx context.Context, service Service[T, R, L], ac
 		enc.Encode(items)
 
 	case "create":
-		item := createItem()
-		created, err := service.Create(ctx, item)
-		if err != nil {
-			log.Print(err)
+		items := make([]*T, max(count, 1))
+		for i := range items {
+			items[i] = createItem()
+		}
+
+		if batch, ok := service.(client.BatchCreator[T]); ok {
+			results, _ := batch.CreateBatch(ctx, items, client.BatchOptions{ContinueOnError: true})
+			for _, r := range results {
+				if r.Err != nil {
+					log.Printf("item %d: %v", r.Index, r.Err)
+					continue
+				}
+				enc.Encode(r.Resource)
+			}
 			return
 		}
 
-		enc.Encode(created)
+		for _, item := range items {
+			created, err := service.Create(ctx, item)
+			if err != nil {
+				log.Print(err)
+				continue
+			}
+			enc.Encode(created)
+		}
 
 	case "update":
 		if id == 0 {
@@ -0,0 +1,165 @@
+// Package attachments offloads ticket attachments into external blob storage
+// for archival and compliance purposes.
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ManifestKey is the key the manifest is written to in the blob store
+const ManifestKey = "manifest.json"
+
+// Manifest records every attachment that was offloaded in a run
+type Manifest struct {
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Entries     []ManifestEntry `json:"entries"`
+	Failures    []Failure       `json:"failures,omitempty"`
+}
+
+// ManifestEntry describes a single stored attachment
+type ManifestEntry struct {
+	TicketID int    `json:"ticketId"`
+	FileID   int    `json:"fileId"`
+	Filename string `json:"filename"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Key      string `json:"key"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// Failure records an attachment that could not be offloaded
+type Failure struct {
+	TicketID int    `json:"ticketId"`
+	FileID   int    `json:"fileId"`
+	Error    string `json:"error"`
+}
+
+// Options configures Offload
+type Options struct {
+	// Prefix is prepended to every key written to the store
+	Prefix string
+}
+
+// Offload streams the attachments of every ticket matching filter into store
+// and writes a manifest describing them. Attachments that fail to transfer are
+// listed in the manifest's failures and do not stop the run; an error is only
+// returned when tickets cannot be listed or the manifest cannot be written.
+func Offload(ctx context.Context, c *client.Client, filter *models.SearchTicketsFilter, store BlobStore, opts Options) (*Manifest, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store is required")
+	}
+
+	f := models.SearchTicketsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.OnlyWithAttachment = true
+	if f.Page == 0 {
+		f.Page = 1
+	}
+
+	manifest := &Manifest{GeneratedAt: time.Now().UTC()}
+	for {
+		resp, err := c.Tickets.Search(ctx, &f)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+
+		for _, t := range resp.Tickets {
+			for _, ref := range t.Files {
+				entry, err := offloadFile(ctx, c, store, opts.Prefix, t.ID, ref.ID)
+				if err != nil {
+					manifest.Failures = append(manifest.Failures, Failure{TicketID: t.ID, FileID: ref.ID, Error: err.Error()})
+					continue
+				}
+				manifest.Entries = append(manifest.Entries, *entry)
+			}
+		}
+
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+		f.Page++
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Put(ctx, path.Join(opts.Prefix, ManifestKey), bytes.NewReader(b), int64(len(b)), "application/json"); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func offloadFile(ctx context.Context, c *client.Client, store BlobStore, prefix string, ticketID, fileID int) (*ManifestEntry, error) {
+	file, body, err := c.Files.Download(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	entry := &ManifestEntry{
+		TicketID: ticketID,
+		FileID:   fileID,
+		Filename: deref(file.Filename),
+		MIMEType: deref(file.MIMEType),
+	}
+	entry.Key = path.Join(prefix, "tickets", fmt.Sprint(ticketID), fmt.Sprintf("%d-%s", fileID, safeName(entry.Filename)))
+
+	size := int64(-1)
+	if file.Size != nil {
+		size = *file.Size
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(body, hash)}
+	if err := store.Put(ctx, entry.Key, counter, size, entry.MIMEType); err != nil {
+		return nil, err
+	}
+
+	entry.Size = counter.n
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return entry, nil
+}
+
+// safeName strips path separators so a filename cannot escape its key prefix
+func safeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,71 @@
+package attachments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestOffload(t *testing.T) {
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{{
+			BaseEntity: models.BaseEntity{ID: 12},
+			Files:      []models.EntityRef{{ID: 5}, {ID: 6}},
+		}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/files/5.json", http.StatusOK, models.FileResponse{
+		File: models.File{
+			BaseEntity: models.BaseEntity{ID: 5},
+			Filename:   ptr("../invoice.pdf"),
+			MIMEType:   ptr("application/pdf"),
+			URL:        ptr("https://files.example.com/blobs/5"),
+		},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/blobs/5", http.StatusOK, "%PDF-1.4")
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	root := t.TempDir()
+	manifest, err := Offload(context.Background(), c, nil, DirStore{Root: root}, Options{Prefix: "archive"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(manifest.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(manifest.Entries))
+	}
+	if len(manifest.Failures) != 1 || manifest.Failures[0].FileID != 6 {
+		t.Errorf("expected file 6 to be recorded as a failure, got %+v", manifest.Failures)
+	}
+
+	entry := manifest.Entries[0]
+	if entry.Key != "archive/tickets/12/5-.._invoice.pdf" {
+		t.Errorf("unexpected key %q", entry.Key)
+	}
+	if entry.Size != 8 {
+		t.Errorf("expected size 8, got %d", entry.Size)
+	}
+
+	b, err := os.ReadFile(filepath.Join(root, "archive", "tickets", "12", "5-.._invoice.pdf"))
+	if err != nil || string(b) != "%PDF-1.4" {
+		t.Errorf("expected attachment to be stored, got %q (%v)", b, err)
+	}
+
+	b, err = os.ReadFile(filepath.Join(root, "archive", ManifestKey))
+	if err != nil {
+		t.Fatalf("expected manifest to be written, got %v", err)
+	}
+	var stored Manifest
+	if err := json.Unmarshal(b, &stored); err != nil || len(stored.Entries) != 1 {
+		t.Errorf("unexpected manifest contents: %s", b)
+	}
+}
@@ -0,0 +1,56 @@
+package attachments
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BlobStore is the destination attachments are offloaded to. Implementations
+// for object stores such as S3 or GCS wrap the vendor SDK's upload call; the
+// SDK deliberately does not depend on them.
+type BlobStore interface {
+	// Put stores the contents of r under key. size is -1 when unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+}
+
+// StoreFunc adapts a function to the BlobStore interface, which is usually the
+// shortest way to plug in an S3 or GCS client:
+//
+//	store := attachments.StoreFunc(func(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+//		_, err := uploader.Upload(ctx, &s3.PutObjectInput{Bucket: &bucket, Key: &key, Body: r, ContentType: &contentType})
+//		return err
+//	})
+type StoreFunc func(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+// Put implements BlobStore
+func (f StoreFunc) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	return f(ctx, key, r, size, contentType)
+}
+
+// DirStore stores blobs as files below a local directory, using the key as
+// the relative path
+type DirStore struct {
+	Root string
+}
+
+// Put implements BlobStore
+func (d DirStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path := filepath.Join(d.Root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
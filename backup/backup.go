@@ -0,0 +1,379 @@
+// Package backup snapshots the resources of a Desk installation into a
+// directory and restores a snapshot into another installation.
+//
+// A backup directory holds one JSON Lines file per resource, with every
+// record exactly as the API returned it, an attachments directory when
+// attachments are included, and a manifest describing the rest.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ManifestFile is the name of the manifest in a backup directory
+const ManifestFile = "manifest.json"
+
+const (
+	formatVersion   = 1
+	defaultPageSize = 100
+	messagesFile    = "messages.jsonl"
+	attachmentsDir  = "attachments"
+)
+
+// Manifest describes a backup
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Source is the API base URL the backup was taken from
+	Source      string         `json:"source"`
+	Resources   []ResourceFile `json:"resources"`
+	Attachments []Attachment   `json:"attachments,omitempty"`
+	Failures    []Failure      `json:"failures,omitempty"`
+}
+
+// ResourceFile is the JSON Lines file holding one resource
+type ResourceFile struct {
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	Count int    `json:"count"`
+}
+
+// Attachment is a ticket attachment stored in the backup
+type Attachment struct {
+	FileID   int    `json:"fileId"`
+	TicketID int    `json:"ticketId"`
+	Filename string `json:"filename"`
+	MIMEType string `json:"mimeType,omitempty"`
+	// Path is relative to the backup directory
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Failure is a record that could not be backed up or restored
+type Failure struct {
+	Resource string `json:"resource"`
+	ID       int    `json:"id"`
+	Error    string `json:"error"`
+}
+
+// Options configures Backup
+type Options struct {
+	// Resources limits the backup to these resources. Defaults to every
+	// resource in Resources(). Ticket messages are backed up with tickets.
+	Resources []string
+
+	// Attachments downloads ticket attachments into the backup
+	Attachments bool
+
+	// PageSize is the number of records requested per page. Defaults to 100.
+	PageSize int
+}
+
+// Resources returns the resources that can be backed up, in the order they
+// are restored
+func Resources() []string {
+	names := make([]string, 0, len(specs()))
+	for _, s := range specs() {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// spec describes how a resource is listed and created
+type spec struct {
+	name   string
+	single string
+	list   string
+	// match is the field that identifies an existing record in the target
+	// installation when restoring, empty if records cannot be matched by
+	// content
+	match string
+}
+
+// specs lists the supported resources in dependency order: everything a
+// record refers to comes before it
+func specs() []spec {
+	keyed := func(name string, e models.Entity, match string) spec {
+		single, list := e.EnvelopeKeys()
+		return spec{name: name, single: single, list: list, match: match}
+	}
+
+	return []spec{
+		keyed("tags", models.Tag{}, "name"),
+		keyed("ticketpriorities", models.TicketPriority{}, "name"),
+		keyed("ticketstatuses", models.TicketStatus{}, "name"),
+		keyed("tickettypes", models.TicketType{}, "name"),
+		keyed("ticketsources", models.TicketSource{}, "name"),
+		keyed("businesshours", models.BusinessHour{}, "name"),
+		keyed("inboxes", models.Inbox{}, "name"),
+		keyed("users", models.User{}, "email"),
+		keyed("companies", models.Company{}, "name"),
+		keyed("customers", models.Customer{}, "email"),
+		keyed("spamlists", models.Spamlist{}, "term"),
+		keyed("tickets", models.Ticket{}, ""),
+	}
+}
+
+// Backup writes every record of the selected resources into dir, creating it
+// if needed. Records that fail to back up, such as attachments that cannot be
+// downloaded or a ticket whose messages cannot be listed, are listed in the
+// manifest's failures; listing a resource failing stops the backup.
+func Backup(ctx context.Context, c *client.Client, dir string, opts Options) (*Manifest, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("dir is required")
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	for _, name := range opts.Resources {
+		if !slices.Contains(Resources(), name) {
+			return nil, fmt.Errorf("unsupported resource %q", name)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{Version: formatVersion, CreatedAt: time.Now().UTC(), Source: c.BaseURL()}
+	for _, s := range specs() {
+		if len(opts.Resources) > 0 && !slices.Contains(opts.Resources, s.name) {
+			continue
+		}
+
+		file := s.name + ".jsonl"
+		var tickets []int
+		count, err := writeLines(filepath.Join(dir, file), func(emit func(json.RawMessage) error) error {
+			return eachRecord(ctx, c, s.name, s.list, opts.PageSize, func(raw json.RawMessage) error {
+				if s.name == "tickets" {
+					tickets = append(tickets, recordID(raw))
+				}
+				return emit(raw)
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("back up %s: %w", s.name, err)
+		}
+		manifest.Resources = append(manifest.Resources, ResourceFile{Name: s.name, File: file, Count: count})
+
+		if s.name == "tickets" {
+			if err := backupTicketData(ctx, c, dir, tickets, opts, manifest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writeManifest(dir, manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// backupTicketData writes the messages, and optionally the attachments, of
+// the backed up tickets
+func backupTicketData(ctx context.Context, c *client.Client, dir string, tickets []int, opts Options, manifest *Manifest) error {
+	count, err := writeLines(filepath.Join(dir, messagesFile), func(emit func(json.RawMessage) error) error {
+		for _, id := range tickets {
+			err := eachRecord(ctx, c, fmt.Sprintf("tickets/%d/messages", id), "messages", opts.PageSize, emit)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				manifest.Failures = append(manifest.Failures, Failure{Resource: "messages", ID: id, Error: err.Error()})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("back up messages: %w", err)
+	}
+	manifest.Resources = append(manifest.Resources, ResourceFile{Name: "messages", File: messagesFile, Count: count})
+
+	if !opts.Attachments {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, attachmentsDir), 0o755); err != nil {
+		return err
+	}
+	for _, id := range tickets {
+		ticket, err := c.Tickets.Get(ctx, id, nil)
+		if err != nil {
+			manifest.Failures = append(manifest.Failures, Failure{Resource: "tickets", ID: id, Error: err.Error()})
+			continue
+		}
+		for _, ref := range ticket.Ticket.Files {
+			a, err := downloadAttachment(ctx, c, dir, id, ref.ID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				manifest.Failures = append(manifest.Failures, Failure{Resource: "files", ID: ref.ID, Error: err.Error()})
+				continue
+			}
+			manifest.Attachments = append(manifest.Attachments, *a)
+		}
+	}
+
+	return nil
+}
+
+func downloadAttachment(ctx context.Context, c *client.Client, dir string, ticketID, fileID int) (*Attachment, error) {
+	file, body, err := c.Files.Download(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	a := &Attachment{FileID: fileID, TicketID: ticketID}
+	if file.Filename != nil {
+		a.Filename = *file.Filename
+	}
+	if file.MIMEType != nil {
+		a.MIMEType = *file.MIMEType
+	}
+	a.Path = filepath.ToSlash(filepath.Join(attachmentsDir, strconv.Itoa(fileID)))
+
+	f, err := os.Create(filepath.Join(dir, a.Path))
+	if err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.Size = n
+	return a, nil
+}
+
+// eachRecord lists every record under path and calls fn with each one as the
+// API returned it
+func eachRecord(ctx context.Context, c *client.Client, path, listKey string, pageSize int, fn func(json.RawMessage) error) error {
+	svc := client.NewService[json.RawMessage, json.RawMessage](c, client.NewDefaultPathHandler(path))
+
+	for page := 1; ; page++ {
+		params := (&client.ListOptions{Page: page, PerPage: pageSize}).Values()
+
+		raw, err := svc.List(ctx, params)
+		if err != nil {
+			return fmt.Errorf("list page %d: %w", page, err)
+		}
+
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(*raw, &resp); err != nil {
+			return fmt.Errorf("decode page %d: %w", page, err)
+		}
+
+		var records []json.RawMessage
+		if v, ok := resp[listKey]; ok {
+			if err := json.Unmarshal(v, &records); err != nil {
+				return fmt.Errorf("decode %s: %w", listKey, err)
+			}
+		}
+		for _, r := range records {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+
+		var pagination models.Pagination
+		if v, ok := resp["pagination"]; ok {
+			if err := json.Unmarshal(v, &pagination); err != nil {
+				return fmt.Errorf("decode pagination: %w", err)
+			}
+		}
+		if !pagination.HasMorePages {
+			return nil
+		}
+	}
+}
+
+// writeLines writes the records produced by fill to path as JSON Lines and
+// returns how many were written
+func writeLines(path string, fill func(emit func(json.RawMessage) error) error) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	var count int
+	err = fill(func(raw json.RawMessage) error {
+		if _, err := w.Write(compact(raw)); err != nil {
+			return err
+		}
+		count++
+		return w.WriteByte('\n')
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := w.Flush(); err != nil {
+		return count, err
+	}
+	return count, f.Close()
+}
+
+// compact removes insignificant whitespace, including newlines, so a record
+// fits on one line
+func compact(raw json.RawMessage) []byte {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return raw
+	}
+	return b.Bytes()
+}
+
+func writeManifest(dir string, manifest *Manifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), b, 0o644)
+}
+
+// ReadManifest reads the manifest of the backup in dir
+func ReadManifest(dir string) (*Manifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if m.Version != formatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", m.Version)
+	}
+
+	return &m, nil
+}
+
+func recordID(raw json.RawMessage) int {
+	var r struct {
+		ID int `json:"id"`
+	}
+	_ = json.Unmarshal(raw, &r)
+	return r.ID
+}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	source := client.NewMockRoundTripper()
+	source.AddResponse(http.MethodGet, "/tags.json", http.StatusOK, `{"tags":[{"id":1,"type":"tags","name":"vip"}],"pagination":{}}`)
+	source.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, `{"tickets":[{
+		"id":10,"subject":"Help",
+		"tags":[{"id":1,"type":"tags"}],
+		"customer":{"id":99,"type":"customers"}
+	}],"pagination":{}}`)
+	source.AddResponse(http.MethodGet, "/tickets/10/messages.json", http.StatusOK, `{"messages":[
+		{"id":101,"htmlBody":"<p>reply</p>","ticket":{"id":10},"createdAt":"2024-01-02T00:00:00Z"},
+		{"id":100,"htmlBody":"<p>first</p>","ticket":{"id":10},"createdAt":"2024-01-01T00:00:00Z"}
+	],"pagination":{}}`)
+
+	dir := t.TempDir()
+	src := client.NewClient("https://source.example.com", client.WithHTTPClient(&http.Client{Transport: source}))
+
+	manifest, err := Backup(context.Background(), src, dir, Options{Resources: []string{"tags", "tickets"}})
+	if err != nil {
+		t.Fatalf("Backup() returned error: %v", err)
+	}
+	if len(manifest.Resources) != 3 || manifest.Resources[2].Name != "messages" || manifest.Resources[2].Count != 2 {
+		t.Fatalf("expected tags, tickets and 2 messages in the manifest, got %+v", manifest.Resources)
+	}
+
+	target := client.NewMockRoundTripper()
+	target.AddResponse(http.MethodPost, "/tags.json", http.StatusCreated, `{"tag":{"id":501}}`)
+	target.AddResponse(http.MethodPost, "/tickets.json", http.StatusCreated, `{"ticket":{"id":610}}`)
+	target.AddResponse(http.MethodPost, "/tickets/610/messages.json", http.StatusCreated, `{"message":{"id":700}}`)
+	dst := client.NewClient("https://target.example.com", client.WithHTTPClient(&http.Client{Transport: target}))
+
+	ids := newMemoryIDMap()
+	report, err := Restore(context.Background(), dst, dir, RestoreOptions{IDs: ids})
+	if err != nil {
+		t.Fatalf("Restore() returned error: %v", err)
+	}
+	if report.Created["tags"] != 1 || report.Created["tickets"] != 1 || report.Created["messages"] != 1 || len(report.Failures) != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	requests := target.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+
+	var ticket struct {
+		Ticket map[string]any `json:"ticket"`
+	}
+	decodeBody(t, requests[1], &ticket)
+	if _, ok := ticket.Ticket["id"]; ok {
+		t.Error("expected the backed up ID to be removed")
+	}
+	if _, ok := ticket.Ticket["customer"]; ok {
+		t.Error("expected the reference to an unrestored customer to be dropped")
+	}
+	if tags := ticket.Ticket["tags"].([]any); len(tags) != 1 || tags[0].(map[string]any)["id"] != float64(501) {
+		t.Errorf("expected the tag to point at the restored tag, got %v", ticket.Ticket["tags"])
+	}
+	if ticket.Ticket["message"] != "<p>first</p>" {
+		t.Errorf("expected the ticket to open with its first message, got %v", ticket.Ticket["message"])
+	}
+
+	var message struct {
+		Message map[string]any `json:"message"`
+	}
+	decodeBody(t, requests[2], &message)
+	if message.Message["message"] != "<p>reply</p>" {
+		t.Errorf("expected only the reply to be restored as a message, got %v", message.Message["message"])
+	}
+
+	report, err = Restore(context.Background(), dst, dir, RestoreOptions{IDs: ids})
+	if err != nil {
+		t.Fatalf("Restore() returned error: %v", err)
+	}
+	if len(target.GetRequests()) != 3 || report.Skipped["tickets"] != 1 {
+		t.Errorf("expected a second restore to skip everything, got %+v", report)
+	}
+}
+
+func decodeBody(t *testing.T, req *http.Request, v any) {
+	t.Helper()
+
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatalf("failed to decode request body %s: %v", b, err)
+	}
+}
@@ -0,0 +1,234 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Strategy is how Restore handles a backed up record that matches a record
+// already in the target installation. Records are matched on their name, or
+// their email for users and customers, ignoring case.
+type Strategy string
+
+const (
+	// StrategySkip keeps the existing record unchanged and points references
+	// to the backed up record at it
+	StrategySkip Strategy = "skip"
+	// StrategyOverwrite updates the existing record with the backed up data
+	StrategyOverwrite Strategy = "overwrite"
+	// StrategyDuplicate creates the record anyway, with the duplicate suffix
+	// added to its name or email
+	StrategyDuplicate Strategy = "duplicate"
+	// StrategyExternalID matches customers and tickets on their external ID,
+	// falling back to the backed up ID when a record has none, and keeps the
+	// existing record. Created records get that external ID, so later
+	// restores and imports find them.
+	StrategyExternalID Strategy = "external-id"
+)
+
+const defaultDuplicateSuffix = "restored"
+
+// Resolution is what Restore did with a backed up record
+type Resolution string
+
+const (
+	// ResolutionCreated means no matching record existed and one was created
+	ResolutionCreated Resolution = "created"
+	// ResolutionKept means a matching record existed and was left as it was
+	ResolutionKept Resolution = "kept"
+	// ResolutionOverwritten means a matching record existed and was updated
+	ResolutionOverwritten Resolution = "overwritten"
+	// ResolutionDuplicated means a matching record existed and a renamed
+	// copy was created next to it
+	ResolutionDuplicated Resolution = "duplicated"
+)
+
+// Decision records how a backed up record was restored
+type Decision struct {
+	Resource string `json:"resource"`
+	// ID is the record's ID in the backup
+	ID int `json:"id"`
+	// Match is the name, email or external ID the record was matched on
+	Match      string     `json:"match,omitempty"`
+	Strategy   Strategy   `json:"strategy,omitempty"`
+	Resolution Resolution `json:"resolution"`
+	// TargetID is the ID of the record in the target installation
+	TargetID int `json:"targetId"`
+}
+
+// ParseStrategies parses a comma-separated list of conflict strategies such
+// as "skip,customers=external-id,tags=overwrite". An entry without a
+// resource sets the strategy of every resource not listed explicitly and is
+// returned as the default.
+func ParseStrategies(s string) (Strategy, map[string]Strategy, error) {
+	var def Strategy
+	per := make(map[string]Strategy)
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		resource, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			value = resource
+		}
+
+		strategy := Strategy(strings.TrimSpace(value))
+		if err := strategy.validate(); err != nil {
+			return "", nil, err
+		}
+		if ok {
+			per[strings.TrimSpace(resource)] = strategy
+		} else {
+			def = strategy
+		}
+	}
+
+	return def, per, nil
+}
+
+func (s Strategy) validate() error {
+	switch s {
+	case StrategySkip, StrategyOverwrite, StrategyDuplicate, StrategyExternalID:
+		return nil
+	default:
+		return fmt.Errorf("unknown conflict strategy %q", s)
+	}
+}
+
+// supports reports whether records of sp can be matched with strategy s
+func (s Strategy) supports(sp spec) bool {
+	if s == StrategyExternalID {
+		return sp.name == "customers" || sp.name == "tickets"
+	}
+	return sp.match != ""
+}
+
+// strategy returns the conflict strategy for a resource, empty when records
+// are created without looking for existing ones
+func (r *restorer) strategy(sp spec) Strategy {
+	if s, ok := r.opts.Conflicts[sp.name]; ok {
+		return s
+	}
+	if r.opts.Conflict.supports(sp) {
+		return r.opts.Conflict
+	}
+	return ""
+}
+
+// resolve looks for a record in the target installation matching obj and
+// applies the resource's conflict strategy. It returns the ID of the record
+// obj now maps to.
+func (r *restorer) resolve(ctx context.Context, sp spec, oldID int, obj map[string]any) (*Decision, error) {
+	d := &Decision{Resource: sp.name, ID: oldID, Strategy: r.strategy(sp), Resolution: ResolutionCreated}
+
+	var existingID int
+	switch d.Strategy {
+	case "":
+	case StrategyExternalID:
+		d.Match, _ = obj["externalId"].(string)
+		if d.Match == "" {
+			d.Match = fmt.Sprint(oldID)
+			obj["externalId"] = d.Match
+		}
+
+		var err error
+		if existingID, err = r.findByExternalID(ctx, sp.name, d.Match); err != nil {
+			return nil, err
+		}
+	default:
+		d.Match, _ = obj[sp.match].(string)
+		if d.Match != "" {
+			existing, err := r.existing(ctx, sp)
+			if err != nil {
+				return nil, err
+			}
+			existingID = existing[strings.ToLower(d.Match)]
+		}
+	}
+
+	var err error
+	switch {
+	case existingID == 0:
+		d.TargetID, err = r.save(ctx, sp.name, sp.single, 0, obj)
+	case d.Strategy == StrategySkip || d.Strategy == StrategyExternalID:
+		d.Resolution, d.TargetID = ResolutionKept, existingID
+	case d.Strategy == StrategyOverwrite:
+		d.Resolution = ResolutionOverwritten
+		d.TargetID, err = r.save(ctx, sp.name, sp.single, existingID, obj)
+	case d.Strategy == StrategyDuplicate:
+		d.Resolution = ResolutionDuplicated
+		obj[sp.match] = withSuffix(d.Match, sp.match, r.opts.DuplicateSuffix)
+		d.TargetID, err = r.save(ctx, sp.name, sp.single, 0, obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Later records in the backup may match the one just created
+	if d.Strategy != "" && d.Strategy != StrategyExternalID && existingID == 0 && d.Match != "" {
+		r.matches[sp.name][strings.ToLower(d.Match)] = d.TargetID
+	}
+
+	return d, nil
+}
+
+// existing indexes the records of a resource in the target installation by
+// their match field. The index is built once per restore.
+func (r *restorer) existing(ctx context.Context, sp spec) (map[string]int, error) {
+	if m, ok := r.matches[sp.name]; ok {
+		return m, nil
+	}
+
+	m := make(map[string]int)
+	err := eachRecord(ctx, r.c, sp.name, sp.list, defaultPageSize, func(raw json.RawMessage) error {
+		var rec map[string]any
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return err
+		}
+		if key, _ := rec[sp.match].(string); key != "" {
+			m[strings.ToLower(key)] = recordID(raw)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list existing %s: %w", sp.name, err)
+	}
+
+	r.matches[sp.name] = m
+	return m, nil
+}
+
+func (r *restorer) findByExternalID(ctx context.Context, resource, externalID string) (int, error) {
+	switch resource {
+	case "customers":
+		c, err := r.c.Customers.FindByExternalID(ctx, externalID)
+		if err != nil || c == nil {
+			return 0, err
+		}
+		return c.ID, nil
+	case "tickets":
+		t, err := r.c.Tickets.FindByExternalID(ctx, externalID)
+		if err != nil || t == nil {
+			return 0, err
+		}
+		return t.ID, nil
+	default:
+		return 0, fmt.Errorf("%s have no external ID", resource)
+	}
+}
+
+// withSuffix marks a duplicated name, or an email address using plus
+// addressing so mail still reaches the same mailbox
+func withSuffix(value, field, suffix string) string {
+	if field == "email" {
+		if local, domain, ok := strings.Cut(value, "@"); ok {
+			return local + "+" + suffix + "@" + domain
+		}
+	}
+	return value + " (" + suffix + ")"
+}
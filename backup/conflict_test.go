@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+)
+
+func TestRestoreConflicts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"tags.jsonl":      `{"id":1,"name":"VIP"}` + "\n" + `{"id":2,"name":"billing"}` + "\n",
+		"customers.jsonl": `{"id":5,"email":"jo@example.com"}` + "\n",
+		"tickets.jsonl":   `{"id":10,"subject":"Help"}` + "\n",
+	}
+	manifest := &Manifest{Version: formatVersion}
+	for _, name := range []string{"tags", "customers", "tickets"} {
+		file := name + ".jsonl"
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(files[file]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		manifest.Resources = append(manifest.Resources, ResourceFile{Name: name, File: file})
+	}
+	if err := writeManifest(dir, manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := client.NewMockRoundTripper()
+	mock.AddResponse(http.MethodGet, "/tags.json", http.StatusOK, `{"tags":[{"id":301,"name":"vip"}],"pagination":{}}`)
+	mock.AddResponse(http.MethodPost, "/tags.json", http.StatusCreated, `{"tag":{"id":302}}`)
+	mock.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, `{"customers":[{"id":401,"email":"jo@example.com"}],"pagination":{}}`)
+	mock.AddResponse(http.MethodPost, "/customers.json", http.StatusCreated, `{"customer":{"id":402}}`)
+	mock.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, `{"tickets":[{"id":501,"externalId":"10"}],"pagination":{}}`)
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mock}))
+
+	report, err := Restore(context.Background(), c, dir, RestoreOptions{
+		Conflict:  StrategySkip,
+		Conflicts: map[string]Strategy{"customers": StrategyDuplicate, "tickets": StrategyExternalID},
+	})
+	if err != nil {
+		t.Fatalf("Restore() returned error: %v", err)
+	}
+
+	want := []Decision{
+		{Resource: "tags", ID: 1, Match: "VIP", Strategy: StrategySkip, Resolution: ResolutionKept, TargetID: 301},
+		{Resource: "tags", ID: 2, Match: "billing", Strategy: StrategySkip, Resolution: ResolutionCreated, TargetID: 302},
+		{Resource: "customers", ID: 5, Match: "jo@example.com", Strategy: StrategyDuplicate, Resolution: ResolutionDuplicated, TargetID: 402},
+		{Resource: "tickets", ID: 10, Match: "10", Strategy: StrategyExternalID, Resolution: ResolutionKept, TargetID: 501},
+	}
+	if !reflect.DeepEqual(report.Decisions, want) {
+		t.Errorf("unexpected decisions\n got %+v\nwant %+v", report.Decisions, want)
+	}
+
+	var customer struct {
+		Customer map[string]any `json:"customer"`
+	}
+	for _, req := range mock.GetRequests() {
+		if req.Method == http.MethodPost && req.URL.Path == "/customers.json" {
+			decodeBody(t, req, &customer)
+		}
+	}
+	if customer.Customer["email"] != "jo+restored@example.com" {
+		t.Errorf("expected the duplicate to use a plus address, got %v", customer.Customer["email"])
+	}
+}
+
+func TestParseStrategies(t *testing.T) {
+	def, per, err := ParseStrategies("skip, customers=external-id,tags=overwrite")
+	if err != nil {
+		t.Fatalf("ParseStrategies() returned error: %v", err)
+	}
+	if def != StrategySkip {
+		t.Errorf("expected the default to be skip, got %q", def)
+	}
+	if want := map[string]Strategy{"customers": StrategyExternalID, "tags": StrategyOverwrite}; !reflect.DeepEqual(per, want) {
+		t.Errorf("expected %v, got %v", want, per)
+	}
+
+	if _, _, err := ParseStrategies("tags=merge"); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+	_, err = Restore(context.Background(), nil, t.TempDir(), RestoreOptions{Conflicts: map[string]Strategy{"tags": StrategyExternalID}})
+	if err == nil || err.Error() != "tags cannot be restored with the external-id conflict strategy" {
+		t.Errorf("expected external IDs to be rejected for tags, got %v", err)
+	}
+}
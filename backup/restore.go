@@ -0,0 +1,499 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/migrate"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// RestoreOptions configures Restore
+type RestoreOptions struct {
+	// Resources limits the restore to these resources. Defaults to
+	// everything in the backup. Ticket messages are restored with tickets.
+	Resources []string
+
+	// IDs records the new ID of every restored record, keyed by resource
+	// name and backed up ID. Records already in IDs are skipped, so an
+	// interrupted restore can be resumed by passing the same map, for
+	// example a migrate.FileIDMap. Defaults to an in-memory map.
+	IDs migrate.IDMap
+
+	// Conflict is the strategy for records that already exist in the target
+	// installation, used for every resource it supports that has no entry
+	// in Conflicts. When neither is set records are created without looking
+	// for existing ones.
+	Conflict Strategy
+
+	// Conflicts sets the strategy per resource
+	Conflicts map[string]Strategy
+
+	// DuplicateSuffix is added to the names and emails of records created
+	// by StrategyDuplicate. Defaults to "restored".
+	DuplicateSuffix string
+}
+
+// RestoreReport summarizes a restore
+type RestoreReport struct {
+	// Created counts the records created, or overwritten, per resource
+	Created map[string]int
+	// Skipped counts the records per resource that were already restored
+	// or matched an existing record that was kept
+	Skipped map[string]int
+	// Decisions lists how every restored record was matched against the
+	// target installation, in restore order
+	Decisions []Decision
+	Failures  []Failure
+}
+
+// Restore creates the records of the backup in dir in the installation c
+// talks to. Resources are restored in dependency order and references
+// between records are rewritten to the new IDs; references to records that
+// were not restored, such as customer contacts, are dropped. Records that
+// fail to restore are listed in the report's failures and records that refer
+// to them lose the reference. Records that already exist in the target
+// installation are handled according to the conflict strategies in opts.
+//
+// Restoring users invites them to the installation.
+func Restore(ctx context.Context, c *client.Client, dir string, opts RestoreOptions) (*RestoreReport, error) {
+	if err := validateStrategies(opts); err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IDs == nil {
+		opts.IDs = newMemoryIDMap()
+	}
+	if opts.DuplicateSuffix == "" {
+		opts.DuplicateSuffix = defaultDuplicateSuffix
+	}
+
+	r := &restorer{
+		c:       c,
+		dir:     dir,
+		ids:     opts.IDs,
+		opts:    opts,
+		matches: make(map[string]map[string]int),
+		report:  &RestoreReport{Created: map[string]int{}, Skipped: map[string]int{}},
+	}
+
+	selected := func(name string) bool {
+		return len(opts.Resources) == 0 || slices.Contains(opts.Resources, name)
+	}
+	files := make(map[string]string)
+	for _, rf := range manifest.Resources {
+		files[rf.Name] = rf.File
+	}
+
+	// Attachments go first so that tickets can refer to them
+	if _, ok := files["tickets"]; ok && selected("tickets") {
+		for _, a := range manifest.Attachments {
+			if err := r.restoreAttachment(ctx, a); err != nil {
+				return r.report, err
+			}
+		}
+	}
+
+	for _, s := range specs() {
+		file, ok := files[s.name]
+		if !ok || !selected(s.name) {
+			continue
+		}
+
+		var first map[int]firstMessage
+		if s.name == "tickets" {
+			if first, err = r.firstMessages(files["messages"]); err != nil {
+				return r.report, err
+			}
+		}
+
+		err := eachLine(filepath.Join(dir, file), func(raw json.RawMessage) error {
+			return r.restoreRecord(ctx, s, raw, first)
+		})
+		if err != nil {
+			return r.report, fmt.Errorf("restore %s: %w", s.name, err)
+		}
+
+		if s.name == "tickets" && files["messages"] != "" {
+			err := eachLine(filepath.Join(dir, files["messages"]), func(raw json.RawMessage) error {
+				return r.restoreMessage(ctx, raw, first)
+			})
+			if err != nil {
+				return r.report, fmt.Errorf("restore messages: %w", err)
+			}
+		}
+	}
+
+	return r.report, nil
+}
+
+// validateStrategies checks that every resource with a conflict strategy
+// can be matched with it
+func validateStrategies(opts RestoreOptions) error {
+	if opts.Conflict != "" {
+		if err := opts.Conflict.validate(); err != nil {
+			return err
+		}
+	}
+
+	for name, strategy := range opts.Conflicts {
+		if err := strategy.validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		i := slices.IndexFunc(specs(), func(s spec) bool { return s.name == name })
+		if i < 0 {
+			return fmt.Errorf("unsupported resource %q", name)
+		}
+		if !strategy.supports(specs()[i]) {
+			return fmt.Errorf("%s cannot be restored with the %s conflict strategy", name, strategy)
+		}
+	}
+
+	return nil
+}
+
+type restorer struct {
+	c    *client.Client
+	dir  string
+	ids  migrate.IDMap
+	opts RestoreOptions
+	// matches indexes existing records per resource for conflict handling
+	matches map[string]map[string]int
+	report  *RestoreReport
+}
+
+// firstMessage is the message a ticket was opened with. Tickets are created
+// with it as their body, so it is not restored again as a reply.
+type firstMessage struct {
+	id   int
+	at   time.Time
+	body string
+}
+
+func (r *restorer) restoreRecord(ctx context.Context, s spec, raw json.RawMessage, first map[int]firstMessage) error {
+	oldID := recordID(raw)
+	if _, ok, err := r.ids.Get(ctx, migrate.Kind(s.name), strconv.Itoa(oldID)); err != nil || ok {
+		if ok {
+			r.report.Skipped[s.name]++
+		}
+		return err
+	}
+
+	obj, err := r.prepare(ctx, raw)
+	if err != nil {
+		return err
+	}
+	if fm, ok := first[oldID]; ok && obj["message"] == nil {
+		obj["message"] = fm.body
+	}
+
+	d, err := r.resolve(ctx, s, oldID, obj)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.report.Failures = append(r.report.Failures, Failure{Resource: s.name, ID: oldID, Error: err.Error()})
+		return nil
+	}
+
+	r.report.Decisions = append(r.report.Decisions, *d)
+	if d.Resolution == ResolutionKept {
+		r.report.Skipped[s.name]++
+	} else {
+		r.report.Created[s.name]++
+	}
+	return r.ids.Put(ctx, migrate.Kind(s.name), strconv.Itoa(oldID), d.TargetID)
+}
+
+func (r *restorer) restoreMessage(ctx context.Context, raw json.RawMessage, first map[int]firstMessage) error {
+	var m struct {
+		ID       int              `json:"id"`
+		Ticket   models.EntityRef `json:"ticket"`
+		HTMLBody *string          `json:"htmlBody"`
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return err
+	}
+	if fm, ok := first[m.Ticket.ID]; ok && fm.id == m.ID {
+		return nil
+	}
+
+	if _, ok, err := r.ids.Get(ctx, "messages", strconv.Itoa(m.ID)); err != nil || ok {
+		if ok {
+			r.report.Skipped["messages"]++
+		}
+		return err
+	}
+
+	ticketID, ok, err := r.ids.Get(ctx, "tickets", strconv.Itoa(m.Ticket.ID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		r.report.Failures = append(r.report.Failures, Failure{Resource: "messages", ID: m.ID, Error: fmt.Sprintf("ticket %d was not restored", m.Ticket.ID)})
+		return nil
+	}
+
+	obj, err := r.prepare(ctx, raw)
+	if err != nil {
+		return err
+	}
+	delete(obj, "ticket")
+	if m.HTMLBody != nil && obj["message"] == nil {
+		obj["message"] = *m.HTMLBody
+	}
+
+	newID, err := r.save(ctx, fmt.Sprintf("tickets/%d/messages", ticketID), "message", 0, obj)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.report.Failures = append(r.report.Failures, Failure{Resource: "messages", ID: m.ID, Error: err.Error()})
+		return nil
+	}
+
+	r.report.Created["messages"]++
+	return r.ids.Put(ctx, "messages", strconv.Itoa(m.ID), newID)
+}
+
+func (r *restorer) restoreAttachment(ctx context.Context, a Attachment) error {
+	if _, ok, err := r.ids.Get(ctx, "files", strconv.Itoa(a.FileID)); err != nil || ok {
+		if ok {
+			r.report.Skipped["files"]++
+		}
+		return err
+	}
+
+	fail := func(err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.report.Failures = append(r.report.Failures, Failure{Resource: "files", ID: a.FileID, Error: err.Error()})
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.dir, filepath.FromSlash(a.Path)))
+	if err != nil {
+		return fail(err)
+	}
+
+	fileType, disposition := models.FileTypeAttachment, models.DispositionAttachment
+	ref, err := r.c.Files.Create(ctx, &models.FileResponse{File: models.File{
+		Filename:    &a.Filename,
+		MIMEType:    &a.MIMEType,
+		Type:        &fileType,
+		Disposition: &disposition,
+	}})
+	if err != nil {
+		return fail(err)
+	}
+	if err := r.c.Files.Upload(ctx, ref, data); err != nil {
+		return fail(err)
+	}
+
+	r.report.Created["files"]++
+	return r.ids.Put(ctx, "files", strconv.Itoa(a.FileID), ref.File.ID)
+}
+
+// firstMessages finds the oldest customer-visible message of every ticket in
+// the messages file
+func (r *restorer) firstMessages(file string) (map[int]firstMessage, error) {
+	first := make(map[int]firstMessage)
+	if file == "" {
+		return first, nil
+	}
+
+	err := eachLine(filepath.Join(r.dir, file), func(raw json.RawMessage) error {
+		var m models.Message
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if m.Message == nil || (m.ThreadType != nil && *m.ThreadType == models.ThreadTypeNote) {
+			return nil
+		}
+
+		var at time.Time
+		if m.CreatedAt != nil {
+			at = *m.CreatedAt
+		}
+		if fm, ok := first[m.Ticket.ID]; !ok || at.Before(fm.at) {
+			first[m.Ticket.ID] = firstMessage{id: m.ID, at: at, body: *m.Message}
+		}
+		return nil
+	})
+
+	return first, err
+}
+
+// prepare decodes a backed up record for creating it again: server-managed
+// fields are removed and references are rewritten to the restored IDs
+func (r *restorer) prepare(ctx context.Context, raw json.RawMessage) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	for _, k := range []string{"id", "createdAt", "updatedAt", "createdBy", "updatedBy"} {
+		delete(obj, k)
+	}
+
+	for k, v := range obj {
+		switch v := v.(type) {
+		case map[string]any:
+			ref, ok, err := r.remap(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				obj[k] = ref
+			} else if isRef(v) {
+				delete(obj, k)
+			}
+		case []any:
+			kept := make([]any, 0, len(v))
+			for _, item := range v {
+				m, isMap := item.(map[string]any)
+				if !isMap || !isRef(m) {
+					kept = append(kept, item)
+					continue
+				}
+				ref, ok, err := r.remap(ctx, m)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					kept = append(kept, ref)
+				}
+			}
+			obj[k] = kept
+		}
+	}
+
+	return obj, nil
+}
+
+// remap rewrites a reference to the restored record. It reports false when
+// v is not a reference or refers to a record that was not restored.
+func (r *restorer) remap(ctx context.Context, v map[string]any) (map[string]any, bool, error) {
+	if !isRef(v) {
+		return nil, false, nil
+	}
+
+	typ := v["type"].(string)
+	newID, ok, err := r.ids.Get(ctx, migrate.Kind(typ), v["id"].(json.Number).String())
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return map[string]any{"id": newID, "type": typ}, true, nil
+}
+
+// isRef reports whether v looks like {"id": 1, "type": "tags"}
+func isRef(v map[string]any) bool {
+	_, hasID := v["id"].(json.Number)
+	typ, hasType := v["type"].(string)
+	return hasID && hasType && typ != ""
+}
+
+// save sends obj wrapped in key to path, creating a record or, when id is
+// set, updating that record. It returns the saved record's ID.
+func (r *restorer) save(ctx context.Context, path, key string, id int, obj map[string]any) (int, error) {
+	body, err := json.Marshal(map[string]any{key: obj})
+	if err != nil {
+		return 0, err
+	}
+
+	svc := client.NewService[json.RawMessage, json.RawMessage](r.c, client.NewDefaultPathHandler(path))
+	req := json.RawMessage(body)
+	var resp *json.RawMessage
+	if id > 0 {
+		resp, err = svc.Update(ctx, id, &req)
+	} else {
+		resp, err = svc.Create(ctx, &req)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(*resp, &out); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+	saved := recordID(out[key])
+	if saved <= 0 {
+		return 0, fmt.Errorf("response has no %s ID", key)
+	}
+
+	return saved, nil
+}
+
+// eachLine calls fn with every record in a JSON Lines file
+func eachLine(path string, fn func(json.RawMessage) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := fn(json.RawMessage(bytes.Clone(line))); err != nil {
+			return err
+		}
+	}
+
+	return sc.Err()
+}
+
+// memoryIDMap is a migrate.IDMap that lives for a single restore
+type memoryIDMap struct {
+	mu  sync.Mutex
+	ids map[migrate.Kind]map[string]int
+}
+
+func newMemoryIDMap() *memoryIDMap {
+	return &memoryIDMap{ids: make(map[migrate.Kind]map[string]int)}
+}
+
+func (m *memoryIDMap) Get(_ context.Context, kind migrate.Kind, sourceID string) (int, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, ok := m.ids[kind][sourceID]
+	return id, ok, nil
+}
+
+func (m *memoryIDMap) Put(_ context.Context, kind migrate.Kind, sourceID string, deskID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ids[kind] == nil {
+		m.ids[kind] = make(map[string]int)
+	}
+	m.ids[kind][sourceID] = deskID
+	return nil
+}
@@ -0,0 +1,97 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func BenchmarkDecodeTickets(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		payload := TicketsJSON(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for b.Loop() {
+				var resp models.TicketsResponse
+				if err := json.Unmarshal(payload, &resp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncodeTickets(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		resp := Tickets(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := json.Marshal(resp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilterBuild(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		client.NewFilter().
+			Eq("status", "open").
+			In("inbox", []any{1, 2, 3}).
+			Or(
+				client.NewFilter().Gt("createdAt", "2024-01-01"),
+				client.NewFilter().Lt("updatedAt", "2024-12-31"),
+			).
+			Build()
+	}
+}
+
+func BenchmarkMiddlewareChain(b *testing.B) {
+	noop := func(ctx context.Context, req *http.Request, next client.RequestHandler) (*http.Response, error) {
+		return next(ctx, req)
+	}
+
+	for _, n := range []int{0, 5, 20} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			mockTransport := client.NewMockRoundTripper()
+			mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, models.TicketResponse{})
+
+			opts := []client.Option{client.WithHTTPClient(&http.Client{Transport: mockTransport})}
+			for range n {
+				opts = append(opts, client.WithMiddleware(noop))
+			}
+			c := client.NewClient("https://example.com", opts...)
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := c.Tickets.Get(ctx, 1, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkListTickets(b *testing.B) {
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, string(TicketsJSON(100)))
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := c.Tickets.List(ctx, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Package bench holds the SDK's benchmarks. It has no runtime code of its
+// own beyond the payload generators the benchmarks share.
+//
+// Run the benchmarks and compare against a baseline with benchstat:
+//
+//	go test ./bench -run '^$' -bench . -benchmem -count 10 > new.txt
+//	benchstat old.txt new.txt
+//
+// Every benchmark works with the standard profiling flags, for example:
+//
+//	go test ./bench -run '^$' -bench DecodeTickets -cpuprofile cpu.out -memprofile mem.out
+//	go tool pprof -http :8080 cpu.out
+package bench
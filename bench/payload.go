@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Tickets builds a TicketsResponse with n fully populated tickets, including
+// the included data the API sends alongside them
+func Tickets(n int) models.TicketsResponse {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	resp := models.TicketsResponse{
+		Tickets:    make([]models.Ticket, n),
+		Pagination: models.Pagination{Records: n, PageSize: n, Pages: 1, Page: 1},
+	}
+
+	for i := range resp.Tickets {
+		at := created.Add(time.Duration(i) * time.Minute)
+		resp.Tickets[i] = models.Ticket{
+			BaseEntity:  models.BaseEntity{ID: i + 1, CreatedAt: &at, UpdatedAt: &at},
+			Subject:     ptr(fmt.Sprintf("Ticket %d: unable to log in after password reset", i+1)),
+			PreviewText: ptr("Hi, since this morning I can no longer log in to my account, the page just reloads."),
+			Agent:       &models.EntityRef{ID: i%10 + 1, Type: "users"},
+			Customer:    &models.EntityRef{ID: i + 1000, Type: "customers"},
+			Inbox:       &models.EntityRef{ID: i%3 + 1, Type: "inboxes"},
+			Status:      &models.EntityRef{ID: 1, Type: "ticketstatuses"},
+			Priority:    &models.EntityRef{ID: 2, Type: "ticketpriorities"},
+			Tags:        []models.EntityRef{{ID: 1, Type: "tags"}, {ID: 2, Type: "tags"}},
+			Messages:    []models.EntityRef{{ID: i*2 + 1, Type: "messages"}, {ID: i*2 + 2, Type: "messages"}},
+			CC:          []string{"cc@example.com"},
+		}
+		resp.Included.Customers = append(resp.Included.Customers, models.Customer{
+			BaseEntity: models.BaseEntity{ID: i + 1000},
+			FirstName:  ptr("Jane"),
+			LastName:   ptr("Doe"),
+			Email:      ptr(fmt.Sprintf("customer%d@example.com", i)),
+		})
+	}
+
+	return resp
+}
+
+// TicketsJSON returns Tickets(n) encoded as the API would send it
+func TicketsJSON(n int) []byte {
+	b, err := json.Marshal(Tickets(n))
+	if err != nil {
+		// Tickets only contains marshalable values
+		return nil
+	}
+	return b
+}
+
+func ptr[T any](v T) *T { return &v }
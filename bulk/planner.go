@@ -0,0 +1,180 @@
+// Package bulk paces large batches of API operations against the account's
+// rate limit. Instead of firing every request at once and hoping for the
+// best, a Plan works out up front how long a batch will take, spends the
+// budget that is currently free straight away and spreads the rest evenly
+// over the rate limit window, reporting progress and an updated ETA as it
+// goes.
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+)
+
+const defaultWindow = time.Minute
+
+// Budget is the rate limit a plan has to fit into
+type Budget struct {
+	// Limit is the number of requests allowed per Window
+	Limit int
+	// Remaining is the number of requests that can be made right now
+	// before the limit is reached
+	Remaining int
+	// Window is the rate limit period. Defaults to one minute.
+	Window time.Duration
+}
+
+// BudgetFromUsage reads the current budget from the rate limit headers seen
+// by a client created with client.WithUsageTracking. It reports false when
+// no response has carried rate limit headers yet, for example before the
+// first request.
+func BudgetFromUsage(c *client.Client, window time.Duration) (Budget, bool) {
+	usage := c.Usage()
+	if usage == nil || usage.RateLimit <= 0 {
+		return Budget{}, false
+	}
+	return Budget{Limit: usage.RateLimit, Remaining: usage.RateLimitRemaining, Window: window}, true
+}
+
+// Options configures Schedule
+type Options struct {
+	Budget Budget
+
+	// RequestsPerOperation is the number of requests each operation makes.
+	// Defaults to 1.
+	RequestsPerOperation int
+
+	// Headroom is the number of requests per window left unused for other
+	// clients sharing the API key, such as agents using the Desk UI
+	Headroom int
+
+	// Progress, when set, is called after every operation
+	Progress func(Progress)
+}
+
+// Plan is a paced schedule for a batch of operations
+type Plan struct {
+	// Operations is the number of operations in the batch
+	Operations int
+	// Requests is the number of requests the batch is expected to make
+	Requests int
+	// Burst is the number of operations started straight away, using the
+	// budget that is currently free
+	Burst int
+	// Interval is the delay between the operations after the burst
+	Interval time.Duration
+	// ETA is how long the batch is expected to take, ignoring the time the
+	// requests themselves take
+	ETA time.Duration
+
+	progress func(Progress)
+}
+
+// Progress reports how far a running plan has got
+type Progress struct {
+	Done   int
+	Failed int
+	Total  int
+	// ETA is the expected time left, based on the remaining operations
+	ETA time.Duration
+}
+
+// Result is the outcome of running a plan
+type Result struct {
+	// Done counts the operations that succeeded
+	Done int
+	// Failed maps the index of each failed operation to its error
+	Failed  map[int]error
+	Elapsed time.Duration
+}
+
+// Schedule plans n operations so they fit within opts.Budget. Operations
+// that fit in the remaining budget run immediately; the rest are paced at
+// the rate the limit allows, minus the headroom.
+func Schedule(n int, opts Options) (*Plan, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must not be negative")
+	}
+	if opts.Budget.Limit <= 0 {
+		return nil, fmt.Errorf("opts.Budget.Limit must be greater than 0")
+	}
+	if opts.Budget.Window <= 0 {
+		opts.Budget.Window = defaultWindow
+	}
+	if opts.RequestsPerOperation <= 0 {
+		opts.RequestsPerOperation = 1
+	}
+
+	perWindow := opts.Budget.Limit - opts.Headroom
+	if perWindow < opts.RequestsPerOperation {
+		return nil, fmt.Errorf("a limit of %d with %d headroom leaves no room for operations of %d requests", opts.Budget.Limit, opts.Headroom, opts.RequestsPerOperation)
+	}
+
+	free := max(opts.Budget.Remaining-opts.Headroom, 0)
+	p := &Plan{
+		Operations: n,
+		Requests:   n * opts.RequestsPerOperation,
+		Burst:      min(free/opts.RequestsPerOperation, n),
+		Interval:   opts.Budget.Window * time.Duration(opts.RequestsPerOperation) / time.Duration(perWindow),
+		progress:   opts.Progress,
+	}
+	p.ETA = p.eta(0)
+
+	return p, nil
+}
+
+// eta is the paced time left once done operations have finished
+func (p *Plan) eta(done int) time.Duration {
+	paced := p.Operations - max(p.Burst, done)
+	return time.Duration(max(paced, 0)) * p.Interval
+}
+
+// Run calls fn for every operation, in order, with its index. Operations
+// after the burst wait for their slot. Failed operations are recorded in the
+// result and do not stop the run; cancelling ctx does, returning the
+// operations finished so far.
+func (p *Plan) Run(ctx context.Context, fn func(ctx context.Context, i int) error) (*Result, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("fn is required")
+	}
+
+	start := time.Now()
+	result := &Result{Failed: map[int]error{}}
+
+	// The burst uses up the free budget, so the first paced operation
+	// waits for a slot too
+	next := start.Add(p.Interval)
+	for i := range p.Operations {
+		if i >= p.Burst {
+			if wait := time.Until(next); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					result.Elapsed = time.Since(start)
+					return result, ctx.Err()
+				}
+			}
+			next = time.Now().Add(p.Interval)
+		}
+
+		if err := fn(ctx, i); err != nil {
+			if ctx.Err() != nil {
+				result.Elapsed = time.Since(start)
+				return result, ctx.Err()
+			}
+			result.Failed[i] = err
+		} else {
+			result.Done++
+		}
+
+		if p.progress != nil {
+			p.progress(Progress{Done: result.Done, Failed: len(result.Failed), Total: p.Operations, ETA: p.eta(i + 1)})
+		}
+	}
+
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
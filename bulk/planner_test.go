@@ -0,0 +1,63 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	p, err := Schedule(100, Options{
+		Budget:               Budget{Limit: 120, Remaining: 30, Window: time.Minute},
+		RequestsPerOperation: 2,
+		Headroom:             20,
+	})
+	if err != nil {
+		t.Fatalf("Schedule() returned error: %v", err)
+	}
+
+	// 10 free requests cover 5 operations; the other 95 get 100 requests a
+	// minute, two per operation
+	if p.Requests != 200 || p.Burst != 5 || p.Interval != 1200*time.Millisecond {
+		t.Errorf("unexpected plan %+v", p)
+	}
+	if p.ETA != 95*1200*time.Millisecond {
+		t.Errorf("expected an ETA of 1m54s, got %s", p.ETA)
+	}
+
+	if _, err := Schedule(1, Options{Budget: Budget{Limit: 10}, Headroom: 10}); err == nil {
+		t.Error("expected a budget used up by headroom to be rejected")
+	}
+}
+
+func TestPlanRun(t *testing.T) {
+	p, err := Schedule(4, Options{Budget: Budget{Limit: 100, Remaining: 2, Window: time.Second}})
+	if err != nil {
+		t.Fatalf("Schedule() returned error: %v", err)
+	}
+
+	var progress []Progress
+	p.progress = func(pr Progress) { progress = append(progress, pr) }
+
+	start := time.Now()
+	result, err := p.Run(context.Background(), func(_ context.Context, i int) error {
+		if i == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if result.Done != 3 || result.Failed[1] == nil {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if elapsed := time.Since(start); elapsed < 2*p.Interval {
+		t.Errorf("expected the two paced operations to wait %s, took %s", 2*p.Interval, elapsed)
+	}
+	if len(progress) != 4 || progress[3].ETA != 0 || progress[0].ETA != 2*p.Interval {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+}
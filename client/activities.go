@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ActivityService reads the activity log of tickets: status changes,
+// assignments, merges and everything else Desk records about them
+type ActivityService struct {
+	client *Client
+}
+
+// NewActivityService creates a new activity service
+func NewActivityService(client *Client) *ActivityService {
+	return &ActivityService{client: client}
+}
+
+// ActivityListOptions configures ListByTicket
+type ActivityListOptions struct {
+	// Kinds limits the entries to these kinds. Empty returns every entry.
+	Kinds []models.TicketActivityKind
+	// Since limits the entries to those recorded at or after this time
+	Since time.Time
+}
+
+// ListByTicket returns a ticket's activity log, oldest entry first. The log
+// is side-loaded with the ticket, so it costs a single request.
+func (s *ActivityService) ListByTicket(ctx context.Context, ticketID int, opts *ActivityListOptions) ([]models.TicketActivity, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+	if opts == nil {
+		opts = &ActivityListOptions{}
+	}
+
+	ticket, err := s.client.Tickets.Get(ctx, ticketID, (&GetOptions{Includes: []string{"activities"}}).Values())
+	if err != nil {
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	var activities []models.TicketActivity
+	for _, a := range ticket.Included.Ticketactivities {
+		if a.Ticket.ID != 0 && a.Ticket.ID != ticketID {
+			continue
+		}
+		if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, a.Kind()) {
+			continue
+		}
+		if !opts.Since.IsZero() && eventTime(a.BaseEntity).Before(opts.Since) {
+			continue
+		}
+		activities = append(activities, a)
+	}
+
+	sort.SliceStable(activities, func(i, j int) bool {
+		return eventTime(activities[i].BaseEntity).Before(eventTime(activities[j].BaseEntity))
+	})
+
+	return activities, nil
+}
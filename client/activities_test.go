@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestActivityServiceListByTicket(t *testing.T) {
+	at := func(hour int) *time.Time {
+		v := time.Date(2024, 6, 10, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/9.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 9}},
+		Included: models.IncludedData{
+			Ticketactivities: []models.TicketActivity{
+				{BaseEntity: models.BaseEntity{ID: 1, CreatedAt: at(12)}, EventType: ptr("agentAssigned"), TargetAgent: &models.EntityRef{ID: 4}, Ticket: models.EntityRef{ID: 9}},
+				{BaseEntity: models.BaseEntity{ID: 2, CreatedAt: at(10)}, EventType: ptr("statusChanged"), Ticket: models.EntityRef{ID: 9}},
+				{BaseEntity: models.BaseEntity{ID: 3, CreatedAt: at(11)}, EventType: ptr("ticketMerged"), MergedTicket: &models.EntityRef{ID: 8}, Ticket: models.EntityRef{ID: 9}},
+				{BaseEntity: models.BaseEntity{ID: 4, CreatedAt: at(9)}, EventType: ptr("statusChanged"), Ticket: models.EntityRef{ID: 8}},
+			},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	all, err := c.Activities.ListByTicket(context.Background(), 9, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	wantKinds := []models.TicketActivityKind{
+		models.TicketActivityKindStatusChange,
+		models.TicketActivityKindMerge,
+		models.TicketActivityKindAssignment,
+	}
+	if len(all) != len(wantKinds) {
+		t.Fatalf("expected %d activities for the ticket, got %d", len(wantKinds), len(all))
+	}
+	for i, a := range all {
+		if a.Kind() != wantKinds[i] {
+			t.Errorf("activity %d: expected kind %q, got %q", i, wantKinds[i], a.Kind())
+		}
+	}
+
+	filtered, err := c.Activities.ListByTicket(context.Background(), 9, &ActivityListOptions{
+		Kinds: []models.TicketActivityKind{models.TicketActivityKindStatusChange, models.TicketActivityKindAssignment},
+		Since: *at(11),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != 1 {
+		t.Errorf("expected only the assignment, got %+v", filtered)
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// ErrBatchSkipped is the error recorded for items a batch did not attempt
+// because an earlier item failed and BatchOptions.ContinueOnError was unset
+const ErrBatchSkipped = batchError("skipped after an earlier failure")
+
+type batchError string
+
+func (e batchError) Error() string { return string(e) }
+
+// BatchOptions configures CreateBatch
+type BatchOptions struct {
+	// Concurrency is the maximum number of requests in flight. Defaults
+	// to 4.
+	Concurrency int
+
+	// ContinueOnError attempts every item even after one has failed.
+	// Without it the first failure stops items that have not started yet.
+	ContinueOnError bool
+}
+
+// BatchResult is the outcome for one item of a batch
+type BatchResult[T any] struct {
+	// Index is the item's position in the input
+	Index int
+	// Resource is the created resource, nil when Err is set
+	Resource *T
+	Err      error
+}
+
+// BatchCreator is implemented by services that can create resources in
+// parallel, such as every Service
+type BatchCreator[T any] interface {
+	CreateBatch(ctx context.Context, resources []*T, opts BatchOptions) ([]BatchResult[T], error)
+}
+
+// CreateBatch creates resources in parallel with at most opts.Concurrency
+// requests in flight. The results are in input order. Unless
+// opts.ContinueOnError is set, the first failure is also returned as the
+// error and items not yet started are recorded with ErrBatchSkipped; with it,
+// failures are only recorded in the results.
+func (s *Service[T, L]) CreateBatch(ctx context.Context, resources []*T, opts BatchOptions) ([]BatchResult[T], error) {
+	return runBatch(ctx, resources, opts, s.Create)
+}
+
+// runBatch calls create on every resource with the concurrency and error
+// handling of CreateBatch. Services that validate in their own Create pass
+// it here so batches are validated too.
+func runBatch[T any](ctx context.Context, resources []*T, opts BatchOptions, create func(context.Context, *T) (*T, error)) ([]BatchResult[T], error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultBatchConcurrency
+	}
+
+	results := make([]BatchResult[T], len(resources))
+	for i := range results {
+		results[i] = BatchResult[T]{Index: i, Err: ErrBatchSkipped}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, opts.Concurrency)
+		firstErr error
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil && !opts.ContinueOnError
+	}
+
+	for i, resource := range resources {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return results, ctx.Err()
+		}
+		if failed() {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			created, err := create(ctx, resource)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = BatchResult[T]{Index: i, Resource: created, Err: err}
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("create item %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if opts.ContinueOnError {
+		return results, nil
+	}
+	return results, firstErr
+}
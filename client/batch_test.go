@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestServiceCreateBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		var tag models.TagResponse
+		_ = json.NewDecoder(r.Body).Decode(&tag)
+		if *tag.Tag.Name == "bad" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		tag.Tag.ID = len(*tag.Tag.Name)
+		_ = json.NewEncoder(w).Encode(tag)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	var tags []*models.TagResponse
+	for _, name := range []string{"a", "bb", "bad", "dddd", "eeeee"} {
+		tags = append(tags, &models.TagResponse{Tag: models.Tag{Name: &name}})
+	}
+
+	results, err := c.Tags.CreateBatch(context.Background(), tags, BatchOptions{Concurrency: 2, ContinueOnError: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("expected result %d to have index %d, got %d", i, i, r.Index)
+		}
+		if i == 2 {
+			var apiErr *APIError
+			if !errors.As(r.Err, &apiErr) {
+				t.Errorf("expected item 2 to fail with an APIError, got %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Resource.Tag.ID != len(*tags[i].Tag.Name) {
+			t.Errorf("unexpected result %d: %+v", i, r)
+		}
+	}
+
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", got)
+	}
+}
+
+func TestServiceCreateBatchStopsOnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	tags := make([]*models.TagResponse, 5)
+	for i := range tags {
+		tags[i] = &models.TagResponse{}
+	}
+
+	results, err := c.Tags.CreateBatch(context.Background(), tags, BatchOptions{Concurrency: 1})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if results[0].Err == nil || errors.Is(results[0].Err, ErrBatchSkipped) {
+		t.Errorf("expected the first item to fail, got %v", results[0].Err)
+	}
+	for _, r := range results[1:] {
+		if !errors.Is(r.Err, ErrBatchSkipped) {
+			t.Errorf("expected item %d to be skipped, got %v", r.Index, r.Err)
+		}
+	}
+}
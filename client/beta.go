@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrBetaDisabled is returned, wrapped with the endpoint's path, when a beta
+// endpoint is called on a client created without WithBetaEndpoints. Check for
+// it with errors.Is.
+const ErrBetaDisabled = betaError("beta endpoints are disabled")
+
+type betaError string
+
+func (e betaError) Error() string { return string(e) }
+
+// WithBetaEndpoints allows calls to endpoints Desk has not declared stable
+// yet, such as reports. Their requests and responses may change without
+// notice, so production code should not depend on them; enable them per
+// environment, for example only in staging or for early adopters.
+func WithBetaEndpoints() Option {
+	return func(c *Client) {
+		c.beta = true
+	}
+}
+
+// BetaEndpointsEnabled reports whether the client was created with
+// WithBetaEndpoints
+func (c *Client) BetaEndpointsEnabled() bool {
+	return c.beta
+}
+
+// checkBeta refuses requests to beta endpoints unless they are enabled
+func (c *Client) checkBeta(req *http.Request) error {
+	if c.beta {
+		return nil
+	}
+
+	path := req.URL.Path
+	if base, err := url.Parse(c.baseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	path = strings.TrimPrefix(path, "/")
+
+	if isBetaEndpoint(path) {
+		return fmt.Errorf("%s: %w", path, ErrBetaDisabled)
+	}
+
+	return nil
+}
+
+// isBetaEndpoint reports whether path, relative to the API base URL, belongs
+// to a beta endpoint
+func isBetaEndpoint(path string) bool {
+	switch {
+	case strings.HasPrefix(path, "reports/"), strings.HasPrefix(path, "reports."):
+		return true
+	default:
+		return false
+	}
+}
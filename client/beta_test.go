@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBetaEndpoints(t *testing.T) {
+	mock := NewMockRoundTripper()
+	mock.AddResponse(http.MethodGet, "/desk/api/v2/reports/tickets.json", http.StatusOK, `{}`)
+	mock.AddResponse(http.MethodGet, "/desk/api/v2/tags.json", http.StatusOK, `{"tags":[]}`)
+
+	newReports := func(c *Client) *Service[json.RawMessage, json.RawMessage] {
+		return NewService[json.RawMessage, json.RawMessage](c, NewDefaultPathHandler("reports/tickets"))
+	}
+
+	c := NewClient("https://example.com/desk/api/v2", WithHTTPClient(&http.Client{Transport: mock}))
+	if _, err := newReports(c).List(context.Background(), nil); !errors.Is(err, ErrBetaDisabled) {
+		t.Fatalf("expected ErrBetaDisabled, got %v", err)
+	}
+	if _, err := c.Tags.List(context.Background(), nil); err != nil {
+		t.Fatalf("expected stable endpoints to work, got %v", err)
+	}
+	if n := len(mock.GetRequests()); n != 1 {
+		t.Fatalf("expected only the stable request to be sent, got %d requests", n)
+	}
+
+	c = NewClient("https://example.com/desk/api/v2", WithHTTPClient(&http.Client{Transport: mock}), WithBetaEndpoints())
+	if _, err := newReports(c).List(context.Background(), nil); err != nil {
+		t.Fatalf("expected beta endpoints to be allowed, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BudgetMiddlewareName is the name the request budget is registered under
+const BudgetMiddlewareName = "budget"
+
+// defaultBudgetInterval is used when WithRequestBudget is given an interval
+// of 0 or less
+const defaultBudgetInterval = time.Minute
+
+// BudgetExceededError is returned, without sending the request, once a
+// client has used up its request budget for the current interval
+type BudgetExceededError struct {
+	Limit    int
+	Interval time.Duration
+	// ResetAt is when the budget is renewed
+	ResetAt time.Time
+}
+
+// Error implements error
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("request budget of %d per %s exceeded, resets at %s", e.Limit, e.Interval, e.ResetAt.Format(time.RFC3339))
+}
+
+// WithRequestBudget caps the client at n requests per interval, counting
+// every request actually sent, retries included. Requests over the cap fail
+// immediately with a *BudgetExceededError instead of waiting, which protects
+// a shared installation from runaway scripts. This is independent of, and
+// usually well below, Desk's own rate limits. Windows are fixed: the first
+// starts with the first request. An n below 1 is raised to 1, and an
+// interval of 0 or less falls back to one minute, so a misconfigured budget
+// still caps the client rather than blocking or allowing everything.
+func WithRequestBudget(n int, interval time.Duration) Option {
+	if interval <= 0 {
+		interval = defaultBudgetInterval
+	}
+	b := &requestBudget{limit: max(n, 1), interval: interval, now: time.Now}
+	// Innermost apart from HAR recording, so retries made by other
+	// middleware are counted
+	return WithMiddlewarePriority(BudgetMiddlewareName, math.MaxInt-1, b.middleware())
+}
+
+type requestBudget struct {
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	used        int
+}
+
+// take uses one request from the budget, or reports why it cannot
+func (b *requestBudget) take() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if b.windowStart.IsZero() || !now.Before(b.windowStart.Add(b.interval)) {
+		b.windowStart = now
+		b.used = 0
+	}
+
+	if b.used >= b.limit {
+		return &BudgetExceededError{Limit: b.limit, Interval: b.interval, ResetAt: b.windowStart.Add(b.interval)}
+	}
+
+	b.used++
+	return nil
+}
+
+func (b *requestBudget) middleware() MiddlewareFunc {
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		if err := b.take(); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestBudget(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	b := &requestBudget{limit: 2, interval: time.Minute, now: func() time.Time { return now }}
+
+	for i := range 2 {
+		if err := b.take(); err != nil {
+			t.Fatalf("request %d: expected budget to allow it, got %v", i+1, err)
+		}
+	}
+
+	err := b.take()
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected *BudgetExceededError, got %v", err)
+	}
+	if want := now.Add(time.Minute); !exceeded.ResetAt.Equal(want) {
+		t.Errorf("expected reset at %s, got %s", want, exceeded.ResetAt)
+	}
+
+	now = now.Add(time.Minute)
+	if err := b.take(); err != nil {
+		t.Fatalf("expected the budget to renew after the interval, got %v", err)
+	}
+}
+
+func TestWithRequestBudget(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, map[string]any{"ticket": map[string]any{"id": 1}})
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithRequestBudget(3, time.Hour),
+	)
+
+	var exceeded int
+	for range 5 {
+		_, err := c.Tickets.Get(context.Background(), 1, nil)
+		var budgetErr *BudgetExceededError
+		if errors.As(err, &budgetErr) {
+			exceeded++
+		}
+	}
+
+	if exceeded != 2 {
+		t.Errorf("expected 2 requests over budget, got %d", exceeded)
+	}
+	if got := len(mockTransport.GetRequests()); got != 3 {
+		t.Errorf("expected 3 requests to be sent, got %d", got)
+	}
+}
+
+func TestWithRequestBudgetInvalid(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, map[string]any{"ticket": map[string]any{"id": 1}})
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithRequestBudget(0, -time.Second),
+	)
+
+	if _, err := c.Tickets.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected n to be raised to 1, got %v", err)
+	}
+
+	_, err := c.Tickets.Get(context.Background(), 1, nil)
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected *BudgetExceededError, got %v", err)
+	}
+	if exceeded.Limit != 1 || exceeded.Interval != defaultBudgetInterval {
+		t.Errorf("expected a budget of 1 per %s, got %d per %s", defaultBudgetInterval, exceeded.Limit, exceeded.Interval)
+	}
+}
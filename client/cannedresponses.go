@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// CannedResponseService handles saved replies
+type CannedResponseService struct {
+	*Service[models.CannedResponseResponse, models.CannedResponsesResponse]
+}
+
+// NewCannedResponseService creates a new canned response service
+func NewCannedResponseService(client *Client) *CannedResponseService {
+	return &CannedResponseService{
+		Service: NewService[models.CannedResponseResponse, models.CannedResponsesResponse](client, NewDefaultPathHandler("cannedresponses")),
+	}
+}
+
+// Get retrieves a canned response by ID
+func (s *CannedResponseService) Get(ctx context.Context, id int, params url.Values) (*models.CannedResponseResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of canned responses with optional filters
+func (s *CannedResponseService) List(ctx context.Context, params url.Values) (*models.CannedResponsesResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Create creates a new canned response
+func (s *CannedResponseService) Create(ctx context.Context, cannedResponse *models.CannedResponseResponse) (*models.CannedResponseResponse, error) {
+	return s.Service.Create(ctx, cannedResponse)
+}
+
+// Update updates an existing canned response
+func (s *CannedResponseService) Update(ctx context.Context, id int, cannedResponse *models.CannedResponseResponse) (*models.CannedResponseResponse, error) {
+	return s.Service.Update(ctx, id, cannedResponse)
+}
+
+// Delete deletes a canned response
+func (s *CannedResponseService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
+
+// CannedResponseFolderService handles the folders canned responses are
+// organized in
+type CannedResponseFolderService struct {
+	*Service[models.CannedResponseFolderResponse, models.CannedResponseFoldersResponse]
+}
+
+// NewCannedResponseFolderService creates a new canned response folder service
+func NewCannedResponseFolderService(client *Client) *CannedResponseFolderService {
+	return &CannedResponseFolderService{
+		Service: NewService[models.CannedResponseFolderResponse, models.CannedResponseFoldersResponse](client, NewDefaultPathHandler("cannedresponsefolders")),
+	}
+}
+
+// Get retrieves a canned response folder by ID
+func (s *CannedResponseFolderService) Get(ctx context.Context, id int, params url.Values) (*models.CannedResponseFolderResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of canned response folders with optional filters
+func (s *CannedResponseFolderService) List(ctx context.Context, params url.Values) (*models.CannedResponseFoldersResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Create creates a new canned response folder
+func (s *CannedResponseFolderService) Create(ctx context.Context, folder *models.CannedResponseFolderResponse) (*models.CannedResponseFolderResponse, error) {
+	return s.Service.Create(ctx, folder)
+}
+
+// Update updates an existing canned response folder
+func (s *CannedResponseFolderService) Update(ctx context.Context, id int, folder *models.CannedResponseFolderResponse) (*models.CannedResponseFolderResponse, error) {
+	return s.Service.Update(ctx, id, folder)
+}
+
+// Delete deletes a canned response folder. Depending on the installation,
+// the API may refuse to delete a folder that still holds canned responses.
+func (s *CannedResponseFolderService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
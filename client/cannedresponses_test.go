@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCannedResponseService(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/cannedresponses.json", http.StatusOK,
+		`{"cannedResponses":[{"id":1,"name":"Thanks"},{"id":2,"name":"Refund"}],"included":{"cannedresponsefolders":[]},"pagination":{"page":1}}`)
+	mockTransport.AddResponse(http.MethodPatch, "/cannedresponses/2.json", http.StatusOK, `{"cannedResponse":{"id":2,"name":"Refunds"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	var names []string
+	for r, err := range c.CannedResponses.Iter(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		names = append(names, *r.Item.Name)
+	}
+	if len(names) != 2 || names[1] != "Refund" {
+		t.Errorf("expected both canned responses, got %v", names)
+	}
+
+	updated, err := c.CannedResponses.Patch(context.Background(), 2, map[string]string{"name": "Refunds"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if *updated.Item.Name != "Refunds" {
+		t.Errorf("expected the renamed canned response, got %+v", updated.Item)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[1].Body)
+	if string(b) != `{"cannedResponse":{"name":"Refunds"}}` {
+		t.Errorf("expected the patch wrapped in the envelope key, got %s", b)
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Middleware is an entry in the client's middleware chain. The chain runs in
+// ascending Priority order, so the lowest priority runs first and sees the
+// request before everything else; entries with equal priority run in the order
+// they were added.
+type Middleware struct {
+	Name     string
+	Priority int
+	Func     MiddlewareFunc
+}
+
+// WithNamedMiddleware adds middleware under a name so it can later be found,
+// reordered or removed. It is added with priority 0.
+func WithNamedMiddleware(name string, mw MiddlewareFunc) Option {
+	return WithMiddlewarePriority(name, 0, mw)
+}
+
+// WithMiddlewarePriority adds named middleware at an explicit position in the
+// chain. For example, give a retry middleware a lower priority than a rate
+// limiter so every attempt is rate limited, or a higher one so that only the
+// first attempt is.
+func WithMiddlewarePriority(name string, priority int, mw MiddlewareFunc) Option {
+	return func(c *Client) {
+		c.UseMiddleware(name, priority, mw)
+	}
+}
+
+// WithMiddlewareBefore adds named middleware directly in front of the
+// middleware called before, so it runs first. If there is no middleware
+// called before it is added at the end of the chain.
+func WithMiddlewareBefore(before, name string, mw MiddlewareFunc) Option {
+	return func(c *Client) {
+		if err := c.InsertMiddlewareBefore(before, name, mw); err != nil {
+			c.UseMiddleware(name, 0, mw)
+		}
+	}
+}
+
+// Middlewares returns the middleware chain in execution order
+func (c *Client) Middlewares() []Middleware {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]Middleware(nil), c.middleware...)
+}
+
+// UseMiddleware adds named middleware at the given priority. It can be called
+// after the client has been created; requests already in flight keep the
+// chain they started with.
+func (c *Client) UseMiddleware(name string, priority int, mw MiddlewareFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.middleware = append(c.middleware, Middleware{Name: name, Priority: priority, Func: mw})
+	sort.SliceStable(c.middleware, func(i, j int) bool {
+		return c.middleware[i].Priority < c.middleware[j].Priority
+	})
+}
+
+// InsertMiddlewareBefore adds named middleware directly in front of the
+// middleware called before, taking on its priority
+func (c *Client) InsertMiddlewareBefore(before, name string, mw MiddlewareFunc) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, m := range c.middleware {
+		if m.Name != before {
+			continue
+		}
+
+		entry := Middleware{Name: name, Priority: m.Priority, Func: mw}
+		c.middleware = append(c.middleware[:i], append([]Middleware{entry}, c.middleware[i:]...)...)
+		return nil
+	}
+
+	return fmt.Errorf("middleware %q not found", before)
+}
+
+// RemoveMiddleware removes every middleware called name and reports whether
+// any was found
+func (c *Client) RemoveMiddleware(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.middleware[:0:0]
+	for _, m := range c.middleware {
+		if m.Name != name {
+			kept = append(kept, m)
+		}
+	}
+
+	removed := len(kept) != len(c.middleware)
+	c.middleware = kept
+	return removed
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, models.TicketResponse{})
+
+	var order []string
+	record := func(name string) MiddlewareFunc {
+		return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+			order = append(order, name)
+			return next(ctx, req)
+		}
+	}
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithNamedMiddleware("logging", record("logging")),
+		WithMiddlewarePriority("retry", 10, record("retry")),
+		WithMiddlewarePriority("auth", -10, record("auth")),
+		WithMiddlewareBefore("retry", "ratelimit", record("ratelimit")),
+	)
+
+	var names []string
+	for _, m := range c.Middlewares() {
+		names = append(names, m.Name)
+	}
+	want := []string{"auth", "logging", "ratelimit", "retry"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("expected chain %v, got %v", want, names)
+	}
+
+	if !c.RemoveMiddleware("logging") {
+		t.Fatalf("expected logging middleware to be removed")
+	}
+	c.UseMiddleware("metrics", 5, record("metrics"))
+
+	if _, err := c.Tickets.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want = []string{"auth", "metrics", "ratelimit", "retry"}
+	if !slices.Equal(order, want) {
+		t.Errorf("expected execution order %v, got %v", want, order)
+	}
+
+	if err := c.InsertMiddlewareBefore("missing", "x", record("x")); err == nil {
+		t.Errorf("expected an error inserting before unknown middleware")
+	}
+}
@@ -1,40 +1,74 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/teamwork/desksdkgo/models"
 )
 
-// Client represents the Desk API client
+// Client represents the Desk API client. A Client is safe for concurrent use
+// by multiple goroutines and should be reused rather than created per request.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	logLevel   slog.Level
-	logger     *slog.Logger
-	httpClient *http.Client
-	middleware []MiddlewareFunc
+	baseURL     string
+	credentials Credentials
+	logLevel    slog.Level
+	logger      *slog.Logger
+	httpClient  *http.Client
+	rootCAs     *x509.CertPool
+	proxy       func(*http.Request) (*url.URL, error)
+	hooks       []hooks
+	usage       *usageTracker
+	journal     *journal
+	beta        bool
+
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+
+	mu         sync.RWMutex
+	middleware []Middleware
 
 	// Services
-	BusinessHours    *BusinessHourService
-	Companies        *CompanyService
-	Customers        *CustomerService
-	Files            *FileService
-	HelpDocArticles  *HelpDocArticleService
-	HelpDocSites     *HelpDocSiteService
-	Inboxes          *InboxService
-	Messages         *MessageService
-	SLAs             *SLAService
-	Spamlists        *SpamlistService
-	Tags             *TagService
-	TicketPriorities *TicketPriorityService
-	Tickets          *TicketService
-	TicketSources    *TicketSourceService
-	TicketStatuses   *TicketStatusService
-	TicketTypes      *TicketTypeService
-	Users            *UserService
+	Activities            *ActivityService
+	BusinessHours         *BusinessHourService
+	CannedResponses       *CannedResponseService
+	CannedResponseFolders *CannedResponseFolderService
+	Companies             *CompanyService
+	Contacts              *ContactService
+	Customers             *CustomerService
+	CustomFields          *CustomFieldService
+	Files                 *FileService
+	Happiness             *HappinessService
+	HelpDocArticles       *HelpDocArticleService
+	HelpDocCategories     *HelpDocCategoryService
+	HelpDocComments       *HelpDocCommentService
+	HelpDocSites          *HelpDocSiteService
+	Inboxes               *InboxService
+	Messages              *MessageService
+	Notes                 *NoteService
+	Reports               *ReportService
+	Roles                 *RoleService
+	SLAs                  *SLAService
+	Spamlists             *SpamlistService
+	Tags                  *TagService
+	TicketFilters         *TicketFilterService
+	TicketPriorities      *TicketPriorityService
+	Tickets               *TicketService
+	TicketSources         *TicketSourceService
+	TicketStatuses        *TicketStatusService
+	TicketTypes           *TicketTypeService
+	TimeLogs              *TimeLogService
+	Users                 *UserService
+	Webhooks              *WebhookService
 }
 
 // MiddlewareFunc represents a middleware function that can modify requests before they are sent
@@ -53,11 +87,10 @@ type Config struct {
 // Option is a function that configures a Client
 type Option func(*Client)
 
-// WithAPIKey sets the API key for the client
+// WithAPIKey sets the API key for the client. Use WithCredentials for keys
+// that can change while the client is running.
 func WithAPIKey(apiKey string) Option {
-	return func(c *Client) {
-		c.apiKey = apiKey
-	}
+	return WithCredentials(StaticCredentials(apiKey))
 }
 
 // WithHTTPClient sets a custom HTTP client
@@ -81,11 +114,12 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
-// WithMiddleware adds middleware to the client
+// WithMiddleware adds unnamed middleware to the client with priority 0.
+// Middleware runs in the order it is added: the first one added sees the
+// request first and the response last. Use WithNamedMiddleware or
+// WithMiddlewarePriority to control the order explicitly.
 func WithMiddleware(mw MiddlewareFunc) Option {
-	return func(c *Client) {
-		c.middleware = append(c.middleware, mw)
-	}
+	return WithMiddlewarePriority("", 0, mw)
 }
 
 // NewClient creates a new Desk.com API client
@@ -100,35 +134,56 @@ func NewClient(baseURL string, opts ...Option) *Client {
 
 	if client.httpClient == nil {
 		client.httpClient = NewLoggingClientWithLogger(client.logLevel, client.logger)
+		if client.proxy != nil || client.rootCAs != nil {
+			client.httpClient.Transport.(*LoggingTransport).Transport = newTransport(client.proxy, client.rootCAs)
+		}
 	}
 
 	// Initialize services
+	client.Activities = NewActivityService(client)
 	client.BusinessHours = NewBusinessHourService(client)
+	client.CannedResponses = NewCannedResponseService(client)
+	client.CannedResponseFolders = NewCannedResponseFolderService(client)
 	client.Companies = NewCompanyService(client)
+	client.Contacts = NewContactService(client)
 	client.Customers = NewCustomerService(client)
+	client.CustomFields = NewCustomFieldService(client)
 	client.Files = NewFileService(client)
+	client.Happiness = NewHappinessService(client)
 	client.HelpDocArticles = NewHelpDocArticleService(client)
+	client.HelpDocCategories = NewHelpDocCategoryService(client)
+	client.HelpDocComments = NewHelpDocCommentService(client)
 	client.HelpDocSites = NewHelpDocSiteService(client)
 	client.Inboxes = NewInboxService(client)
 	client.Messages = NewMessageService(client)
+	client.Notes = NewNoteService(client)
+	client.Reports = NewReportService(client)
+	client.Roles = NewRoleService(client)
 	client.SLAs = NewSLAService(client)
 	client.Spamlists = NewSpamlistService(client)
 	client.Tags = NewTagService(client)
+	client.TicketFilters = NewTicketFilterService(client)
 	client.TicketPriorities = NewTicketPriorityService(client)
 	client.Tickets = NewTicketService(client)
 	client.TicketSources = NewTicketSourceService(client)
 	client.TicketStatuses = NewTicketStatusService(client)
 	client.TicketTypes = NewTicketTypeService(client)
+	client.TimeLogs = NewTimeLogService(client)
 	client.Users = NewUserService(client)
+	client.Webhooks = NewWebhookService(client)
 
 	return client
 }
 
 // doRequest performs an HTTP request with the client's configuration
 func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.checkBeta(req); err != nil {
+		return nil, err
+	}
+
 	// Add API key if set
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, err
 	}
 
 	// Add content type
@@ -137,43 +192,143 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 	// Add accept header
 	req.Header.Set("Accept", "application/json")
 
+	// Carry a caller-supplied request ID even without RequestIDMiddleware
+	if id, ok := RequestIDFromContext(ctx); ok && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	c.runBeforeHooks(ctx, req)
+	event := c.runRequestHooks(ctx, req)
+
 	finalHandler := func(ctx context.Context, req *http.Request) (*http.Response, error) {
 		return c.httpClient.Do(req)
 	}
 
+	chain := c.Middlewares()
 	handler := finalHandler
-	for i := len(c.middleware) - 1; i >= 0; i-- {
-		middleware := c.middleware[i]
+	for i := len(chain) - 1; i >= 0; i-- {
+		middleware := chain[i].Func
 		next := handler
 		handler = func(ctx context.Context, req *http.Request) (*http.Response, error) {
 			return middleware(ctx, req, next)
 		}
 	}
 
-	return handler(ctx, req)
+	resp, err := handler(ctx, req)
+	c.runResponseHooks(ctx, event, resp, err)
+	if err == nil {
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.invalidateCredentials()
+		}
+		c.runAfterHooks(ctx, resp)
+	}
+
+	return resp, err
+}
+
+// BaseURL returns the API base URL the client was created with, for use with
+// the WebURL helpers on models
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// WebURL returns the Desk UI page for a resource, such as ("tickets", 123),
+// on the installation the client talks to
+func (c *Client) WebURL(resource string, id int) (string, error) {
+	return models.WebURL(c.baseURL, resource, id)
+}
+
+// logError logs an error with structured fields if a logger is available
+func (c *Client) logError(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if c != nil && c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelError, msg, attrs...)
+	}
+}
+
+// request sends a JSON request to path, which is relative to the base URL and
+// should include the .json suffix and any query string. body is marshaled when
+// non-nil, and a successful response is decoded into out when out is non-nil.
+// Any 2xx status is treated as success.
+func (c *Client) request(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			c.logError(ctx, "failed to marshal request body", slog.Any("error", err))
+			return err
+		}
+		reader = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", c.baseURL, path), reader)
+	if err != nil {
+		c.logError(ctx, "failed to create request", slog.Any("error", err))
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		c.logError(ctx, "request failed", slog.Any("error", err), slog.String("method", method), slog.String("url", req.URL.String()), requestIDAttr(req))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(resp.Body)
+		c.logError(ctx, "unexpected status code",
+			slog.Int("status_code", resp.StatusCode),
+			slog.String("method", method),
+			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
+			slog.String("response_body", string(b)),
+		)
+		return newAPIError(req, resp, b)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		c.logError(ctx, "failed to decode response",
+			slog.Any("error", err),
+			slog.String("method", method),
+			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
+		)
+		return err
+	}
+
+	return nil
 }
 
 // GetOptions represents options for single-resource get operations
 type GetOptions struct {
-	Fields   string
-	Includes string
+	Fields string
+	// Includes lists the related resources to side-load, for example
+	// "customers" or "tags". Nil side-loads everything; an empty, non-nil
+	// slice side-loads nothing, which keeps ticket payloads small.
+	Includes []string
 }
 
 // Values builds a url.Values from the options. Defaults includes to "all" when unset.
 func (o *GetOptions) Values() url.Values {
 	v := url.Values{}
-	includes := "all"
-	if o != nil && o.Includes != "" {
-		includes = o.Includes
+	switch {
+	case o == nil || o.Includes == nil:
+		v.Set("includes", "all")
+	case len(o.Includes) > 0:
+		v.Set("includes", strings.Join(o.Includes, ","))
 	}
-	v.Set("includes", includes)
 	if o != nil && o.Fields != "" {
 		v.Set("fields", o.Fields)
 	}
 	return v
 }
 
-// ListOptions represents options for list operations
+// ListOptions represents options for list operations. PerPage, SortBy and
+// SortDir are sent as the API's pageSize, orderBy and orderMode, the same as
+// PageParams.
 type ListOptions struct {
 	Page    int
 	PerPage int
@@ -182,27 +337,29 @@ type ListOptions struct {
 	Embed   string
 	Fields  string
 	Q       string
+	// Includes lists the related resources to side-load. List sends no
+	// includes unless asked, so nil side-loads nothing.
+	Includes []string
 }
 
 // Encode encodes the options into a query string
 func (o *ListOptions) Encode() string {
+	return o.Values().Encode()
+}
+
+// Values builds a url.Values from the options for use with Service.List
+func (o *ListOptions) Values() url.Values {
 	if o == nil {
-		return ""
+		return url.Values{}
 	}
 
-	v := url.Values{}
-	if o.Page > 0 {
-		v.Set("page", strconv.Itoa(o.Page))
-	}
-	if o.PerPage > 0 {
-		v.Set("per_page", strconv.Itoa(o.PerPage))
-	}
-	if o.SortBy != "" {
-		v.Set("sort_by", o.SortBy)
-	}
-	if o.SortDir != "" {
-		v.Set("sort_dir", o.SortDir)
-	}
+	v := PageParams{
+		Page:      o.Page,
+		PageSize:  o.PerPage,
+		OrderBy:   o.SortBy,
+		OrderMode: OrderMode(o.SortDir),
+		Includes:  o.Includes,
+	}.values()
 	if o.Embed != "" {
 		v.Set("embed", o.Embed)
 	}
@@ -213,5 +370,5 @@ func (o *ListOptions) Encode() string {
 		v.Set("q", o.Q)
 	}
 
-	return v.Encode()
+	return v
 }
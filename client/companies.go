@@ -2,7 +2,10 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -10,12 +13,14 @@ import (
 // CompanyService handles company-related operations
 type CompanyService struct {
 	*Service[models.CompanyResponse, models.CompaniesResponse]
+	client *Client
 }
 
 // NewCompanyService creates a new company service
 func NewCompanyService(client *Client) *CompanyService {
 	return &CompanyService{
 		Service: NewService[models.CompanyResponse, models.CompaniesResponse](client, NewDefaultPathHandler("companies")),
+		client:  client,
 	}
 }
 
@@ -38,3 +43,238 @@ func (s *CompanyService) Create(ctx context.Context, company *models.CompanyResp
 func (s *CompanyService) Update(ctx context.Context, id int, company *models.CompanyResponse) (*models.CompanyResponse, error) {
 	return s.Service.Update(ctx, id, company)
 }
+
+// Notes lists the notes on a company
+func (s *CompanyService) Notes(ctx context.Context, companyID int, params url.Values) (*models.CompanyNotesResponse, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("companies/%d/notes.json", companyID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.CompanyNotesResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AddNote adds a note to a company
+func (s *CompanyService) AddNote(ctx context.Context, companyID int, body string) (*models.CompanyNote, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	if body == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	req := models.CompanyNoteResponse{Note: models.CompanyNote{Body: &body}}
+
+	var resp models.CompanyNoteResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("companies/%d/notes.json", companyID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Note, nil
+}
+
+// RemoveNote deletes a note from a company
+func (s *CompanyService) RemoveNote(ctx context.Context, companyID, noteID int) error {
+	if companyID <= 0 {
+		return fmt.Errorf("companyID must be greater than 0")
+	}
+
+	if noteID <= 0 {
+		return fmt.Errorf("noteID must be greater than 0")
+	}
+
+	return s.request(ctx, http.MethodDelete, fmt.Sprintf("companies/%d/notes/%d.json", companyID, noteID), nil, nil)
+}
+
+// Merge merges duplicate companies into primaryID. The duplicates'
+// customers, domains, notes and tags move to the primary company and the
+// duplicates are deleted. The merged company is returned.
+func (s *CompanyService) Merge(ctx context.Context, primaryID int, duplicateIDs []int) (*models.CompanyResponse, error) {
+	if err := validateMergeIDs(primaryID, duplicateIDs); err != nil {
+		return nil, err
+	}
+
+	body := map[string][]int{"ids": duplicateIDs}
+
+	var resp models.CompanyResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("companies/%d/merge.json", primaryID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ListDomains lists the email domains of a company. Customers whose email
+// address is on one of them are added to the company automatically.
+func (s *CompanyService) ListDomains(ctx context.Context, companyID int, params url.Values) (*models.CompanyDomainsResponse, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("companies/%d/domains.json", companyID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.CompanyDomainsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// AddDomain adds an email domain, such as "example.com", to a company. A
+// leading "@" is dropped and the domain is lowercased.
+func (s *CompanyService) AddDomain(ctx context.Context, companyID int, domain string) (*models.Domain, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+	if domain == "" {
+		return nil, fmt.Errorf("domain is required")
+	}
+	if strings.ContainsAny(domain, "@/ ") || !strings.Contains(domain, ".") {
+		return nil, fmt.Errorf("domain %q is not a valid domain name", domain)
+	}
+
+	req := models.CompanyDomainResponse{Item: models.Domain{Name: &domain}}
+
+	var resp models.CompanyDomainResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("companies/%d/domains.json", companyID), req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Item, nil
+}
+
+// RemoveDomain removes an email domain from a company. Customers already in
+// the company stay in it.
+func (s *CompanyService) RemoveDomain(ctx context.Context, companyID, domainID int) error {
+	if companyID <= 0 {
+		return fmt.Errorf("companyID must be greater than 0")
+	}
+
+	if domainID <= 0 {
+		return fmt.Errorf("domainID must be greater than 0")
+	}
+
+	return s.request(ctx, http.MethodDelete, fmt.Sprintf("companies/%d/domains/%d.json", companyID, domainID), nil, nil)
+}
+
+// AddTags adds tags to a company, keeping the tags it already has
+func (s *CompanyService) AddTags(ctx context.Context, companyID int, tagIDs ...int) error {
+	if companyID <= 0 {
+		return fmt.Errorf("companyID must be greater than 0")
+	}
+
+	if len(tagIDs) == 0 {
+		return fmt.Errorf("tagIDs is required")
+	}
+
+	tags := make([]models.EntityRef, len(tagIDs))
+	for i, id := range tagIDs {
+		if id <= 0 {
+			return fmt.Errorf("tagIDs[%d] must be greater than 0", i)
+		}
+		tags[i] = models.EntityRef{ID: id}
+	}
+
+	body := map[string][]models.EntityRef{"tags": tags}
+
+	return s.request(ctx, http.MethodPost, fmt.Sprintf("companies/%d/tags.json", companyID), body, nil)
+}
+
+// RemoveTag removes a tag from a company
+func (s *CompanyService) RemoveTag(ctx context.Context, companyID, tagID int) error {
+	if companyID <= 0 {
+		return fmt.Errorf("companyID must be greater than 0")
+	}
+
+	if tagID <= 0 {
+		return fmt.Errorf("tagID must be greater than 0")
+	}
+
+	return s.request(ctx, http.MethodDelete, fmt.Sprintf("companies/%d/tags/%d.json", companyID, tagID), nil, nil)
+}
+
+// SetInboxes restricts the company's customers to the given inboxes in the
+// portal. Calling it without inbox IDs lifts the restriction.
+func (s *CompanyService) SetInboxes(ctx context.Context, companyID int, inboxIDs ...int) (*models.CompanyResponse, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	inboxes := make([]models.EntityRef, len(inboxIDs))
+	for i, id := range inboxIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("inboxIDs[%d] must be greater than 0", i)
+		}
+		inboxes[i] = models.EntityRef{ID: id, Type: "inboxes"}
+	}
+
+	body := map[string]map[string][]models.EntityRef{"company": {"inboxes": inboxes}}
+
+	var resp models.CompanyResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("companies/%d.json", companyID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SLAs returns the enabled SLAs that target the company. SLA membership is
+// stored on the SLA rather than on the company, so this pages through every
+// SLA and keeps those with an "eq" company condition for companyID. Assign a
+// company to an SLA through SLAs.Update.
+func (s *CompanyService) SLAs(ctx context.Context, companyID int) ([]models.SLA, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("companyID must be greater than 0")
+	}
+
+	params := url.Values{}
+	params.Set("includes", "slacompanies")
+
+	var slas []models.SLA
+	err := s.client.SLAs.ListAllFunc(ctx, params, func(page *models.SLAsResponse) error {
+		matches := make(map[int]bool)
+		for _, sc := range page.Included.SLACompanies {
+			if sc.Company == nil || sc.Company.ID != companyID {
+				continue
+			}
+			if sc.Condition != nil && *sc.Condition != models.SLAConditionOptionEqual {
+				continue
+			}
+			matches[sc.ID] = true
+		}
+
+		for _, sla := range page.SLAs {
+			if sla.Enabled != nil && !*sla.Enabled {
+				continue
+			}
+			for _, ref := range sla.Companies {
+				if matches[ref.ID] {
+					slas = append(slas, sla)
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slas, nil
+}
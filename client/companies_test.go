@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestCompanyServiceSLAs(t *testing.T) {
+	eq, ne := models.SLAConditionOptionEqual, models.SLAConditionOptionNotEqual
+	disabled := false
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/slas.json", http.StatusOK, models.SLAsResponse{
+		SLAs: []models.SLA{
+			{BaseEntity: models.BaseEntity{ID: 1}, Companies: []models.EntityRef{{ID: 10}}},
+			{BaseEntity: models.BaseEntity{ID: 2}, Companies: []models.EntityRef{{ID: 20}}},
+			{BaseEntity: models.BaseEntity{ID: 3}, Companies: []models.EntityRef{{ID: 30}}},
+			{BaseEntity: models.BaseEntity{ID: 4}, Enabled: &disabled, Companies: []models.EntityRef{{ID: 40}}},
+		},
+		Included: models.IncludedData{SLACompanies: []models.SLACompany{
+			{BaseEntity: models.BaseEntity{ID: 10}, Company: &models.EntityRef{ID: 5}, Condition: &eq},
+			{BaseEntity: models.BaseEntity{ID: 20}, Company: &models.EntityRef{ID: 5}, Condition: &ne},
+			{BaseEntity: models.BaseEntity{ID: 30}, Company: &models.EntityRef{ID: 6}, Condition: &eq},
+			{BaseEntity: models.BaseEntity{ID: 40}, Company: &models.EntityRef{ID: 5}, Condition: &eq},
+		}},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	slas, err := c.Companies.SLAs(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(slas) != 1 || slas[0].ID != 1 {
+		t.Fatalf("expected only SLA 1, got %+v", slas)
+	}
+
+	if got := mockTransport.GetRequests()[0].URL.Query().Get("includes"); got != "slacompanies" {
+		t.Errorf("expected slacompanies to be included, got %q", got)
+	}
+}
+
+func TestCompanyServiceDomains(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/companies/5/domains.json", http.StatusOK, `{"domains":[{"id":1,"name":"example.com"}],"pagination":{"records":1}}`)
+	mockTransport.AddResponse(http.MethodPost, "/companies/5/domains.json", http.StatusCreated, `{"domain":{"id":2,"name":"example.org"}}`)
+	mockTransport.AddResponse(http.MethodDelete, "/companies/5/domains/2.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	domains, err := c.Companies.ListDomains(ctx, 5, url.Values{"page": {"2"}})
+	if err != nil {
+		t.Fatalf("ListDomains() returned error: %v", err)
+	}
+	if len(domains.Items) != 1 || *domains.Items[0].Name != "example.com" || domains.Pagination.Records != 1 {
+		t.Errorf("unexpected domains %+v", domains)
+	}
+
+	domain, err := c.Companies.AddDomain(ctx, 5, "  @Example.ORG ")
+	if err != nil {
+		t.Fatalf("AddDomain() returned error: %v", err)
+	}
+	if domain.ID != 2 {
+		t.Errorf("expected domain 2, got %+v", domain)
+	}
+
+	if err := c.Companies.RemoveDomain(ctx, 5, 2); err != nil {
+		t.Fatalf("RemoveDomain() returned error: %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	if got := requests[0].URL.Query().Get("page"); got != "2" {
+		t.Errorf("expected the params to be passed on, got page %q", got)
+	}
+
+	var sent models.CompanyDomainResponse
+	body, _ := io.ReadAll(requests[1].Body)
+	if err := json.Unmarshal(body, &sent); err != nil || sent.Item.Name == nil || *sent.Item.Name != "example.org" {
+		t.Errorf("expected the normalised domain to be sent, got %s", body)
+	}
+
+	if got := requests[2].Method + " " + requests[2].URL.Path; got != "DELETE /companies/5/domains/2.json" {
+		t.Errorf("unexpected remove request %s", got)
+	}
+}
+
+func TestCompanyServiceDomainsValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	for _, domain := range []string{"", " @ ", "localhost", "user@example.com", "example.com/path", "exa mple.com"} {
+		if _, err := c.Companies.AddDomain(ctx, 5, domain); err == nil {
+			t.Errorf("expected AddDomain to reject %q", domain)
+		}
+	}
+	if _, err := c.Companies.AddDomain(ctx, 0, "example.com"); err == nil {
+		t.Error("expected AddDomain to reject companyID 0")
+	}
+	if _, err := c.Companies.ListDomains(ctx, 0, nil); err == nil {
+		t.Error("expected ListDomains to reject companyID 0")
+	}
+	if err := c.Companies.RemoveDomain(ctx, 5, 0); err == nil {
+		t.Error("expected RemoveDomain to reject domainID 0")
+	}
+
+	if n := len(mockTransport.GetRequests()); n != 0 {
+		t.Errorf("expected invalid calls not to send requests, got %d", n)
+	}
+}
+
+func TestCompanyServiceNotes(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/companies/5/notes.json", http.StatusOK, `{"notes":[{"id":1,"body":"Renewal due in May"}],"pagination":{"page":2}}`)
+	mockTransport.AddResponse(http.MethodPost, "/companies/5/notes.json", http.StatusCreated, `{"note":{"id":2,"body":"Signed for another year"}}`)
+	mockTransport.AddResponse(http.MethodDelete, "/companies/5/notes/2.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	notes, err := c.Companies.Notes(ctx, 5, url.Values{"page": {"2"}})
+	if err != nil {
+		t.Fatalf("Notes() returned error: %v", err)
+	}
+	if len(notes.Notes) != 1 || *notes.Notes[0].Body != "Renewal due in May" {
+		t.Errorf("unexpected notes %+v", notes.Notes)
+	}
+
+	note, err := c.Companies.AddNote(ctx, 5, "Signed for another year")
+	if err != nil {
+		t.Fatalf("AddNote() returned error: %v", err)
+	}
+	if note.ID != 2 {
+		t.Errorf("expected note 2, got %d", note.ID)
+	}
+
+	if err := c.Companies.RemoveNote(ctx, 5, 2); err != nil {
+		t.Fatalf("RemoveNote() returned error: %v", err)
+	}
+
+	if _, err := c.Companies.Notes(ctx, 0, nil); err == nil {
+		t.Error("expected an error for companyID 0")
+	}
+	if _, err := c.Companies.AddNote(ctx, 5, ""); err == nil {
+		t.Error("expected an error for an empty body")
+	}
+	if err := c.Companies.RemoveNote(ctx, 5, 0); err == nil {
+		t.Error("expected an error for noteID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	if got := requests[0].URL.Query().Get("page"); got != "2" {
+		t.Errorf("expected the params to be passed on, got page %q", got)
+	}
+
+	var sent models.CompanyNoteResponse
+	if err := json.NewDecoder(requests[1].Body).Decode(&sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if sent.Note.Body == nil || *sent.Note.Body != "Signed for another year" {
+		t.Errorf("unexpected note sent %+v", sent.Note)
+	}
+	if requests[2].Method != http.MethodDelete {
+		t.Errorf("expected a DELETE, got %s", requests[2].Method)
+	}
+}
+
+func TestCompanyServiceTags(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/companies/5/tags.json", http.StatusOK, "")
+	mockTransport.AddResponse(http.MethodDelete, "/companies/5/tags/7.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	if err := c.Companies.AddTags(ctx, 5, 7, 8); err != nil {
+		t.Fatalf("AddTags() returned error: %v", err)
+	}
+	if err := c.Companies.RemoveTag(ctx, 5, 7); err != nil {
+		t.Fatalf("RemoveTag() returned error: %v", err)
+	}
+
+	if err := c.Companies.AddTags(ctx, 5); err == nil {
+		t.Error("expected an error without tag IDs")
+	}
+	if err := c.Companies.AddTags(ctx, 5, 7, 0); err == nil {
+		t.Error("expected an error for tag ID 0")
+	}
+	if err := c.Companies.RemoveTag(ctx, 0, 7); err == nil {
+		t.Error("expected an error for companyID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	var sent struct {
+		Tags []models.EntityRef `json:"tags"`
+	}
+	if err := json.NewDecoder(requests[0].Body).Decode(&sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if len(sent.Tags) != 2 || sent.Tags[0].ID != 7 || sent.Tags[1].ID != 8 {
+		t.Errorf("expected tags 7 and 8, got %+v", sent.Tags)
+	}
+	if requests[1].Method != http.MethodDelete {
+		t.Errorf("expected a DELETE, got %s", requests[1].Method)
+	}
+}
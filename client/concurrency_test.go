@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// TestConcurrentUse exercises a single client from many goroutines. Run with
+// -race to check the concurrency guarantee documented on Client.
+func TestConcurrentUse(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets.json", http.StatusCreated, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 1}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, models.CustomersResponse{
+		Customers: []models.Customer{{BaseEntity: models.BaseEntity{ID: 2}}},
+	})
+	mockTransport.AddResponse(http.MethodPost, "/tickets/1/messages.json", http.StatusCreated, models.MessageResponse{})
+
+	recorder := NewHARRecorder()
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithUsageTracking(),
+		WithHARRecorder(recorder),
+		WithMiddleware(RetryMiddleware(1, 0)),
+	)
+
+	ctx := context.Background()
+	const workers = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*3)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			created, err := c.Tickets.Create(ctx, &models.TicketResponse{Ticket: models.Ticket{Subject: ptr("concurrent")}})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if created.Ticket.ID != 1 {
+				t.Errorf("expected ticket 1, got %d", created.Ticket.ID)
+			}
+
+			customers, err := c.Customers.List(ctx, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(customers.Customers) != 1 {
+				t.Errorf("expected 1 customer, got %d", len(customers.Customers))
+			}
+
+			if _, err := c.Messages.CreateForTicket(ctx, 1, &models.MessageResponse{}); err != nil {
+				errs <- err
+			}
+
+			// Reconfiguring and inspecting while requests are in flight
+			switch i % 4 {
+			case 0:
+				c.Tickets.WithHooks(func(*http.Request) {}, nil)
+			case 1:
+				c.UseMiddleware("noop", 0, func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+					return next(ctx, req)
+				})
+			case 2:
+				_ = c.Usage()
+			case 3:
+				_ = c.Middlewares()
+				_ = recorder.HAR()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if got := c.Usage().Requests; got != workers*3 {
+		t.Errorf("expected %d requests, got %d", workers*3, got)
+	}
+	if got := len(recorder.HAR().Log.Entries); got != workers*3 {
+		t.Errorf("expected %d recorded entries, got %d", workers*3, got)
+	}
+}
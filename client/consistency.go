@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	visibilityInitialDelay = 100 * time.Millisecond
+	visibilityMaxDelay     = 2 * time.Second
+)
+
+// Getter is implemented by every resource service
+type Getter[R any] interface {
+	Get(ctx context.Context, id int, params url.Values) (*R, error)
+}
+
+// WaitForVisibility polls svc.Get until the resource with the given id can be
+// retrieved, backing off between attempts, and returns it. Some endpoints are
+// eventually consistent, so a resource that was just created may briefly be
+// missing; use this instead of sleeping after a create. Only "not found"
+// responses (an *APIError with status 404) are retried; any other error is
+// returned straight away. It gives up once timeout has passed, returning the
+// last error.
+//
+//	created, _ := c.Tickets.Create(ctx, ticket)
+//	ticket, err := client.WaitForVisibility(ctx, c.Tickets, created.Ticket.ID, 10*time.Second)
+func WaitForVisibility[R any](ctx context.Context, svc Getter[R], id int, timeout time.Duration) (*R, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be greater than 0")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delay := visibilityInitialDelay
+	for attempt := 1; ; attempt++ {
+		resource, err := svc.Get(ctx, id, nil)
+		if err == nil {
+			return resource, nil
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("resource %d not visible after %d attempts: %w", id, attempt, err)
+		}
+
+		delay = min(delay*2, visibilityMaxDelay)
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+type flakyGetter struct {
+	calls     int
+	visibleAt int
+	// err is returned before the resource is visible, a 404 when nil
+	err error
+}
+
+func (g *flakyGetter) Get(ctx context.Context, id int, params url.Values) (*models.TicketResponse, error) {
+	g.calls++
+	if g.calls < g.visibleAt {
+		if g.err != nil {
+			return nil, g.err
+		}
+		return nil, fmt.Errorf("get ticket: %w", &APIError{StatusCode: http.StatusNotFound})
+	}
+	return &models.TicketResponse{Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: id}}}, nil
+}
+
+func TestWaitForVisibility(t *testing.T) {
+	g := &flakyGetter{visibleAt: 3}
+
+	resp, err := WaitForVisibility(context.Background(), g, 42, 5*time.Second)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.Ticket.ID != 42 {
+		t.Errorf("expected ticket 42, got %d", resp.Ticket.ID)
+	}
+
+	if g.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", g.calls)
+	}
+}
+
+func TestWaitForVisibilityTimeout(t *testing.T) {
+	g := &flakyGetter{visibleAt: 1000}
+
+	_, err := WaitForVisibility(context.Background(), g, 42, 150*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error when the resource never becomes visible")
+	}
+
+	if g.calls < 2 {
+		t.Errorf("expected at least 2 attempts, got %d", g.calls)
+	}
+}
+
+func TestWaitForVisibilityOtherErrors(t *testing.T) {
+	for name, err := range map[string]error{
+		"forbidden":  &APIError{StatusCode: http.StatusForbidden},
+		"validation": &APIError{StatusCode: http.StatusUnprocessableEntity},
+		"transport":  errors.New("connection refused"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := &flakyGetter{visibleAt: 1000, err: err}
+
+			start := time.Now()
+			_, got := WaitForVisibility(context.Background(), g, 42, 5*time.Second)
+			if !errors.Is(got, err) {
+				t.Fatalf("expected %v to be returned, got %v", err, got)
+			}
+			if g.calls != 1 {
+				t.Errorf("expected no retry, got %d attempts", g.calls)
+			}
+			if time.Since(start) > time.Second {
+				t.Errorf("expected an immediate return, took %s", time.Since(start))
+			}
+		})
+	}
+}
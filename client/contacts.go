@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ContactService handles customer contacts: the email addresses and phone
+// numbers a customer can be reached on
+type ContactService struct {
+	*Service[models.ContactResponse, models.ContactsResponse]
+}
+
+// NewContactService creates a new contact service
+func NewContactService(client *Client) *ContactService {
+	return &ContactService{
+		Service: NewService[models.ContactResponse, models.ContactsResponse](client, NewDefaultPathHandler("contacts")),
+	}
+}
+
+// Get retrieves a contact by ID
+func (s *ContactService) Get(ctx context.Context, id int, params url.Values) (*models.ContactResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of contacts with optional filters
+func (s *ContactService) List(ctx context.Context, params url.Values) (*models.ContactsResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// ListForCustomer retrieves a customer's contacts
+func (s *ContactService) ListForCustomer(ctx context.Context, customerID int, params url.Values) (*models.ContactsResponse, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("customers/%d/contacts.json", customerID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.ContactsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Create adds a contact to a customer. The customer, type and value are
+// required; models.EmailContact and models.PhoneContact build the latter two.
+func (s *ContactService) Create(ctx context.Context, contact *models.ContactResponse) (*models.ContactResponse, error) {
+	if contact == nil {
+		return nil, fmt.Errorf("contact is required")
+	}
+	if contact.Item.Customer == nil || contact.Item.Customer.ID <= 0 {
+		return nil, fmt.Errorf("contact.customer.id is required")
+	}
+	if contact.Item.Kind() == "" {
+		return nil, fmt.Errorf("contact.type is required")
+	}
+	if contact.Item.Value == nil || *contact.Item.Value == "" {
+		return nil, fmt.Errorf("contact.value is required")
+	}
+
+	return s.Service.Create(ctx, contact)
+}
+
+// CreateBatch creates contacts in parallel, validating each one as Create
+// does. See Service.CreateBatch.
+func (s *ContactService) CreateBatch(ctx context.Context, items []*models.ContactResponse, opts BatchOptions) ([]BatchResult[models.ContactResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// AddEmail adds an alternate email address to a customer. It does not become
+// the main address; update the contact's IsMain for that.
+func (s *ContactService) AddEmail(ctx context.Context, customerID int, email string) (*models.ContactResponse, error) {
+	contact := models.EmailContact(email, false)
+	contact.Customer = &models.EntityRef{ID: customerID}
+	return s.Create(ctx, &models.ContactResponse{Item: contact})
+}
+
+// AddPhone adds a phone number to a customer
+func (s *ContactService) AddPhone(ctx context.Context, customerID int, number string) (*models.ContactResponse, error) {
+	contact := models.PhoneContact(number)
+	contact.Customer = &models.EntityRef{ID: customerID}
+	return s.Create(ctx, &models.ContactResponse{Item: contact})
+}
+
+// Update updates an existing contact
+func (s *ContactService) Update(ctx context.Context, id int, contact *models.ContactResponse) (*models.ContactResponse, error) {
+	return s.Service.Update(ctx, id, contact)
+}
+
+// Delete removes a contact from its customer. A customer's main email
+// contact cannot be deleted while it is the main one.
+func (s *ContactService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials supplies the API key for each request. It is called before
+// every request, so implementations that read from a changing source pick up
+// rotated keys without restarting the client. Implementations must be safe
+// for concurrent use.
+type Credentials interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsFunc adapts a function, such as a call to an external secret
+// manager, to the Credentials interface. Wrap it in CachedCredentials to
+// avoid calling it on every request.
+type CredentialsFunc func(ctx context.Context) (string, error)
+
+// APIKey implements Credentials
+func (f CredentialsFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials always returns the same key
+func StaticCredentials(apiKey string) Credentials {
+	return CredentialsFunc(func(context.Context) (string, error) {
+		return apiKey, nil
+	})
+}
+
+// EnvCredentials reads the key from an environment variable on every
+// request
+func EnvCredentials(name string) Credentials {
+	return CredentialsFunc(func(context.Context) (string, error) {
+		key := strings.TrimSpace(os.Getenv(name))
+		if key == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return key, nil
+	})
+}
+
+// FileCredentials reads the key from a file, such as a mounted Kubernetes
+// secret. The file is read again whenever its modification time changes.
+func FileCredentials(path string) Credentials {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+}
+
+func (f *fileCredentials) APIKey(context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("read credentials file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key != "" && info.ModTime().Equal(f.modTime) {
+		return f.key, nil
+	}
+
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("read credentials file: %w", err)
+	}
+
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", fmt.Errorf("credentials file %s is empty", f.path)
+	}
+
+	f.key, f.modTime = key, info.ModTime()
+	return f.key, nil
+}
+
+// CachedCredentials wraps creds so the key is fetched at most once per ttl.
+// The cached key is also dropped when the API rejects it with 401
+// Unauthorized, so a rotated key is fetched on the next request.
+func CachedCredentials(creds Credentials, ttl time.Duration) *CachedCredentialsProvider {
+	return &CachedCredentialsProvider{creds: creds, ttl: ttl, now: time.Now}
+}
+
+// CachedCredentialsProvider is the Credentials returned by CachedCredentials
+type CachedCredentialsProvider struct {
+	creds Credentials
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	key     string
+	fetched time.Time
+}
+
+// APIKey implements Credentials
+func (c *CachedCredentialsProvider) APIKey(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != "" && c.now().Sub(c.fetched) < c.ttl {
+		return c.key, nil
+	}
+
+	key, err := c.creds.APIKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.key, c.fetched = key, c.now()
+	return key, nil
+}
+
+// Invalidate drops the cached key
+func (c *CachedCredentialsProvider) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.key = ""
+}
+
+// WithCredentials sets where the API key comes from. It replaces WithAPIKey,
+// which is the same as WithCredentials(StaticCredentials(key)).
+func WithCredentials(creds Credentials) Option {
+	return func(c *Client) {
+		c.credentials = creds
+	}
+}
+
+// authorize sets the Authorization header from the client's credentials
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	if c.credentials == nil {
+		return nil
+	}
+
+	key, err := c.credentials.APIKey(ctx)
+	if err != nil {
+		return fmt.Errorf("get API key: %w", err)
+	}
+
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	return nil
+}
+
+// invalidateCredentials tells credentials that cache the key that it was
+// rejected
+func (c *Client) invalidateCredentials() {
+	if inv, ok := c.credentials.(interface{ Invalidate() }); ok {
+		inv.Invalidate()
+	}
+}
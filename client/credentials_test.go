@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWithCredentialsRotation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusUnauthorized, "")
+
+	var calls int
+	creds := CachedCredentials(CredentialsFunc(func(ctx context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "old-key", nil
+		}
+		return "new-key", nil
+	}), time.Hour)
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithCredentials(creds),
+	)
+
+	// The first request is rejected, which drops the cached key
+	if _, err := c.Tickets.Get(context.Background(), 1, nil); err == nil {
+		t.Fatal("expected error for 401 response")
+	}
+	_, _ = c.Tickets.Get(context.Background(), 1, nil)
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "Bearer old-key" {
+		t.Errorf("first request: expected old key, got %q", got)
+	}
+	if got := requests[1].Header.Get("Authorization"); got != "Bearer new-key" {
+		t.Errorf("second request: expected rotated key, got %q", got)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds := FileCredentials(path)
+	if key, err := creds.APIKey(context.Background()); err != nil || key != "first" {
+		t.Fatalf("expected first, got %q (%v)", key, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if key, err := creds.APIKey(context.Background()); err != nil || key != "second" {
+		t.Fatalf("expected the rotated key, got %q (%v)", key, err)
+	}
+}
+
+func TestEnvCredentialsMissing(t *testing.T) {
+	t.Setenv("DESK_TEST_API_KEY", "")
+
+	c := NewClient("https://example.com", WithCredentials(EnvCredentials("DESK_TEST_API_KEY")))
+	if _, err := c.Tickets.Get(context.Background(), 1, nil); err == nil {
+		t.Fatal("expected error when the environment variable is empty")
+	}
+}
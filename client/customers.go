@@ -2,7 +2,11 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
+	"time"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -10,12 +14,14 @@ import (
 // CustomerService handles customer-related operations
 type CustomerService struct {
 	*Service[models.CustomerResponse, models.CustomersResponse]
+	client *Client
 }
 
 // NewCustomerService creates a new customer service
 func NewCustomerService(client *Client) *CustomerService {
 	return &CustomerService{
 		Service: NewService[models.CustomerResponse, models.CustomersResponse](client, NewDefaultPathHandler("customers")),
+		client:  client,
 	}
 }
 
@@ -38,3 +44,364 @@ func (s *CustomerService) Create(ctx context.Context, customer *models.CustomerR
 func (s *CustomerService) Update(ctx context.Context, id int, customer *models.CustomerResponse) (*models.CustomerResponse, error) {
 	return s.Service.Update(ctx, id, customer)
 }
+
+// FindByExternalID returns the customer created with the given external ID,
+// or nil if there is none
+func (s *CustomerService) FindByExternalID(ctx context.Context, externalID string) (*models.Customer, error) {
+	if externalID == "" {
+		return nil, fmt.Errorf("externalID is required")
+	}
+
+	resp, err := s.List(ctx, externalIDParams(externalID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Customers {
+		if c := &resp.Customers[i]; c.ExternalID != nil && *c.ExternalID == externalID {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Delete deletes a customer
+func (s *CustomerService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
+
+// ListDeleted retrieves customers that have been deleted but can still be
+// restored. Any filter in params is combined with the deleted state.
+func (s *CustomerService) ListDeleted(ctx context.Context, params url.Values) (*models.CustomersResponse, error) {
+	params, err := withStateFilter(params, models.StateDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Service.List(ctx, params)
+}
+
+// Restore brings back a deleted customer
+func (s *CustomerService) Restore(ctx context.Context, id int) (*models.CustomerResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	body := map[string]map[string]models.State{"customer": {"state": models.StateActive}}
+
+	var resp models.CustomerResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("customers/%d.json", id), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetPortalAccess enables or disables customer portal login for a customer.
+// Enabling access does not notify the customer; follow it with
+// InviteToPortal to send them a welcome email.
+func (s *CustomerService) SetPortalAccess(ctx context.Context, customerID int, enabled bool) (*models.CustomerResponse, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+
+	body := map[string]map[string]bool{"customer": {"portalAccess": enabled}}
+
+	var resp models.CustomerResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("customers/%d.json", customerID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetCompany moves a customer to a company without resending the rest of the
+// customer, such as their contacts. A companyID of 0 removes the customer
+// from their company.
+func (s *CustomerService) SetCompany(ctx context.Context, customerID, companyID int) (*models.CustomerResponse, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+	if companyID < 0 {
+		return nil, fmt.Errorf("companyID must not be negative")
+	}
+
+	var company *models.EntityRef
+	if companyID > 0 {
+		company = &models.EntityRef{ID: companyID, Type: "companies"}
+	}
+	body := map[string]map[string]*models.EntityRef{"customer": {"company": company}}
+
+	var resp models.CustomerResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("customers/%d.json", customerID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Merge merges duplicate customers into primaryID. The duplicates' tickets,
+// contacts and notes move to the primary customer and the duplicates are
+// deleted. The merged customer is returned.
+func (s *CustomerService) Merge(ctx context.Context, primaryID int, duplicateIDs []int) (*models.CustomerResponse, error) {
+	if err := validateMergeIDs(primaryID, duplicateIDs); err != nil {
+		return nil, err
+	}
+
+	body := map[string][]int{"ids": duplicateIDs}
+
+	var resp models.CustomerResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("customers/%d/merge.json", primaryID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// validateMergeIDs checks the IDs passed to a merge. Merging a record into
+// itself or naming a duplicate twice is rejected before anything is sent.
+func validateMergeIDs(primaryID int, duplicateIDs []int) error {
+	if primaryID <= 0 {
+		return fmt.Errorf("primaryID must be greater than 0")
+	}
+	if len(duplicateIDs) == 0 {
+		return fmt.Errorf("duplicateIDs is required")
+	}
+
+	seen := make(map[int]bool, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		switch {
+		case id <= 0:
+			return fmt.Errorf("duplicateIDs must be greater than 0")
+		case id == primaryID:
+			return fmt.Errorf("duplicateIDs must not contain primaryID %d", primaryID)
+		case seen[id]:
+			return fmt.Errorf("duplicateIDs contains %d more than once", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// CompanyMoveResult is the outcome of MoveCompany
+type CompanyMoveResult struct {
+	// Matched holds the IDs of every customer that belonged to the source
+	// company
+	Matched []int
+	// Moved holds the IDs of the customers that were moved
+	Moved []int
+	// Failed maps the IDs that could not be moved to the error returned
+	Failed map[int]error
+}
+
+// MoveCompany moves every customer of fromCompanyID to toCompanyID, for
+// example after merging two companies. A toCompanyID of 0 removes the
+// customers from the company instead. All customers are found before any is
+// moved, so paging is not affected by the changes, and failures on
+// individual customers are recorded in the result rather than aborting the
+// run.
+func (s *CustomerService) MoveCompany(ctx context.Context, fromCompanyID, toCompanyID int) (*CompanyMoveResult, error) {
+	if fromCompanyID <= 0 {
+		return nil, fmt.Errorf("fromCompanyID must be greater than 0")
+	}
+	if toCompanyID < 0 {
+		return nil, fmt.Errorf("toCompanyID must not be negative")
+	}
+	if fromCompanyID == toCompanyID {
+		return nil, fmt.Errorf("fromCompanyID and toCompanyID must differ")
+	}
+
+	params := url.Values{}
+	params.Set("filter", NewFilter().Eq("company", fromCompanyID).Build())
+
+	result := &CompanyMoveResult{Failed: map[int]error{}}
+	err := s.ListAllFunc(ctx, params, func(page *models.CustomersResponse) error {
+		for _, c := range page.Customers {
+			// Guard against the filter being ignored: only ever move
+			// customers known to belong to the source company
+			if c.Company != nil && c.Company.ID == fromCompanyID {
+				result.Matched = append(result.Matched, c.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range result.Matched {
+		if _, err := s.SetCompany(ctx, id, toCompanyID); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			result.Failed[id] = err
+			continue
+		}
+		result.Moved = append(result.Moved, id)
+	}
+
+	return result, nil
+}
+
+// InviteToPortal sends the customer a welcome email inviting them to set up
+// their customer portal account. The returned customer has WelcomeEmailSent
+// set.
+func (s *CustomerService) InviteToPortal(ctx context.Context, customerID int) (*models.Customer, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+
+	var resp models.CustomerResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("customers/%d/welcome.json", customerID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Customer, nil
+}
+
+// SendPasswordReset emails the customer a link to reset their customer portal
+// password
+func (s *CustomerService) SendPasswordReset(ctx context.Context, customerID int) error {
+	if customerID <= 0 {
+		return fmt.Errorf("customerID must be greater than 0")
+	}
+
+	return s.request(ctx, http.MethodPost, fmt.Sprintf("customers/%d/resetpassword.json", customerID), nil, nil)
+}
+
+// CustomerActivityOptions configures Activity
+type CustomerActivityOptions struct {
+	// Since and Until limit the history to tickets created and happiness
+	// ratings given in that range. Zero values leave the range open.
+	Since time.Time
+	Until time.Time
+
+	// IncludeMessages adds the messages on each ticket to the history. This
+	// costs one request per ticket.
+	IncludeMessages bool
+}
+
+// Activity returns a customer's tickets, their happiness ratings and
+// optionally the messages on the tickets, as a single history ordered oldest
+// first
+func (s *CustomerService) Activity(ctx context.Context, customerID int, opts *CustomerActivityOptions) ([]models.CustomerActivity, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+	if opts == nil {
+		opts = &CustomerActivityOptions{}
+	}
+
+	filter := &models.SearchTicketsFilter{Customers: []int64{int64(customerID)}, Page: 1}
+	if !opts.Since.IsZero() {
+		filter.StartDate = &opts.Since
+	}
+	if !opts.Until.IsZero() {
+		filter.EndDate = &opts.Until
+	}
+
+	var tickets []models.Ticket
+	for {
+		resp, err := s.client.Tickets.Search(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+		tickets = append(tickets, resp.Tickets...)
+
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+		filter.Page++
+	}
+
+	var activity []models.CustomerActivity
+	for i := range tickets {
+		t := &tickets[i]
+		activity = append(activity, models.CustomerActivity{
+			Type:   models.CustomerActivityTypeTicket,
+			At:     eventTime(t.BaseEntity),
+			Ticket: t,
+		})
+
+		if !opts.IncludeMessages {
+			continue
+		}
+
+		messages, err := s.client.Messages.Thread(ctx, t.ID, false)
+		if err != nil {
+			return nil, fmt.Errorf("list messages for ticket %d: %w", t.ID, err)
+		}
+
+		for j := range messages {
+			m := &messages[j]
+			activity = append(activity, models.CustomerActivity{
+				Type:    models.CustomerActivityTypeMessage,
+				At:      eventTime(m.BaseEntity),
+				Ticket:  t,
+				Message: m,
+			})
+		}
+	}
+
+	happiness, err := s.happinessActivity(ctx, customerID, opts, tickets)
+	if err != nil {
+		return nil, err
+	}
+	activity = append(activity, happiness...)
+
+	sort.SliceStable(activity, func(i, j int) bool {
+		return activity[i].At.Before(activity[j].At)
+	})
+
+	return activity, nil
+}
+
+// happinessActivity lists the customer's happiness ratings as activity
+// entries, linked to the rated ticket when it is among tickets
+func (s *CustomerService) happinessActivity(ctx context.Context, customerID int, opts *CustomerActivityOptions, tickets []models.Ticket) ([]models.CustomerActivity, error) {
+	byID := make(map[int]*models.Ticket, len(tickets))
+	for i := range tickets {
+		byID[tickets[i].ID] = &tickets[i]
+	}
+
+	params := (&HappinessListParams{
+		CustomerIDs: []int{customerID},
+		Since:       opts.Since,
+		Until:       opts.Until,
+	}).Values()
+
+	var activity []models.CustomerActivity
+	err := s.client.Happiness.ListAllFunc(ctx, params, func(page *models.HappinessSurveysResponse) error {
+		for i := range page.Items {
+			h := &page.Items[i]
+
+			at := eventTime(h.BaseEntity)
+			if h.RatedAt != nil {
+				at = *h.RatedAt
+			}
+
+			var ticket *models.Ticket
+			if h.Ticket != nil {
+				ticket = byID[h.Ticket.ID]
+				if ticket == nil {
+					ticket = &models.Ticket{BaseEntity: models.BaseEntity{ID: h.Ticket.ID}}
+				}
+			}
+
+			activity = append(activity, models.CustomerActivity{
+				Type:      models.CustomerActivityTypeHappiness,
+				At:        at,
+				Ticket:    ticket,
+				Happiness: h,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list happiness ratings: %w", err)
+	}
+
+	return activity, nil
+}
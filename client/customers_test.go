@@ -0,0 +1,260 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestCustomerServiceMoveCompany(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, models.CustomersResponse{
+		Customers: []models.Customer{
+			{BaseEntity: models.BaseEntity{ID: 1}, Company: &models.EntityRef{ID: 5}},
+			{BaseEntity: models.BaseEntity{ID: 2}, Company: &models.EntityRef{ID: 6}},
+			{BaseEntity: models.BaseEntity{ID: 3}},
+			{BaseEntity: models.BaseEntity{ID: 4}, Company: &models.EntityRef{ID: 5}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPatch, "/customers/1.json", http.StatusOK, `{"customer":{"id":1}}`)
+	mockTransport.AddResponse(http.MethodPatch, "/customers/4.json", http.StatusNotFound, `{}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	result, err := c.Customers.MoveCompany(context.Background(), 5, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(result.Matched, []int{1, 4}) {
+		t.Errorf("expected only customers of company 5 to match, got %v", result.Matched)
+	}
+	if !reflect.DeepEqual(result.Moved, []int{1}) || result.Failed[4] == nil {
+		t.Errorf("expected customer 1 to move and customer 4 to fail, got %+v", result)
+	}
+
+	requests := mockTransport.GetRequests()
+	body, err := io.ReadAll(requests[1].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	if want := `{"customer":{"company":{"id":7,"type":"companies","meta":null}}}`; string(body) != want {
+		t.Errorf("expected body %s, got %s", want, body)
+	}
+}
+
+func TestCustomerServiceSetCompanyClears(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/customers/1.json", http.StatusOK, `{"customer":{"id":1}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	if _, err := c.Customers.SetCompany(context.Background(), 1, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	body, err := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	if want := `{"customer":{"company":null}}`; string(body) != want {
+		t.Errorf("expected body %s, got %s", want, body)
+	}
+}
+
+func TestCustomerServiceMerge(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/customers/1/merge.json", http.StatusOK, `{"customer":{"id":1}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := c.Customers.Merge(context.Background(), 1, []int{2, 3})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Customer.ID != 1 {
+		t.Errorf("expected the primary customer, got %d", resp.Customer.ID)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if string(b) != `{"ids":[2,3]}` {
+		t.Errorf("unexpected merge body %s", b)
+	}
+
+	for _, dups := range [][]int{nil, {0}, {1}, {2, 2}} {
+		if _, err := c.Customers.Merge(context.Background(), 1, dups); err == nil {
+			t.Errorf("expected an error merging %v", dups)
+		}
+	}
+	if len(mockTransport.GetRequests()) != 1 {
+		t.Error("expected invalid merges not to be sent")
+	}
+}
+
+func TestCustomerServiceActivity(t *testing.T) {
+	day := func(d int) *time.Time {
+		at := time.Date(2025, 3, d, 9, 0, 0, 0, time.UTC)
+		return &at
+	}
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 10, CreatedAt: day(1)}},
+			{BaseEntity: models.BaseEntity{ID: 11, CreatedAt: day(5)}},
+		},
+	})
+	rating := models.HappinessRatingHappy
+	mockTransport.AddResponse(http.MethodGet, "/happinesssurveys.json", http.StatusOK, models.HappinessSurveysResponse{
+		Items: []models.HappinessSurvey{
+			{BaseEntity: models.BaseEntity{ID: 20}, Rating: &rating, Ticket: &models.EntityRef{ID: 10}, RatedAt: day(3)},
+			{BaseEntity: models.BaseEntity{ID: 21}, Rating: &rating, Ticket: &models.EntityRef{ID: 9}, RatedAt: day(7)},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	activity, err := c.Customers.Activity(context.Background(), 4, nil)
+	if err != nil {
+		t.Fatalf("Activity() returned error: %v", err)
+	}
+
+	var got []string
+	for _, a := range activity {
+		got = append(got, fmt.Sprintf("%s:%d", a.Type, a.Ticket.ID))
+	}
+	if want := []string{"ticket:10", "happiness:10", "ticket:11", "happiness:9"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected history %v, got %v", want, got)
+	}
+	if activity[1].Ticket != activity[0].Ticket {
+		t.Error("expected the rating to be linked to the ticket in the history")
+	}
+	if activity[1].Happiness.ID != 20 || !activity[1].At.Equal(*day(3)) {
+		t.Errorf("unexpected happiness entry %+v", activity[1])
+	}
+
+	requests := mockTransport.GetRequests()
+	filter := requests[len(requests)-1].URL.Query().Get("filter")
+	if !strings.Contains(filter, `"customer":{"$in":[4]}`) {
+		t.Errorf("expected happiness ratings to be filtered by customer, got %s", filter)
+	}
+
+	if _, err := c.Customers.Activity(context.Background(), 0, nil); err == nil {
+		t.Error("expected an error for customerID 0")
+	}
+}
+
+func TestCustomerServicePortalInvites(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/customers/4/welcome.json", http.StatusOK, `{"customer":{"id":4,"welcomeEmailSent":true}}`)
+	mockTransport.AddResponse(http.MethodPost, "/customers/4/resetpassword.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	customer, err := c.Customers.InviteToPortal(ctx, 4)
+	if err != nil {
+		t.Fatalf("InviteToPortal() returned error: %v", err)
+	}
+	if customer.ID != 4 || customer.WelcomeEmailSent == nil || !*customer.WelcomeEmailSent {
+		t.Errorf("expected customer 4 with the welcome email sent, got %+v", customer)
+	}
+
+	if err := c.Customers.SendPasswordReset(ctx, 4); err != nil {
+		t.Fatalf("SendPasswordReset() returned error: %v", err)
+	}
+
+	if _, err := c.Customers.InviteToPortal(ctx, 0); err == nil {
+		t.Error("expected an error for customerID 0")
+	}
+	if err := c.Customers.SendPasswordReset(ctx, 0); err == nil {
+		t.Error("expected an error for customerID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for i, want := range []string{"/customers/4/welcome.json", "/customers/4/resetpassword.json"} {
+		if requests[i].Method != http.MethodPost || requests[i].URL.Path != want {
+			t.Errorf("request %d: expected POST %s, got %s %s", i, want, requests[i].Method, requests[i].URL.Path)
+		}
+	}
+}
+
+func TestCustomerServiceListDeletedAndRestore(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, `{"customers":[{"id":4}]}`)
+	mockTransport.AddResponse(http.MethodPatch, "/customers/4.json", http.StatusOK, `{"customer":{"id":4}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	filter, err := NewFilter().Eq("email", "a@example.com").Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := c.Customers.ListDeleted(ctx, url.Values{"filter": {filter}})
+	if err != nil {
+		t.Fatalf("ListDeleted() returned error: %v", err)
+	}
+	if len(deleted.Customers) != 1 || deleted.Customers[0].ID != 4 {
+		t.Errorf("expected deleted customer 4, got %+v", deleted.Customers)
+	}
+
+	if _, err := c.Customers.Restore(ctx, 4); err != nil {
+		t.Fatalf("Restore() returned error: %v", err)
+	}
+	if _, err := c.Customers.Restore(ctx, 0); err == nil {
+		t.Error("expected an error for id 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if want := `{"$and":[{"email":{"$eq":"a@example.com"}},{"state":{"$eq":"deleted"}}]}`; requests[0].URL.Query().Get("filter") != want {
+		t.Errorf("expected filter %s, got %s", want, requests[0].URL.Query().Get("filter"))
+	}
+	b, _ := io.ReadAll(requests[1].Body)
+	if want := `{"customer":{"state":"active"}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+}
+
+func TestCustomerServiceSetPortalAccess(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/customers/4.json", http.StatusOK, `{"customer":{"id":4,"portalAccess":false}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := c.Customers.SetPortalAccess(context.Background(), 4, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Customer.PortalAccess == nil || *resp.Customer.PortalAccess {
+		t.Errorf("expected portal access to be disabled, got %v", resp.Customer.PortalAccess)
+	}
+
+	if _, err := c.Customers.SetPortalAccess(context.Background(), 0, true); err == nil {
+		t.Error("expected an error for customerID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	// false must be sent rather than omitted
+	b, _ := io.ReadAll(requests[0].Body)
+	if want := `{"customer":{"portalAccess":false}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// CustomFieldService handles custom field definitions. The values of a
+// ticket's or customer's custom fields are read and written through the
+// CustomFieldValues of the ticket or customer itself.
+type CustomFieldService struct {
+	*Service[models.CustomFieldResponse, models.CustomFieldsResponse]
+}
+
+// NewCustomFieldService creates a new custom field service
+func NewCustomFieldService(client *Client) *CustomFieldService {
+	return &CustomFieldService{
+		Service: NewService[models.CustomFieldResponse, models.CustomFieldsResponse](client, NewDefaultPathHandler("customfields")),
+	}
+}
+
+// Get retrieves a custom field by ID
+func (s *CustomFieldService) Get(ctx context.Context, id int, params url.Values) (*models.CustomFieldResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of custom fields with optional filters
+func (s *CustomFieldService) List(ctx context.Context, params url.Values) (*models.CustomFieldsResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// ListForEntity retrieves the custom fields defined on tickets or customers
+func (s *CustomFieldService) ListForEntity(ctx context.Context, entity models.CustomFieldEntity, params url.Values) (*models.CustomFieldsResponse, error) {
+	if entity == "" {
+		return nil, fmt.Errorf("entity is required")
+	}
+
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("entity", string(entity))
+
+	return s.Service.List(ctx, q)
+}
+
+// Create creates a new custom field. The name and type are required.
+func (s *CustomFieldService) Create(ctx context.Context, field *models.CustomFieldResponse) (*models.CustomFieldResponse, error) {
+	if field == nil {
+		return nil, fmt.Errorf("field is required")
+	}
+	if field.Item.Name == nil || *field.Item.Name == "" {
+		return nil, fmt.Errorf("field.name is required")
+	}
+	if field.Item.Type == nil || *field.Item.Type == "" {
+		return nil, fmt.Errorf("field.type is required")
+	}
+
+	return s.Service.Create(ctx, field)
+}
+
+// CreateBatch creates custom fields in parallel, validating each one as
+// Create does. See Service.CreateBatch.
+func (s *CustomFieldService) CreateBatch(ctx context.Context, items []*models.CustomFieldResponse, opts BatchOptions) ([]BatchResult[models.CustomFieldResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing custom field
+func (s *CustomFieldService) Update(ctx context.Context, id int, field *models.CustomFieldResponse) (*models.CustomFieldResponse, error) {
+	return s.Service.Update(ctx, id, field)
+}
+
+// Delete deletes a custom field. Values stored under its handle are removed
+// from every ticket or customer.
+func (s *CustomFieldService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultDeleteConcurrency = 4
+	deleteWherePageSize      = 100
+)
+
+// ConfirmFunc is asked to approve a DeleteWhere run before anything is
+// deleted. It receives the IDs of every matching resource; returning false
+// cancels the run.
+type ConfirmFunc func(ctx context.Context, ids []int) (bool, error)
+
+// DeleteWhereOptions configures DeleteWhere
+type DeleteWhereOptions struct {
+	// Confirm approves the deletion once the matching resources are known.
+	// Either Confirm or Force is required.
+	Confirm ConfirmFunc
+
+	// Force deletes without asking for confirmation
+	Force bool
+
+	// Concurrency is the maximum number of deletes in flight. Defaults to 4.
+	Concurrency int
+}
+
+// DeleteWhereResult is the outcome of DeleteWhere
+type DeleteWhereResult struct {
+	// Matched holds the IDs of every resource that matched the filter
+	Matched []int
+	// Confirmed reports whether the deletion went ahead
+	Confirmed bool
+	// Deleted holds the IDs that were deleted, in no particular order
+	Deleted []int
+	// Failed maps the IDs that could not be deleted to the error returned
+	Failed map[int]error
+}
+
+// DeleteWhere deletes every resource matching filter. All matching IDs are
+// collected before anything is deleted, so paging is not affected by the
+// deletions, and are then passed to opts.Confirm. Failures on individual
+// resources are recorded in the result rather than aborting the run.
+func (s *Service[T, L]) DeleteWhere(ctx context.Context, filter *FilterBuilder, opts DeleteWhereOptions) (*DeleteWhereResult, error) {
+	if filter == nil {
+		return nil, fmt.Errorf("filter is required")
+	}
+	if opts.Confirm == nil && !opts.Force {
+		return nil, fmt.Errorf("opts.Confirm or opts.Force is required")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultDeleteConcurrency
+	}
+
+	encoded, err := filter.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encode filter: %w", err)
+	}
+	if encoded == "" {
+		return nil, fmt.Errorf("filter must have at least one condition")
+	}
+
+	params := PageParams{PageSize: deleteWherePageSize}.values()
+	params.Set("filter", encoded)
+
+	matched, err := s.ListIDs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DeleteWhereResult{Matched: matched, Failed: map[int]error{}}
+
+	if len(result.Matched) == 0 {
+		return result, nil
+	}
+
+	if !opts.Force {
+		ok, err := opts.Confirm(ctx, result.Matched)
+		if err != nil {
+			return result, fmt.Errorf("confirm: %w", err)
+		}
+		if !ok {
+			return result, nil
+		}
+	}
+	result.Confirmed = true
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, opts.Concurrency)
+	)
+	for _, id := range result.Matched {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return result, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := s.Delete(ctx, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed[id] = err
+				return
+			}
+			result.Deleted = append(result.Deleted, id)
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestServiceDeleteWhere(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, models.CustomersResponse{
+		Customers: []models.Customer{
+			{BaseEntity: models.BaseEntity{ID: 1}},
+			{BaseEntity: models.BaseEntity{ID: 2}},
+			{BaseEntity: models.BaseEntity{ID: 3}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodDelete, "/customers/1.json", http.StatusNoContent, "")
+	mockTransport.AddResponse(http.MethodDelete, "/customers/2.json", http.StatusNoContent, "")
+	mockTransport.AddResponse(http.MethodDelete, "/customers/3.json", http.StatusInternalServerError, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	filter := NewFilter().Eq("email", "test@example.com")
+
+	var asked []int
+	result, err := c.Customers.DeleteWhere(context.Background(), filter, DeleteWhereOptions{
+		Confirm: func(ctx context.Context, ids []int) (bool, error) {
+			asked = ids
+			return false, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Confirmed || len(result.Deleted) != 0 {
+		t.Fatalf("expected nothing to be deleted when not confirmed, got %+v", result)
+	}
+	if !slices.Equal(asked, []int{1, 2, 3}) {
+		t.Fatalf("expected confirmation for [1 2 3], got %v", asked)
+	}
+	if got := len(mockTransport.GetRequests()); got != 1 {
+		t.Fatalf("expected only the list request, got %d requests", got)
+	}
+
+	result, err = c.Customers.DeleteWhere(context.Background(), filter, DeleteWhereOptions{Force: true, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	slices.Sort(result.Deleted)
+	if !slices.Equal(result.Deleted, []int{1, 2}) {
+		t.Errorf("expected [1 2] to be deleted, got %v", result.Deleted)
+	}
+	if _, ok := result.Failed[3]; !ok || len(result.Failed) != 1 {
+		t.Errorf("expected only 3 to fail, got %v", result.Failed)
+	}
+}
+
+func TestServiceDeleteWhereRequiresConfirmation(t *testing.T) {
+	c := NewClient("https://example.com")
+
+	if _, err := c.Customers.DeleteWhere(context.Background(), NewFilter().Eq("email", "x"), DeleteWhereOptions{}); err == nil {
+		t.Fatal("expected error without Confirm or Force")
+	}
+
+	if _, err := c.Customers.DeleteWhere(context.Background(), NewFilter(), DeleteWhereOptions{Force: true}); err == nil {
+		t.Fatal("expected error for an empty filter")
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Desk API answers with an unexpected status.
+// Use errors.As to branch on the status, for example to treat a 404 as
+// "not found" or to back off on a 429:
+//
+//	var apiErr *client.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+//		...
+//	}
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// RequestID is the X-Request-ID of the failed request, taken from the
+	// response when the API echoes one and from the request otherwise
+	RequestID string
+
+	// Code and Message are the error code and message from the response
+	// body, when it has them
+	Code    string
+	Message string
+	// Errors holds the individual errors in the response body, such as one
+	// per invalid field on a 422
+	Errors []FieldError
+
+	// Body is the raw response body
+	Body []byte
+}
+
+// FieldError is a single error in a Desk API error response
+type FieldError struct {
+	// Field is the field the error is about, empty when it is not about a
+	// specific field
+	Field   string
+	Code    string
+	Message string
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "unexpected status code: %d", e.StatusCode)
+
+	switch {
+	case e.Message != "":
+		fmt.Fprintf(&b, ": %s", e.Message)
+	case len(e.Errors) > 0:
+		msgs := make([]string, 0, len(e.Errors))
+		for _, fe := range e.Errors {
+			if fe.Field != "" {
+				msgs = append(msgs, fe.Field+": "+fe.Message)
+			} else {
+				msgs = append(msgs, fe.Message)
+			}
+		}
+		fmt.Fprintf(&b, ": %s", strings.Join(msgs, "; "))
+	case len(e.Body) > 0:
+		fmt.Fprintf(&b, ", body: %s", e.Body)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// newAPIError builds an APIError from a failed response whose body has
+// already been read
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RequestID:  resp.Header.Get(RequestIDHeader),
+		Body:       body,
+	}
+	if e.RequestID == "" {
+		e.RequestID = req.Header.Get(RequestIDHeader)
+	}
+
+	// The error body comes in a few shapes depending on the endpoint: a
+	// top-level message, a list of errors, or both. Anything that isn't JSON
+	// is only kept in Body.
+	var payload struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+		Errors  []struct {
+			Field   string `json:"field"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+			Title   string `json:"title"`
+			Source  struct {
+				Pointer string `json:"pointer"`
+			} `json:"source"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return e
+	}
+
+	e.Code = payload.Code
+	e.Message = payload.Message
+	if e.Message == "" {
+		e.Message = payload.Error
+	}
+
+	for _, pe := range payload.Errors {
+		fe := FieldError{Field: pe.Field, Code: pe.Code, Message: pe.Message}
+		if fe.Field == "" {
+			fe.Field = strings.TrimPrefix(strings.TrimPrefix(pe.Source.Pointer, "/data/attributes/"), "/")
+		}
+		if fe.Message == "" {
+			fe.Message = pe.Detail
+		}
+		if fe.Message == "" {
+			fe.Message = pe.Title
+		}
+		e.Errors = append(e.Errors, fe)
+	}
+
+	return e
+}
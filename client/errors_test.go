@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/404.json", http.StatusNotFound, `{"message":"ticket not found"}`)
+	mockTransport.AddResponse(http.MethodPost, "/customers.json", http.StatusUnprocessableEntity,
+		`{"code":"validation","errors":[{"field":"email","code":"invalid","message":"is not a valid email"},{"source":{"pointer":"/data/attributes/firstName"},"detail":"is required"}]}`)
+	mockTransport.AddResponse(http.MethodDelete, "/tags/1.json", http.StatusInternalServerError, `upstream timeout`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	_, err := c.Tickets.Get(ctx, 404, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "ticket not found" || apiErr.RequestID != "req-1" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+	if got, want := err.Error(), "unexpected status code: 404: ticket not found (request ID req-1)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	_, err = c.Customers.Create(context.Background(), nil)
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != "validation" || len(apiErr.Errors) != 2 {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+	if fe := apiErr.Errors[1]; fe.Field != "firstName" || fe.Message != "is required" {
+		t.Errorf("expected the pointer and detail to be used, got %+v", fe)
+	}
+	if apiErr.Method != http.MethodPost {
+		t.Errorf("expected method POST, got %s", apiErr.Method)
+	}
+
+	err = c.Tags.Delete(context.Background(), 1)
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+	if got, want := err.Error(), "unexpected status code: 500, body: upstream timeout"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FaultConfig describes the failures FaultInjectionMiddleware simulates.
+// Rates are probabilities between 0 and 1, checked in the order rate limit,
+// server error, timeout, and are independent of each other.
+type FaultConfig struct {
+	// RateLimitRate is the chance a request starts a burst of 429 Too Many
+	// Requests responses
+	RateLimitRate float64
+	// RateLimitBurst is how many consecutive requests a burst rejects,
+	// including the one that started it. Defaults to 1.
+	RateLimitBurst int
+	// RetryAfter is sent in the Retry-After header of 429 responses.
+	// Defaults to one second.
+	RetryAfter time.Duration
+
+	// ServerErrorRate is the chance of a 500 Internal Server Error response
+	ServerErrorRate float64
+
+	// TimeoutRate is the chance a request hangs for Timeout and then fails
+	// with context.DeadlineExceeded, as if the connection timed out.
+	// Cancelling the request's context ends the wait early.
+	TimeoutRate float64
+	// Timeout is how long a timed out request hangs. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// Seed makes the sequence of faults repeatable. Zero picks a random
+	// seed.
+	Seed uint64
+}
+
+// FaultInjectionMiddleware fails a share of requests the way Desk does under
+// load, without sending them, so applications can test their retry and
+// backoff handling. Requests that are not failed are passed on untouched. It
+// is meant for tests and staging, never production.
+//
+//	c := client.NewClient(baseURL, client.WithMiddleware(client.FaultInjectionMiddleware(client.FaultConfig{
+//		RateLimitRate:   0.05,
+//		RateLimitBurst:  10,
+//		ServerErrorRate: 0.01,
+//	})))
+func FaultInjectionMiddleware(cfg FaultConfig) MiddlewareFunc {
+	if cfg.RateLimitBurst <= 0 {
+		cfg.RateLimitBurst = 1
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	var (
+		mu        sync.Mutex
+		rng       = rand.New(rand.NewPCG(seed, seed))
+		burstLeft int
+	)
+
+	// roll decides the fault for one request
+	roll := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if burstLeft > 0 {
+			burstLeft--
+			return http.StatusTooManyRequests
+		}
+		if rng.Float64() < cfg.RateLimitRate {
+			burstLeft = cfg.RateLimitBurst - 1
+			return http.StatusTooManyRequests
+		}
+		if rng.Float64() < cfg.ServerErrorRate {
+			return http.StatusInternalServerError
+		}
+		if rng.Float64() < cfg.TimeoutRate {
+			return 0
+		}
+		return http.StatusOK
+	}
+
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		switch status := roll(); status {
+		case http.StatusOK:
+			return next(ctx, req)
+		case 0:
+			select {
+			case <-time.After(cfg.Timeout):
+				return nil, fmt.Errorf("simulated timeout: %w", context.DeadlineExceeded)
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		default:
+			resp := faultResponse(req, status)
+			if status == http.StatusTooManyRequests {
+				resp.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(cfg.RetryAfter.Seconds()))))
+				resp.Header.Set("X-RateLimit-Remaining", "0")
+			}
+			return resp, nil
+		}
+	}
+}
+
+func faultResponse(req *http.Request, status int) *http.Response {
+	body := fmt.Sprintf(`{"errors":[{"code":"simulated","detail":%q}]}`, http.StatusText(status))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFaultInjectionMiddlewareBurst(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, map[string]any{"ticket": map[string]any{"id": 1}})
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithMiddleware(FaultInjectionMiddleware(FaultConfig{RateLimitRate: 1, RateLimitBurst: 3, RetryAfter: 2 * time.Second})),
+	)
+
+	for range 3 {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/tickets/1.json", nil)
+		resp, err := c.doRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("expected a simulated response, got error %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected 429, got %d", resp.StatusCode)
+		}
+		if got := resp.Header.Get("Retry-After"); got != "2" {
+			t.Errorf("expected Retry-After 2, got %q", got)
+		}
+	}
+
+	if got := len(mockTransport.GetRequests()); got != 0 {
+		t.Errorf("expected faulted requests not to be sent, got %d", got)
+	}
+}
+
+func TestFaultInjectionMiddlewareRates(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, map[string]any{"ticket": map[string]any{"id": 1}})
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithMiddleware(FaultInjectionMiddleware(FaultConfig{ServerErrorRate: 0.3, Seed: 42})),
+	)
+
+	const n = 1000
+	var failed int
+	for range n {
+		if _, err := c.Tickets.Get(context.Background(), 1, nil); err != nil {
+			failed++
+		}
+	}
+
+	if failed < 200 || failed > 400 {
+		t.Errorf("expected roughly 30%% of %d requests to fail, got %d", n, failed)
+	}
+	if got := len(mockTransport.GetRequests()); got != n-failed {
+		t.Errorf("expected %d requests to reach the server, got %d", n-failed, got)
+	}
+}
+
+func TestFaultInjectionMiddlewareTimeout(t *testing.T) {
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: NewMockRoundTripper()}),
+		WithMiddleware(FaultInjectionMiddleware(FaultConfig{TimeoutRate: 1, Timeout: time.Millisecond})),
+	)
+
+	_, err := c.Tickets.Get(context.Background(), 1, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+}
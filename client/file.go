@@ -15,6 +15,7 @@ import (
 // FileService handles ticket-related operations
 type FileService struct {
 	*Service[models.FileResponse, models.FilesResponse]
+	client *Client
 }
 
 type FilePathHandler struct {
@@ -33,6 +34,7 @@ func (f FilePathHandler) Create() string {
 func NewFileService(client *Client) *FileService {
 	return &FileService{
 		Service: NewService[models.FileResponse, models.FilesResponse](client, NewFilePathHandler()),
+		client:  client,
 	}
 }
 
@@ -120,6 +122,43 @@ func (s *FileService) Upload(ctx context.Context, file *models.FileResponse, f [
 	return nil
 }
 
+// Download retrieves a file's metadata and opens its contents for reading.
+// The caller must close the returned reader.
+func (s *FileService) Download(ctx context.Context, id int) (*models.File, io.ReadCloser, error) {
+	if id <= 0 {
+		return nil, nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	file, err := s.Get(ctx, id, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if file.File.URL == nil || *file.File.URL == "" {
+		return nil, nil, fmt.Errorf("file %d has no download URL", id)
+	}
+
+	// The download URL is pre-signed by the API, so it is fetched directly
+	// rather than through doRequest, which would add the API credentials.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *file.File.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, nil, fmt.Errorf("failed to download file, status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	return &file.File, resp.Body, nil
+}
+
 // Update updates an existing file
 func (s *FileService) Update(ctx context.Context, id int, file *models.FileResponse) (*models.FileResponse, error) {
 	return s.Service.Update(ctx, id, file)
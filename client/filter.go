@@ -3,6 +3,9 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
 )
 
 // FilterOperator represents the available filter operators
@@ -86,9 +89,11 @@ func (f *FilterBuilder) Nin(field string, values []any) *FilterBuilder {
 
 // And adds an AND condition
 func (f *FilterBuilder) And(filters ...*FilterBuilder) *FilterBuilder {
-	conditions := make([]map[string]any, len(filters))
-	for i, filter := range filters {
-		conditions[i] = filter.filter
+	conditions := make([]map[string]any, 0, len(filters))
+	for _, filter := range filters {
+		if filter != nil {
+			conditions = append(conditions, filter.filter)
+		}
 	}
 	f.filter[string(OpAnd)] = conditions
 	return f
@@ -96,28 +101,78 @@ func (f *FilterBuilder) And(filters ...*FilterBuilder) *FilterBuilder {
 
 // Or adds an OR condition
 func (f *FilterBuilder) Or(filters ...*FilterBuilder) *FilterBuilder {
-	conditions := make([]map[string]any, len(filters))
-	for i, filter := range filters {
-		conditions[i] = filter.filter
+	conditions := make([]map[string]any, 0, len(filters))
+	for _, filter := range filters {
+		if filter != nil {
+			conditions = append(conditions, filter.filter)
+		}
 	}
 	f.filter[string(OpOr)] = conditions
 	return f
 }
 
-// Build returns the filter as a JSON string
+// Build returns the filter as a JSON string. It returns an empty string if a
+// value cannot be encoded as JSON (for example NaN or a channel); use Encode to
+// get the error instead.
 func (f *FilterBuilder) Build() string {
+	data, err := f.Encode()
+	if err != nil {
+		return ""
+	}
+	return data
+}
+
+// Encode returns the filter as a JSON string, or an error if one of the
+// values cannot be encoded as JSON
+func (f *FilterBuilder) Encode() (string, error) {
 	data, err := json.Marshal(f.filter)
 	if err != nil {
-		// This should never happen as we control the structure
-		panic(fmt.Sprintf("failed to marshal filter: %v", err))
+		return "", fmt.Errorf("failed to marshal filter: %w", err)
 	}
-	return string(data)
+	return string(data), nil
 }
 
-// addCondition adds a condition to the filter
+// addCondition adds a condition to the filter. A condition on a field that
+// was previously used for a logical operator replaces it.
 func (f *FilterBuilder) addCondition(field string, op FilterOperator, value any) {
-	if _, exists := f.filter[field]; !exists {
-		f.filter[field] = make(map[string]any)
+	conditions, ok := f.filter[field].(map[string]any)
+	if !ok {
+		conditions = make(map[string]any)
+		f.filter[field] = conditions
+	}
+	conditions[string(op)] = value
+}
+
+// externalIDParams builds list parameters that filter on an exact external ID
+func externalIDParams(externalID string) url.Values {
+	params := url.Values{}
+	params.Set("filter", NewFilter().Eq("externalId", externalID).Build())
+	return params
+}
+
+// withStateFilter returns a copy of params whose filter also requires the
+// given state. A filter already present in params is kept and combined with
+// the state condition.
+func withStateFilter(params url.Values, state models.State) (url.Values, error) {
+	out := url.Values{}
+	for k, v := range params {
+		out[k] = append([]string(nil), v...)
+	}
+
+	filter := NewFilter().Eq("state", state)
+	if existing := params.Get("filter"); existing != "" {
+		parsed := NewFilter()
+		if err := json.Unmarshal([]byte(existing), &parsed.filter); err != nil {
+			return nil, fmt.Errorf("failed to parse filter: %w", err)
+		}
+		filter = NewFilter().And(parsed, filter)
 	}
-	f.filter[field].(map[string]any)[string(op)] = value
+
+	encoded, err := filter.Encode()
+	if err != nil {
+		return nil, err
+	}
+	out.Set("filter", encoded)
+
+	return out, nil
 }
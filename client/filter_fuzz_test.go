@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func FuzzFilterBuilder(f *testing.F) {
+	f.Add("status", "open", int64(1), 1.5, uint8(0))
+	f.Add("$and", "", int64(-1), math.Inf(1), uint8(7))
+	f.Add("", "\x00\xff", int64(0), math.NaN(), uint8(255))
+
+	f.Fuzz(func(t *testing.T, field, s string, n int64, x float64, ops uint8) {
+		build := func() *FilterBuilder {
+			fb := NewFilter()
+			values := []any{s, n, x, nil, []any{s, n}}
+			for i := range 8 {
+				v := values[i%len(values)]
+				switch (int(ops) >> (i % 8)) & 7 {
+				case 0:
+					fb.Eq(field, v)
+				case 1:
+					fb.Ne(field, v)
+				case 2:
+					fb.Lt(field, v)
+				case 3:
+					fb.Gte(field, v)
+				case 4:
+					fb.In(field, []any{v, s})
+				case 5:
+					fb.Nin(field, nil)
+				case 6:
+					fb.And(NewFilter().Eq(s, v), nil)
+				case 7:
+					fb.Or(nil, NewFilter().Gt(field, x))
+				}
+			}
+			return fb
+		}
+
+		fb := build()
+		out, err := fb.Encode()
+		if err != nil {
+			if fb.Build() != "" {
+				t.Fatalf("expected Build to return an empty string when Encode fails")
+			}
+			return
+		}
+
+		if out != fb.Build() {
+			t.Fatalf("Build and Encode disagree: %q vs %q", fb.Build(), out)
+		}
+		if !json.Valid([]byte(out)) {
+			t.Fatalf("filter is not valid JSON: %q", out)
+		}
+	})
+}
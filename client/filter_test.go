@@ -2,7 +2,10 @@ package client
 
 import (
 	"encoding/json"
+	"net/url"
 	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
 )
 
 func TestFilterOperatorConstants(t *testing.T) {
@@ -458,3 +461,36 @@ func TestFilterBuilderImmutability(t *testing.T) {
 		t.Error("Different filters should produce different outputs")
 	}
 }
+
+func TestWithStateFilter(t *testing.T) {
+	params := url.Values{}
+	params.Set("filter", NewFilter().Eq("email", "a@example.com").Build())
+	params.Set("page", "2")
+
+	got, err := withStateFilter(params, models.StateDeleted)
+	if err != nil {
+		t.Fatalf("withStateFilter() returned error: %v", err)
+	}
+
+	want := `{"$and":[{"email":{"$eq":"a@example.com"}},{"state":{"$eq":"deleted"}}]}`
+	if got.Get("filter") != want {
+		t.Errorf("Expected filter %s, got %s", want, got.Get("filter"))
+	}
+
+	if got.Get("page") != "2" {
+		t.Errorf("Expected page to be kept, got %q", got.Get("page"))
+	}
+
+	if params.Get("filter") == want {
+		t.Error("withStateFilter() modified the original params")
+	}
+
+	got, err = withStateFilter(nil, models.StateDeleted)
+	if err != nil {
+		t.Fatalf("withStateFilter() returned error: %v", err)
+	}
+
+	if want := `{"state":{"$eq":"deleted"}}`; got.Get("filter") != want {
+		t.Errorf("Expected filter %s, got %s", want, got.Get("filter"))
+	}
+}
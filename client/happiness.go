@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// HappinessService reads happiness survey responses, for example to feed CSAT
+// reports. Responses are created by customers, so the service is read-only.
+type HappinessService struct {
+	*Service[models.HappinessSurveyResponse, models.HappinessSurveysResponse]
+}
+
+// NewHappinessService creates a new happiness service
+func NewHappinessService(client *Client) *HappinessService {
+	return &HappinessService{
+		Service: NewService[models.HappinessSurveyResponse, models.HappinessSurveysResponse](client, NewDefaultPathHandler("happinesssurveys")),
+	}
+}
+
+// Get retrieves a survey response by ID
+func (s *HappinessService) Get(ctx context.Context, id int, params url.Values) (*models.HappinessSurveyResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of survey responses. Use HappinessListParams to
+// filter by rating or date range:
+//
+//	resp, err := c.Happiness.List(ctx, (&client.HappinessListParams{
+//		Since: time.Now().AddDate(0, -1, 0),
+//	}).Values())
+func (s *HappinessService) List(ctx context.Context, params url.Values) (*models.HappinessSurveysResponse, error) {
+	return s.Service.List(ctx, params)
+}
@@ -0,0 +1,446 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HARMiddlewareName is the name the HAR recorder is registered under
+const HARMiddlewareName = "har"
+
+const harRedacted = "[REDACTED]"
+
+// HAR is an HTTP Archive (HAR 1.2) document. Only the fields needed to
+// inspect and replay API traffic are modelled.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of a HAR document
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that produced the archive
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request/response pair
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest is a recorded request
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is a recorded response
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a header or query string parameter
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is a recorded request body
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is a recorded response body
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings breaks down the time spent on an entry. The recorder only knows
+// the total, which is reported as wait time.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ReadHAR decodes a HAR document
+func ReadHAR(r io.Reader) (*HAR, error) {
+	var har HAR
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, err
+	}
+	return &har, nil
+}
+
+// HARRecorder captures every request a client makes so it can be saved as a
+// HAR file and attached to a bug report. Credentials are redacted before
+// anything is stored.
+type HARRecorder struct {
+	headers map[string]bool
+	fields  map[string]bool
+
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// HAROption configures a HARRecorder
+type HAROption func(*HARRecorder)
+
+// WithHARRedactedHeaders redacts additional request and response headers.
+// Authorization, Cookie, Set-Cookie and X-API-Key are always redacted.
+func WithHARRedactedHeaders(names ...string) HAROption {
+	return func(r *HARRecorder) {
+		for _, name := range names {
+			r.headers[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// WithHARRedactedFields redacts additional JSON body fields and query
+// parameters, matched case-insensitively at any depth. password, token,
+// apiKey and secret are always redacted.
+func WithHARRedactedFields(names ...string) HAROption {
+	return func(r *HARRecorder) {
+		for _, name := range names {
+			r.fields[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// NewHARRecorder creates an empty recorder
+func NewHARRecorder(opts ...HAROption) *HARRecorder {
+	r := &HARRecorder{
+		headers: map[string]bool{"authorization": true, "cookie": true, "set-cookie": true, "x-api-key": true},
+		fields:  map[string]bool{"password": true, "token": true, "apikey": true, "secret": true},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// WithHARRecorder records all of the client's traffic into r. The recorder
+// runs innermost in the middleware chain so it captures exactly what is sent
+// on the wire.
+func WithHARRecorder(r *HARRecorder) Option {
+	return WithMiddlewarePriority(HARMiddlewareName, math.MaxInt, r.Middleware())
+}
+
+// Middleware returns the middleware that records traffic into r
+func (r *HARRecorder) Middleware() MiddlewareFunc {
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		var reqBody []byte
+		if req.Body != nil {
+			b, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			reqBody = b
+			req.Body = io.NopCloser(bytes.NewReader(b))
+		}
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		if err != nil {
+			return resp, err
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		r.add(HAREntry{
+			StartedDateTime: start,
+			Time:            elapsed,
+			Request:         r.request(req, reqBody),
+			Response:        r.response(resp, respBody),
+			Timings:         HARTimings{Wait: elapsed},
+		})
+
+		return resp, nil
+	}
+}
+
+// HAR returns the traffic recorded so far
+func (r *HARRecorder) HAR() *HAR {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "desksdkgo", Version: "1"},
+		Entries: append([]HAREntry{}, r.entries...),
+	}}
+}
+
+// WriteTo writes the recorded traffic as a HAR document
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// WriteFile writes the recorded traffic to a HAR file at path
+func (r *HARRecorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (r *HARRecorder) add(entry HAREntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+}
+
+func (r *HARRecorder) request(req *http.Request, body []byte) HARRequest {
+	u := *req.URL
+	query := u.Query()
+	var params []HARNameValue
+	for name, values := range query {
+		for i, v := range values {
+			if r.fields[strings.ToLower(name)] {
+				v = harRedacted
+				values[i] = v
+			}
+			params = append(params, HARNameValue{Name: name, Value: v})
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	out := HARRequest{
+		Method:      req.Method,
+		URL:         u.String(),
+		HTTPVersion: req.Proto,
+		Headers:     r.headerList(req.Header),
+		QueryString: params,
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	if out.HTTPVersion == "" {
+		out.HTTPVersion = "HTTP/1.1"
+	}
+	if len(body) > 0 {
+		out.PostData = &HARPostData{MimeType: req.Header.Get("Content-Type"), Text: r.redactBody(body)}
+	}
+
+	return out
+}
+
+func (r *HARRecorder) response(resp *http.Response, body []byte) HARResponse {
+	out := HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Headers:     r.headerList(resp.Header),
+		Content: HARContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     r.redactBody(body),
+		},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	if out.HTTPVersion == "" {
+		out.HTTPVersion = "HTTP/1.1"
+	}
+
+	return out
+}
+
+func (r *HARRecorder) headerList(h http.Header) []HARNameValue {
+	var out []HARNameValue
+	for name, values := range h {
+		for _, v := range values {
+			if r.headers[strings.ToLower(name)] {
+				v = harRedacted
+			}
+			out = append(out, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// redactBody replaces sensitive fields in JSON bodies. Bodies that are not
+// JSON are stored unchanged.
+func (r *HARRecorder) redactBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	b, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+func (r *HARRecorder) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if r.fields[strings.ToLower(k)] {
+				v[k] = harRedacted
+				continue
+			}
+			v[k] = r.redactValue(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.redactValue(child)
+		}
+	}
+	return v
+}
+
+// ReplayResult is the outcome of replaying one HAR entry
+type ReplayResult struct {
+	Method         string `json:"method"`
+	URL            string `json:"url"`
+	RecordedStatus int    `json:"recordedStatus"`
+	Status         int    `json:"status,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Replay sends every request recorded in har to this client's base URL, in
+// order, and reports how each response compares with the recorded one.
+// originalBaseURL is the base URL the capture was made against; when empty
+// it is assumed to differ from this client's only in scheme and host.
+// Requests use this client's credentials, since recorded ones are redacted.
+// Replay stops early only if ctx is cancelled.
+func (c *Client) Replay(ctx context.Context, har *HAR, originalBaseURL string) ([]ReplayResult, error) {
+	if har == nil {
+		return nil, fmt.Errorf("har is required")
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ReplayResult
+	for _, entry := range har.Log.Entries {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		result := ReplayResult{Method: entry.Request.Method, RecordedStatus: entry.Response.Status}
+
+		target, err := replayURL(entry.Request.URL, originalBaseURL, base)
+		if err != nil {
+			result.URL = entry.Request.URL
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.URL = target
+
+		var body io.Reader
+		if entry.Request.PostData != nil {
+			body = strings.NewReader(entry.Request.PostData.Text)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, entry.Request.Method, target, body)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		for _, h := range entry.Request.Headers {
+			if h.Value != harRedacted && !strings.EqualFold(h.Name, "Content-Length") {
+				req.Header.Add(h.Name, h.Value)
+			}
+		}
+
+		resp, err := c.doRequest(ctx, req)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		result.Status = resp.StatusCode
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// replayURL rebases a recorded URL onto base
+func replayURL(recorded, originalBaseURL string, base *url.URL) (string, error) {
+	u, err := url.Parse(recorded)
+	if err != nil {
+		return "", err
+	}
+
+	from := originalBaseURL
+	if from == "" {
+		from = (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: base.Path}).String()
+	}
+	from = strings.TrimSuffix(from, "/")
+
+	if !strings.HasPrefix(recorded, from) {
+		return "", fmt.Errorf("recorded URL is not under %s", from)
+	}
+
+	return strings.TrimSuffix(base.String(), "/") + strings.TrimPrefix(recorded, from), nil
+}
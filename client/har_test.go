@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestHARRecorder(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/customers.json", http.StatusCreated, models.CustomerResponse{
+		Customer: models.Customer{BaseEntity: models.BaseEntity{ID: 5}},
+	})
+
+	recorder := NewHARRecorder(WithHARRedactedFields("email"))
+	c := NewClient("https://example.com",
+		WithAPIKey("secret-key"),
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithHARRecorder(recorder),
+	)
+
+	created, err := c.Customers.Create(context.Background(), &models.CustomerResponse{
+		Customer: models.Customer{Email: ptr("jane@example.com"), FirstName: ptr("Jane")},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.Customer.ID != 5 {
+		t.Errorf("expected the response to be passed through, got %+v", created.Customer)
+	}
+
+	var buf bytes.Buffer
+	if _, err := recorder.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "secret-key") || strings.Contains(out, "jane@example.com") {
+		t.Errorf("expected credentials and redacted fields to be removed, got %s", out)
+	}
+
+	har, err := ReadHAR(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(har.Log.Entries))
+	}
+
+	entry := har.Log.Entries[0]
+	if entry.Request.Method != http.MethodPost || entry.Response.Status != http.StatusCreated {
+		t.Errorf("unexpected entry %s %d", entry.Request.Method, entry.Response.Status)
+	}
+	if entry.Request.PostData == nil || !strings.Contains(entry.Request.PostData.Text, "Jane") {
+		t.Errorf("expected request body to be recorded, got %+v", entry.Request.PostData)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/desk/api/v2/tickets.json", http.StatusOK, models.TicketsResponse{})
+
+	c := NewClient("https://staging.example.com/desk/api/v2",
+		WithAPIKey("staging-key"),
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+	)
+
+	har := &HAR{Log: HARLog{Entries: []HAREntry{
+		{
+			Request:  HARRequest{Method: http.MethodGet, URL: "https://prod.example.com/desk/api/v2/tickets.json?page=2", Headers: []HARNameValue{{Name: "Authorization", Value: harRedacted}}},
+			Response: HARResponse{Status: http.StatusOK},
+		},
+		{
+			Request:  HARRequest{Method: http.MethodGet, URL: "https://files.example.com/download/1"},
+			Response: HARResponse{Status: http.StatusOK},
+		},
+	}}}
+
+	results, err := c.Replay(context.Background(), har, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].URL != "https://staging.example.com/desk/api/v2/tickets.json?page=2" || results[0].Status != http.StatusOK {
+		t.Errorf("unexpected result %+v", results[0])
+	}
+	if results[1].Error == "" {
+		t.Errorf("expected an error for a URL outside the API, got %+v", results[1])
+	}
+
+	if got := mockTransport.GetRequests()[0].Header.Get("Authorization"); got != "Bearer staging-key" {
+		t.Errorf("expected replay to use the client's credentials, got %q", got)
+	}
+}
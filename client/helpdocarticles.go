@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/teamwork/desksdkgo/models"
@@ -38,3 +40,77 @@ func (s *HelpDocArticleService) Create(ctx context.Context, article *models.Help
 func (s *HelpDocArticleService) Update(ctx context.Context, id int, article *models.HelpDocArticleResponse) (*models.HelpDocArticleResponse, error) {
 	return s.Service.Update(ctx, id, article)
 }
+
+// Publish makes an article visible on its site
+func (s *HelpDocArticleService) Publish(ctx context.Context, id int) (*models.HelpDocArticleResponse, error) {
+	return s.setStatus(ctx, id, models.HelpDocArticleStatusPublished)
+}
+
+// Unpublish takes an article off its site and turns it back into a draft.
+// Its content is kept.
+func (s *HelpDocArticleService) Unpublish(ctx context.Context, id int) (*models.HelpDocArticleResponse, error) {
+	return s.setStatus(ctx, id, models.HelpDocArticleStatusDraft)
+}
+
+func (s *HelpDocArticleService) setStatus(ctx context.Context, id int, status models.HelpDocArticleStatus) (*models.HelpDocArticleResponse, error) {
+	return s.Patch(ctx, id, map[string]models.HelpDocArticleStatus{"status": status})
+}
+
+// ListRevisions retrieves the saved versions of an article, one page at a
+// time
+func (s *HelpDocArticleService) ListRevisions(ctx context.Context, id int, params url.Values) (*models.HelpDocArticleRevisionsResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	path := fmt.Sprintf("helpdocssites/helpdocarticles/%d/revisions.json", id)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.HelpDocArticleRevisionsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// RestoreRevision rolls an article's title and contents back to those of one
+// of its revisions. The restore is saved as a new revision, so it can itself
+// be rolled back. The article's publishing status is not changed.
+func (s *HelpDocArticleService) RestoreRevision(ctx context.Context, id, revisionID int) (*models.HelpDocArticleResponse, error) {
+	if revisionID <= 0 {
+		return nil, fmt.Errorf("revisionID must be greater than 0")
+	}
+
+	list := func(ctx context.Context, params url.Values) (*models.HelpDocArticleRevisionsResponse, error) {
+		return s.ListRevisions(ctx, id, params)
+	}
+
+	var revision *models.HelpDocArticleRevision
+	err := listPages(ctx, nil, list, func(page *models.HelpDocArticleRevisionsResponse) error {
+		for i := range page.Items {
+			if page.Items[i].ID == revisionID {
+				revision = &page.Items[i]
+				return ErrStopPaging
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		return nil, fmt.Errorf("revision %d not found on article %d", revisionID, id)
+	}
+
+	partial := map[string]string{}
+	if revision.Title != nil {
+		partial["title"] = *revision.Title
+	}
+	if revision.Contents != nil {
+		partial["contents"] = *revision.Contents
+	}
+	return s.Patch(ctx, id, partial)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestHelpDocArticleServicePublishAndRestore(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/helpdocssites/helpdocarticles/5.json", http.StatusOK, `{"helpDocArticle":{"id":5,"status":"published"}}`)
+	mockTransport.AddResponse(http.MethodGet, "/helpdocssites/helpdocarticles/5/revisions.json", http.StatusOK,
+		`{"helpdocarticlerevisions":[{"id":71,"revision":2,"title":"Old title","contents":"Old body"}],"pagination":{"hasMorePages":false}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	article, err := c.HelpDocArticles.Publish(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !article.HelpDocArticle.IsPublished() {
+		t.Errorf("expected the article to be published, got %+v", article.HelpDocArticle.Status)
+	}
+
+	if _, err := c.HelpDocArticles.RestoreRevision(context.Background(), 5, 71); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.HelpDocArticles.RestoreRevision(context.Background(), 5, 72); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(requests))
+	}
+	for i, want := range map[int]string{
+		0: `{"helpDocArticle":{"status":"published"}}`,
+		2: `{"helpDocArticle":{"contents":"Old body","title":"Old title"}}`,
+	} {
+		b, _ := io.ReadAll(requests[i].Body)
+		if string(b) != want {
+			t.Errorf("request %d: expected body %s, got %s", i, want, b)
+		}
+	}
+}
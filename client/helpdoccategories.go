@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// HelpDocCategoryService handles help doc category-related operations
+type HelpDocCategoryService struct {
+	*Service[models.HelpDocCategoryResponse, models.HelpDocCategoriesResponse]
+}
+
+// NewHelpDocCategoryService creates a new help doc category service
+func NewHelpDocCategoryService(client *Client) *HelpDocCategoryService {
+	return &HelpDocCategoryService{
+		Service: NewService[models.HelpDocCategoryResponse, models.HelpDocCategoriesResponse](client, NewDefaultPathHandler("helpdocssites/helpdoccategories")),
+	}
+}
+
+// Get retrieves a help doc category by ID
+func (s *HelpDocCategoryService) Get(ctx context.Context, id int, params url.Values) (*models.HelpDocCategoryResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of help doc categories with optional filters
+func (s *HelpDocCategoryService) List(ctx context.Context, params url.Values) (*models.HelpDocCategoriesResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Create creates a new help doc category. The site and name are required.
+func (s *HelpDocCategoryService) Create(ctx context.Context, category *models.HelpDocCategoryResponse) (*models.HelpDocCategoryResponse, error) {
+	if category == nil {
+		return nil, fmt.Errorf("category is required")
+	}
+	if category.Item.Helpdocsite.ID <= 0 {
+		return nil, fmt.Errorf("category.helpdocsite.id is required")
+	}
+	if category.Item.Name == nil || *category.Item.Name == "" {
+		return nil, fmt.Errorf("category.name is required")
+	}
+
+	return s.Service.Create(ctx, category)
+}
+
+// CreateBatch creates help doc categories in parallel, validating each one as
+// Create does. See Service.CreateBatch.
+func (s *HelpDocCategoryService) CreateBatch(ctx context.Context, items []*models.HelpDocCategoryResponse, opts BatchOptions) ([]BatchResult[models.HelpDocCategoryResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing help doc category
+func (s *HelpDocCategoryService) Update(ctx context.Context, id int, category *models.HelpDocCategoryResponse) (*models.HelpDocCategoryResponse, error) {
+	return s.Service.Update(ctx, id, category)
+}
+
+// Delete deletes a help doc category
+func (s *HelpDocCategoryService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
+
+// Reorder sets the display order of categories to the order of categoryIDs.
+// Categories that are not listed keep their current position after the
+// listed ones.
+func (s *HelpDocCategoryService) Reorder(ctx context.Context, categoryIDs []int) error {
+	if len(categoryIDs) == 0 {
+		return fmt.Errorf("categoryIDs is required")
+	}
+
+	seen := make(map[int]bool, len(categoryIDs))
+	positions := make([]models.HelpDocCategoryPosition, len(categoryIDs))
+	for i, id := range categoryIDs {
+		if id <= 0 {
+			return fmt.Errorf("categoryIDs[%d] must be greater than 0", i)
+		}
+		if seen[id] {
+			return fmt.Errorf("categoryIDs contains %d more than once", id)
+		}
+		seen[id] = true
+		positions[i] = models.HelpDocCategoryPosition{ID: id, DisplayOrder: i}
+	}
+
+	body := map[string][]models.HelpDocCategoryPosition{"helpdoccategories": positions}
+
+	return s.request(ctx, http.MethodPut, "helpdocssites/helpdoccategories/order.json", body, nil)
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestHelpDocCategoryServiceReorder(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPut, "/helpdocssites/helpdoccategories/order.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	if err := c.HelpDocCategories.Reorder(context.Background(), []int{9, 4, 6}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected a single request, got %d", len(requests))
+	}
+
+	b, err := io.ReadAll(requests[0].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+
+	var sent struct {
+		Categories []models.HelpDocCategoryPosition `json:"helpdoccategories"`
+	}
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+
+	want := []models.HelpDocCategoryPosition{{ID: 9, DisplayOrder: 0}, {ID: 4, DisplayOrder: 1}, {ID: 6, DisplayOrder: 2}}
+	if !slices.Equal(sent.Categories, want) {
+		t.Errorf("expected positions %+v, got %+v", want, sent.Categories)
+	}
+
+	for _, ids := range [][]int{nil, {1, 1}, {1, 0}} {
+		if err := c.HelpDocCategories.Reorder(context.Background(), ids); err == nil {
+			t.Errorf("expected an error for category IDs %v", ids)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 1 {
+		t.Errorf("expected invalid reorders not to send requests, got %d requests", n)
+	}
+}
+
+func TestHelpDocCategoryServiceCreateValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	name := "Billing"
+	for _, category := range []*models.HelpDocCategoryResponse{
+		nil,
+		{Item: models.HelpDocCategory{Name: &name}},
+		{Item: models.HelpDocCategory{Helpdocsite: models.EntityRef{ID: 1}}},
+	} {
+		if _, err := c.HelpDocCategories.Create(context.Background(), category); err == nil {
+			t.Errorf("expected an error for %+v", category)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// HelpDocCommentService handles comments on help doc articles
+type HelpDocCommentService struct {
+	*Service[models.HelpDocCommentResponse, models.HelpDocCommentsResponse]
+}
+
+// NewHelpDocCommentService creates a new help doc comment service
+func NewHelpDocCommentService(client *Client) *HelpDocCommentService {
+	return &HelpDocCommentService{
+		Service: NewService[models.HelpDocCommentResponse, models.HelpDocCommentsResponse](client, NewDefaultPathHandler("helpdocssites/helpdoccomments")),
+	}
+}
+
+// Get retrieves a help doc comment by ID
+func (s *HelpDocCommentService) Get(ctx context.Context, id int, params url.Values) (*models.HelpDocCommentResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of help doc comments across all articles
+func (s *HelpDocCommentService) List(ctx context.Context, params url.Values) (*models.HelpDocCommentsResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// ListForArticle retrieves the comments on an article
+func (s *HelpDocCommentService) ListForArticle(ctx context.Context, articleID int, params url.Values) (*models.HelpDocCommentsResponse, error) {
+	if articleID <= 0 {
+		return nil, fmt.Errorf("articleID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("helpdocssites/helpdocarticles/%d/comments.json", articleID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.HelpDocCommentsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Create adds a comment to an article. The article and body are required.
+func (s *HelpDocCommentService) Create(ctx context.Context, comment *models.HelpDocCommentResponse) (*models.HelpDocCommentResponse, error) {
+	if comment == nil {
+		return nil, fmt.Errorf("comment is required")
+	}
+	if comment.Item.Article.ID <= 0 {
+		return nil, fmt.Errorf("comment.article.id is required")
+	}
+	if comment.Item.Body == nil || *comment.Item.Body == "" {
+		return nil, fmt.Errorf("comment.body is required")
+	}
+
+	return s.Service.Create(ctx, comment)
+}
+
+// CreateBatch creates help doc comments in parallel, validating each one as
+// Create does. See Service.CreateBatch.
+func (s *HelpDocCommentService) CreateBatch(ctx context.Context, items []*models.HelpDocCommentResponse, opts BatchOptions) ([]BatchResult[models.HelpDocCommentResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing help doc comment
+func (s *HelpDocCommentService) Update(ctx context.Context, id int, comment *models.HelpDocCommentResponse) (*models.HelpDocCommentResponse, error) {
+	return s.Service.Update(ctx, id, comment)
+}
+
+// SetStatus moderates a comment, for example approving it or marking it as
+// spam
+func (s *HelpDocCommentService) SetStatus(ctx context.Context, id int, status models.HelpDocCommentStatus) (*models.HelpDocCommentResponse, error) {
+	if status == "" {
+		return nil, fmt.Errorf("status is required")
+	}
+
+	return s.Patch(ctx, id, map[string]models.HelpDocCommentStatus{"status": status})
+}
+
+// Delete deletes a help doc comment
+func (s *HelpDocCommentService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestHelpDocCommentServiceListForArticle(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/helpdocssites/helpdocarticles/12/comments.json", http.StatusOK, `{"helpdoccomments":[{"id":3,"article":{"id":12}}]}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	comments, err := c.HelpDocComments.ListForArticle(context.Background(), 12, url.Values{"page": {"2"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(comments.Items) != 1 || comments.Items[0].ID != 3 {
+		t.Errorf("unexpected comments %+v", comments.Items)
+	}
+
+	if got := mockTransport.GetRequests()[0].URL.Query().Get("page"); got != "2" {
+		t.Errorf("expected the params to be passed on, got page %q", got)
+	}
+
+	if _, err := c.HelpDocComments.ListForArticle(context.Background(), 0, nil); err == nil {
+		t.Error("expected an error for articleID 0")
+	}
+}
+
+func TestHelpDocCommentServiceSetStatus(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/helpdocssites/helpdoccomments/3.json", http.StatusOK, `{"helpDocComment":{"id":3,"status":"spam"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	comment, err := c.HelpDocComments.SetStatus(context.Background(), 3, models.HelpDocCommentStatusSpam)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if comment.Item.Status == nil || *comment.Item.Status != models.HelpDocCommentStatusSpam {
+		t.Errorf("unexpected comment %+v", comment.Item)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if want := `{"helpDocComment":{"status":"spam"}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+
+	if _, err := c.HelpDocComments.SetStatus(context.Background(), 3, ""); err == nil {
+		t.Error("expected an error for an empty status")
+	}
+}
+
+func TestHelpDocCommentServiceCreateValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	body := "Helpful, thanks"
+	for _, comment := range []*models.HelpDocCommentResponse{
+		nil,
+		{Item: models.HelpDocComment{Body: &body}},
+		{Item: models.HelpDocComment{Article: models.EntityRef{ID: 12}}},
+	} {
+		if _, err := c.HelpDocComments.Create(context.Background(), comment); err == nil {
+			t.Errorf("expected an error for %+v", comment)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// hooks holds a before/after pair registered with WithHooks
+type hooks struct {
+	before func(*http.Request)
+	after  func(*http.Response)
+}
+
+type hooksContextKey struct{}
+
+// WithHooks registers functions that are called around every request the
+// client sends. before runs after the default headers are set and before any
+// middleware, so it can add or override headers; after runs once a response
+// has been received and is not called when the request fails. Either may be
+// nil. Use Service.WithHooks to limit hooks to a single resource.
+func WithHooks(before func(*http.Request), after func(*http.Response)) Option {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, hooks{before: before, after: after})
+	}
+}
+
+// HookEvent describes a request for hooks registered with WithRequestHook and
+// WithResponseHook. The bodies are copies; the request and response can still
+// be read as usual.
+type HookEvent struct {
+	Request *http.Request
+	// RequestBody is the body sent with the request, nil when there is none
+	RequestBody []byte
+	// Start is when the request entered the middleware chain
+	Start time.Time
+
+	// Response is nil in request hooks and when the request failed
+	Response *http.Response
+	// ResponseBody is the full response body
+	ResponseBody []byte
+	// Err is the error the request failed with
+	Err error
+	// Duration is the time the request took, including any retries made by
+	// middleware
+	Duration time.Duration
+}
+
+// RequestHook is called with every request before it is sent
+type RequestHook func(ctx context.Context, e *HookEvent)
+
+// ResponseHook is called once every request has completed, whether or not
+// it succeeded
+type ResponseHook func(ctx context.Context, e *HookEvent)
+
+// WithRequestHook registers a function that sees every request, with its
+// body, before it is sent. It runs after the before hooks of WithHooks, so
+// the headers are final apart from what middleware adds. Use it for auditing
+// or capture rather than changing the request.
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook registers a function that sees every completed request
+// with both bodies and its duration, including failed requests. Unlike the
+// after hook of WithHooks it does not need to read the response body itself.
+//
+//	client.WithResponseHook(func(ctx context.Context, e *client.HookEvent) {
+//		metrics.Observe(e.Request.URL.Path, e.Duration)
+//	})
+func WithResponseHook(hook ResponseHook) Option {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// runRequestHooks calls the request hooks and returns the event to pass to
+// the response hooks, or nil when there are no hooks
+func (c *Client) runRequestHooks(ctx context.Context, req *http.Request) *HookEvent {
+	if len(c.requestHooks) == 0 && len(c.responseHooks) == 0 {
+		return nil
+	}
+
+	e := &HookEvent{Request: req, Start: time.Now()}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			e.RequestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	for _, hook := range c.requestHooks {
+		hook(ctx, e)
+	}
+	return e
+}
+
+// runResponseHooks completes e with the outcome of the request and calls the
+// response hooks, leaving the response body readable by the caller
+func (c *Client) runResponseHooks(ctx context.Context, e *HookEvent, resp *http.Response, err error) {
+	if e == nil || len(c.responseHooks) == 0 {
+		return
+	}
+
+	e.Duration = time.Since(e.Start)
+	e.Response = resp
+	e.Err = err
+	if resp != nil && resp.Body != nil {
+		b, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		e.ResponseBody = b
+		if readErr != nil && e.Err == nil {
+			e.Err = readErr
+		}
+	}
+
+	for _, hook := range c.responseHooks {
+		hook(ctx, e)
+	}
+}
+
+// WithHooks registers functions that are called around every request this
+// service sends, in addition to the client's hooks. It is safe to call while
+// the service is in use; requests already in flight are not affected.
+//
+//	c.Tickets.WithHooks(func(req *http.Request) {
+//		req.Header.Set("X-Cost-Center", "support")
+//	}, nil)
+func (s *Service[T, L]) WithHooks(before func(*http.Request), after func(*http.Response)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hooks{before: before, after: after})
+}
+
+// serviceHooks returns a snapshot of the service's hooks
+func (s *Service[T, L]) serviceHooks() []hooks {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.hooks[:len(s.hooks):len(s.hooks)]
+}
+
+// withHooks attaches service hooks to ctx so doRequest can run them
+func withHooks(ctx context.Context, h []hooks) context.Context {
+	if len(h) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, hooksContextKey{}, h)
+}
+
+// runBeforeHooks calls the client hooks followed by any service hooks in ctx
+func (c *Client) runBeforeHooks(ctx context.Context, req *http.Request) {
+	serviceHooks, _ := ctx.Value(hooksContextKey{}).([]hooks)
+	for _, list := range [][]hooks{c.hooks, serviceHooks} {
+		for _, h := range list {
+			if h.before != nil {
+				h.before(req)
+			}
+		}
+	}
+}
+
+// runAfterHooks calls the client hooks followed by any service hooks in ctx
+func (c *Client) runAfterHooks(ctx context.Context, resp *http.Response) {
+	serviceHooks, _ := ctx.Value(hooksContextKey{}).([]hooks)
+	for _, list := range [][]hooks{c.hooks, serviceHooks} {
+		for _, h := range list {
+			if h.after != nil {
+				h.after(resp)
+			}
+		}
+	}
+}
+
+// do sends req through the client with this service's hooks applied
+func (s *Service[T, L]) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return s.client.doRequest(withHooks(ctx, s.serviceHooks()), req)
+}
+
+// request is Client.request with this service's hooks applied
+func (s *Service[T, L]) request(ctx context.Context, method, path string, body, out any) error {
+	return s.client.request(withHooks(ctx, s.serviceHooks()), method, path, body, out)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestHooks(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, models.TicketResponse{})
+	mockTransport.AddResponse(http.MethodGet, "/customers/1.json", http.StatusOK, models.CustomerResponse{})
+
+	var statuses []int
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithHooks(func(req *http.Request) {
+			req.Header.Set("X-Client", "desk")
+		}, func(resp *http.Response) {
+			statuses = append(statuses, resp.StatusCode)
+		}),
+	)
+	c.Tickets.WithHooks(func(req *http.Request) {
+		req.Header.Set("X-Resource", "tickets")
+	}, nil)
+
+	if _, err := c.Tickets.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.Customers.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	for _, req := range requests {
+		if req.Header.Get("X-Client") != "desk" {
+			t.Errorf("expected client hook to run for %s", req.URL.Path)
+		}
+	}
+	if requests[0].Header.Get("X-Resource") != "tickets" {
+		t.Errorf("expected ticket hook to run for tickets")
+	}
+	if requests[1].Header.Get("X-Resource") != "" {
+		t.Errorf("expected ticket hook not to run for customers")
+	}
+
+	if len(statuses) != 2 {
+		t.Errorf("expected after hook to run twice, ran %d times", len(statuses))
+	}
+}
+
+func TestRequestResponseHooks(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tags.json", http.StatusCreated, `{"tag":{"id":7,"name":"billing"}}`)
+
+	var requests, responses []*HookEvent
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithRequestHook(func(ctx context.Context, e *HookEvent) {
+			requests = append(requests, e)
+		}),
+		WithResponseHook(func(ctx context.Context, e *HookEvent) {
+			responses = append(responses, e)
+		}),
+	)
+
+	name := "billing"
+	tag, err := c.Tags.Create(context.Background(), &models.TagResponse{Tag: models.Tag{Name: &name}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tag.Tag.ID != 7 {
+		t.Errorf("expected the response body to stay readable, got tag %d", tag.Tag.ID)
+	}
+
+	if len(requests) != 1 || len(responses) != 1 {
+		t.Fatalf("expected each hook to run once, got %d and %d", len(requests), len(responses))
+	}
+
+	e := responses[0]
+	if !strings.Contains(string(e.RequestBody), `"name":"billing"`) {
+		t.Errorf("expected the request body, got %s", e.RequestBody)
+	}
+	if string(e.ResponseBody) != `{"tag":{"id":7,"name":"billing"}}` {
+		t.Errorf("expected the response body, got %s", e.ResponseBody)
+	}
+	if e.Response == nil || e.Response.StatusCode != http.StatusCreated {
+		t.Errorf("expected the 201 response, got %+v", e.Response)
+	}
+	if e.Start.IsZero() || e.Duration <= 0 {
+		t.Errorf("expected timing, got start %s and duration %s", e.Start, e.Duration)
+	}
+}
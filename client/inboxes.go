@@ -2,7 +2,10 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -10,12 +13,14 @@ import (
 // InboxService handles ticket-related operations
 type InboxService struct {
 	*Service[models.InboxResponse, models.InboxesResponse]
+	client *Client
 }
 
 // NewInboxService creates a new ticket service
 func NewInboxService(client *Client) *InboxService {
 	return &InboxService{
 		Service: NewService[models.InboxResponse, models.InboxesResponse](client, NewDefaultPathHandler("inboxes")),
+		client:  client,
 	}
 }
 
@@ -38,3 +43,243 @@ func (s *InboxService) Create(ctx context.Context, inbox *models.InboxResponse)
 func (s *InboxService) Update(ctx context.Context, id int, inbox *models.InboxResponse) (*models.InboxResponse, error) {
 	return s.Service.Update(ctx, id, inbox)
 }
+
+// DomainVerification retrieves the DKIM, SPF and DMARC status of the domain an
+// inbox sends email from
+func (s *InboxService) DomainVerification(ctx context.Context, inboxID int) (*models.InboxDomainVerification, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	var resp models.InboxDomainVerificationResponse
+	if err := s.request(ctx, http.MethodGet, fmt.Sprintf("inboxes/%d/domainverification.json", inboxID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.DomainVerification, nil
+}
+
+// VerifyDomain triggers a fresh DNS check of the inbox's sending domain and
+// returns the resulting status. Records that were only just published may
+// still report as pending until DNS has propagated.
+func (s *InboxService) VerifyDomain(ctx context.Context, inboxID int) (*models.InboxDomainVerification, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	var resp models.InboxDomainVerificationResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("inboxes/%d/domainverification.json", inboxID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.DomainVerification, nil
+}
+
+// UpdateSendingSettings changes how an inbox sends email, for example to
+// send through the customer's own SMTP server from their own domain, without
+// sending the rest of the inbox. Only the non-nil fields of settings are
+// updated. Follow it with WaitForDomainVerification when the sending domain
+// changes.
+func (s *InboxService) UpdateSendingSettings(ctx context.Context, inboxID int, settings models.InboxSendingSettings) (*models.InboxResponse, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	body := map[string]models.InboxSendingSettings{"inbox": settings}
+
+	var resp models.InboxResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("inboxes/%d.json", inboxID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// WaitForDomainVerification triggers a DNS check of the inbox's sending
+// domain and keeps checking every interval until the domain is verified or
+// ctx is done, for example after publishing the records from
+// DomainVerification. Failed checks are retried because freshly published
+// records can take a while to propagate. When ctx ends first the last status
+// is returned along with the context's error.
+func (s *InboxService) WaitForDomainVerification(ctx context.Context, inboxID int, interval time.Duration) (*models.InboxDomainVerification, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be greater than 0")
+	}
+
+	for {
+		v, err := s.VerifyDomain(ctx, inboxID)
+		if err != nil {
+			return v, err
+		}
+		if v.IsVerified() {
+			return v, nil
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return v, ctx.Err()
+		}
+	}
+}
+
+// UpdateSpamSettings changes how an inbox treats spam-scored mail without
+// sending the rest of the inbox. Only the non-nil fields of settings are
+// updated.
+func (s *InboxService) UpdateSpamSettings(ctx context.Context, inboxID int, settings models.InboxSpamSettings) (*models.InboxResponse, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	body := map[string]models.InboxSpamSettings{"inbox": settings}
+
+	var resp models.InboxResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("inboxes/%d.json", inboxID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// EmailSettings retrieves an inbox's sending, forwarding, auto-reply and
+// signature settings
+func (s *InboxService) EmailSettings(ctx context.Context, inboxID int) (*models.InboxEmailSettings, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	resp, err := s.Get(ctx, inboxID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := resp.Inbox.EmailSettings()
+	return &settings, nil
+}
+
+// UpdateForwardingSettings changes how forwarded email is handled without
+// sending the rest of the inbox. Only the non-nil writable fields of settings
+// are updated.
+func (s *InboxService) UpdateForwardingSettings(ctx context.Context, inboxID int, settings models.InboxForwardingSettings) (*models.InboxResponse, error) {
+	// The read-only fields are dropped so a settings value read with
+	// EmailSettings can be passed back in
+	settings.ForwardingAddress = nil
+	settings.EmailForwardingState = nil
+
+	return s.patchInbox(ctx, inboxID, settings)
+}
+
+// UpdateAutoReply changes the automatic reply sent when a customer opens a
+// ticket, without sending the rest of the inbox. Only the non-nil fields of
+// settings are updated.
+func (s *InboxService) UpdateAutoReply(ctx context.Context, inboxID int, settings models.InboxAutoReplySettings) (*models.InboxResponse, error) {
+	return s.patchInbox(ctx, inboxID, settings)
+}
+
+// SetSignature changes the HTML signature appended to replies sent from an
+// inbox. An empty signature removes it.
+func (s *InboxService) SetSignature(ctx context.Context, inboxID int, signature string) (*models.InboxResponse, error) {
+	return s.patchInbox(ctx, inboxID, map[string]string{"signature": signature})
+}
+
+// patchInbox sends fields as a partial update of an inbox
+func (s *InboxService) patchInbox(ctx context.Context, inboxID int, fields any) (*models.InboxResponse, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	body := map[string]any{"inbox": fields}
+
+	var resp models.InboxResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("inboxes/%d.json", inboxID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetUserAccess grants a user access to an inbox, or changes the access they
+// already have, without sending the inbox's full user list
+func (s *InboxService) SetUserAccess(ctx context.Context, inboxID, userID int, access models.InboxAccess) (*models.InboxUser, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("userID must be greater than 0")
+	}
+
+	switch access {
+	case models.InboxAccessRead, models.InboxAccessWrite, models.InboxAccessManage:
+	default:
+		return nil, fmt.Errorf("access %q is invalid, must be %q, %q or %q", access, models.InboxAccessRead, models.InboxAccessWrite, models.InboxAccessManage)
+	}
+
+	body := map[string]models.InboxUserAccess{"user": {Access: access}}
+
+	var resp models.InboxUserResponse
+	if err := s.request(ctx, http.MethodPut, fmt.Sprintf("inboxes/%d/users/%d.json", inboxID, userID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// RemoveUser revokes a user's access to an inbox
+func (s *InboxService) RemoveUser(ctx context.Context, inboxID, userID int) error {
+	if inboxID <= 0 {
+		return fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("userID must be greater than 0")
+	}
+
+	return s.request(ctx, http.MethodDelete, fmt.Sprintf("inboxes/%d/users/%d.json", inboxID, userID), nil, nil)
+}
+
+// SetStarred stars or unstars an inbox for a user. Starred inboxes are shown
+// at the top of that user's inbox list.
+func (s *InboxService) SetStarred(ctx context.Context, inboxID, userID int, starred bool) (*models.InboxUser, error) {
+	if inboxID <= 0 {
+		return nil, fmt.Errorf("inboxID must be greater than 0")
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("userID must be greater than 0")
+	}
+
+	body := map[string]models.InboxStar{"user": {Starred: starred}}
+
+	var resp models.InboxUserResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("inboxes/%d/users/%d.json", inboxID, userID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// Reorder sets the display order of inboxes to the order of inboxIDs. Inboxes
+// that are not listed keep their current position after the listed ones.
+func (s *InboxService) Reorder(ctx context.Context, inboxIDs []int) error {
+	if len(inboxIDs) == 0 {
+		return fmt.Errorf("inboxIDs is required")
+	}
+
+	seen := make(map[int]bool, len(inboxIDs))
+	positions := make([]models.InboxPosition, len(inboxIDs))
+	for i, id := range inboxIDs {
+		if id <= 0 {
+			return fmt.Errorf("inboxIDs[%d] must be greater than 0", i)
+		}
+		if seen[id] {
+			return fmt.Errorf("inboxIDs contains %d more than once", id)
+		}
+		seen[id] = true
+		positions[i] = models.InboxPosition{ID: id, DisplayOrder: i}
+	}
+
+	body := map[string][]models.InboxPosition{"inboxes": positions}
+
+	return s.request(ctx, http.MethodPut, "inboxes/order.json", body, nil)
+}
@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestInboxServiceReorder(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPut, "/inboxes/order.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	if err := c.Inboxes.Reorder(context.Background(), []int{7, 3, 5}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	b, err := io.ReadAll(requests[0].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+
+	var sent struct {
+		Inboxes []models.InboxPosition `json:"inboxes"`
+	}
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+
+	want := []models.InboxPosition{{ID: 7, DisplayOrder: 0}, {ID: 3, DisplayOrder: 1}, {ID: 5, DisplayOrder: 2}}
+	if len(sent.Inboxes) != len(want) {
+		t.Fatalf("expected %d positions, got %d", len(want), len(sent.Inboxes))
+	}
+	for i := range want {
+		if sent.Inboxes[i] != want[i] {
+			t.Errorf("position %d: expected %+v, got %+v", i, want[i], sent.Inboxes[i])
+		}
+	}
+
+	if err := c.Inboxes.Reorder(context.Background(), []int{1, 1}); err == nil {
+		t.Errorf("expected an error for duplicate inbox IDs")
+	}
+}
+
+func TestInboxServiceWaitForDomainVerification(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/inboxes/3/domainverification.json", http.StatusOK, `{"domainVerification":{"status":"pending"}}`)
+	mockTransport.AddResponse(http.MethodPost, "/inboxes/4/domainverification.json", http.StatusOK, `{"domainVerification":{"status":"verified"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	v, err := c.Inboxes.WaitForDomainVerification(context.Background(), 4, time.Millisecond)
+	if err != nil || !v.IsVerified() {
+		t.Fatalf("expected a verified domain, got %+v, %v", v, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	v, err = c.Inboxes.WaitForDomainVerification(ctx, 3, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to end polling, got %v", err)
+	}
+	if v == nil || v.Status == nil || *v.Status != models.DomainVerificationStatusPending {
+		t.Errorf("expected the last pending status, got %+v", v)
+	}
+	if n := len(mockTransport.GetRequests()); n < 3 {
+		t.Errorf("expected the pending domain to be checked repeatedly, got %d requests", n)
+	}
+}
+
+func TestInboxServiceEmailSettings(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/inboxes/3.json", http.StatusOK, `{"inbox":{"id":3,"forwardingAddress":"fwd-3@desk.example.com","emailForwardingState":"seen","includeTicketHistoryOnForward":true,"autoReplyEnabled":true,"autoReplySubject":"Thanks","signature":"<p>Support</p>"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	settings, err := c.Inboxes.EmailSettings(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if settings.Forwarding.ForwardingAddress == nil || *settings.Forwarding.ForwardingAddress != "fwd-3@desk.example.com" {
+		t.Errorf("unexpected forwarding settings %+v", settings.Forwarding)
+	}
+	if settings.AutoReply.AutoReplySubject == nil || *settings.AutoReply.AutoReplySubject != "Thanks" {
+		t.Errorf("unexpected auto-reply settings %+v", settings.AutoReply)
+	}
+	if settings.Signature == nil || *settings.Signature != "<p>Support</p>" {
+		t.Errorf("unexpected signature %v", settings.Signature)
+	}
+
+	if _, err := c.Inboxes.EmailSettings(context.Background(), 0); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+}
+
+func TestInboxServiceUpdateEmailSettings(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/inboxes/3.json", http.StatusOK, `{"inbox":{"id":3}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	// Settings as read back with EmailSettings, read-only fields included
+	address, state, history := "fwd-3@desk.example.com", "seen", false
+	if _, err := c.Inboxes.UpdateForwardingSettings(ctx, 3, models.InboxForwardingSettings{
+		ForwardingAddress:             &address,
+		EmailForwardingState:          &state,
+		IncludeTicketHistoryOnForward: &history,
+	}); err != nil {
+		t.Fatalf("UpdateForwardingSettings() returned error: %v", err)
+	}
+
+	enabled, subject := true, "We got your message"
+	if _, err := c.Inboxes.UpdateAutoReply(ctx, 3, models.InboxAutoReplySettings{AutoReplyEnabled: &enabled, AutoReplySubject: &subject}); err != nil {
+		t.Fatalf("UpdateAutoReply() returned error: %v", err)
+	}
+
+	if _, err := c.Inboxes.SetSignature(ctx, 3, ""); err != nil {
+		t.Fatalf("SetSignature() returned error: %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	for i, want := range []string{
+		`{"inbox":{"includeTicketHistoryOnForward":false}}`,
+		`{"inbox":{"autoReplyEnabled":true,"autoReplySubject":"We got your message"}}`,
+		`{"inbox":{"signature":""}}`,
+	} {
+		b, _ := io.ReadAll(requests[i].Body)
+		if string(b) != want {
+			t.Errorf("request %d: expected body %s, got %s", i, want, b)
+		}
+	}
+
+	if _, err := c.Inboxes.SetSignature(ctx, 0, "<p>Support</p>"); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+	if n := len(mockTransport.GetRequests()); n != 3 {
+		t.Errorf("expected an invalid call not to send a request, got %d requests", n)
+	}
+}
+
+func TestInboxServiceDomainVerification(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/inboxes/3/domainverification.json", http.StatusOK, `{"domainVerification":{"domain":"example.com","status":"failed","dkim":{"type":"CNAME","host":"desk._domainkey.example.com","status":"failed"},"spf":{"type":"TXT","status":"verified"}}}`)
+	mockTransport.AddResponse(http.MethodPost, "/inboxes/3/domainverification.json", http.StatusOK, `{"domainVerification":{"domain":"example.com","status":"verified"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	v, err := c.Inboxes.DomainVerification(ctx, 3)
+	if err != nil {
+		t.Fatalf("DomainVerification() returned error: %v", err)
+	}
+	if v.IsVerified() {
+		t.Error("expected a failed domain not to be verified")
+	}
+	if v.DKIM == nil || v.DKIM.Host == nil || *v.DKIM.Host != "desk._domainkey.example.com" || *v.DKIM.Status != models.DomainVerificationStatusFailed {
+		t.Errorf("unexpected DKIM record %+v", v.DKIM)
+	}
+	if v.SPF == nil || *v.SPF.Status != models.DomainVerificationStatusVerified {
+		t.Errorf("unexpected SPF record %+v", v.SPF)
+	}
+
+	v, err = c.Inboxes.VerifyDomain(ctx, 3)
+	if err != nil {
+		t.Fatalf("VerifyDomain() returned error: %v", err)
+	}
+	if !v.IsVerified() {
+		t.Errorf("expected the check to report a verified domain, got %+v", v)
+	}
+
+	if _, err := c.Inboxes.DomainVerification(ctx, 0); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+	if _, err := c.Inboxes.VerifyDomain(ctx, 0); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[0].Method != http.MethodGet || requests[1].Method != http.MethodPost {
+		t.Errorf("expected a GET then a POST, got %s then %s", requests[0].Method, requests[1].Method)
+	}
+}
+
+func TestInboxServiceUpdateSpamSettings(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/inboxes/3.json", http.StatusOK, `{"inbox":{"id":3,"spamThreshold":7,"spamAction":"tag"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := c.Inboxes.UpdateSpamSettings(context.Background(), 3, models.InboxSpamSettings{
+		SpamThreshold: ptr(7),
+		SpamAction:    ptr(models.SpamActionTag),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Inbox.SpamAction == nil || *resp.Inbox.SpamAction != models.SpamActionTag {
+		t.Errorf("unexpected spam action %v", resp.Inbox.SpamAction)
+	}
+
+	if _, err := c.Inboxes.UpdateSpamSettings(context.Background(), 0, models.InboxSpamSettings{}); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	b, _ := io.ReadAll(requests[0].Body)
+	if want := `{"inbox":{"spamThreshold":7,"spamAction":"tag"}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+}
+
+func TestInboxServiceUserAccess(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPut, "/inboxes/3/users/8.json", http.StatusOK, `{"user":{"id":8,"type":"users","meta":{"access":"write"}}}`)
+	mockTransport.AddResponse(http.MethodDelete, "/inboxes/3/users/8.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	user, err := c.Inboxes.SetUserAccess(ctx, 3, 8, models.InboxAccessWrite)
+	if err != nil {
+		t.Fatalf("SetUserAccess() returned error: %v", err)
+	}
+	if user.ID != 8 || user.Meta.Access == nil || *user.Meta.Access != models.InboxAccessWrite {
+		t.Errorf("unexpected inbox user %+v", user)
+	}
+
+	if err := c.Inboxes.RemoveUser(ctx, 3, 8); err != nil {
+		t.Fatalf("RemoveUser() returned error: %v", err)
+	}
+
+	if _, err := c.Inboxes.SetUserAccess(ctx, 3, 8, "owner"); err == nil {
+		t.Error("expected an error for an unknown access level")
+	}
+	if _, err := c.Inboxes.SetUserAccess(ctx, 0, 8, models.InboxAccessRead); err == nil {
+		t.Error("expected an error for inboxID 0")
+	}
+	if err := c.Inboxes.RemoveUser(ctx, 3, 0); err == nil {
+		t.Error("expected an error for userID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	b, _ := io.ReadAll(requests[0].Body)
+	if want := `{"user":{"access":"write"}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+	if requests[1].Method != http.MethodDelete {
+		t.Errorf("expected a DELETE, got %s", requests[1].Method)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"reflect"
+)
+
+// Iter returns an iterator over every resource matching params. Pages are
+// fetched lazily as the loop advances, so only one page is held in memory,
+// and breaking out of the loop stops paging. Each resource is yielded in the
+// same envelope Get returns, with the included data of its page. A failed
+// request is yielded once as the error and ends the iteration.
+//
+//	for t, err := range c.Tickets.Iter(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(t.Ticket.ID)
+//	}
+func (s *Service[T, L]) Iter(ctx context.Context, params url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := s.ListAllFunc(ctx, params, func(page *L) error {
+			items, err := pageItems[T](page)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return ErrStopPaging
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// pageItems wraps each resource in a list response into the single resource
+// envelope T, copying the page's included data along with it
+func pageItems[T any](page any) ([]T, error) {
+	pv := reflect.Indirect(reflect.ValueOf(page))
+	tt := reflect.TypeFor[T]()
+	if pv.Kind() != reflect.Struct || tt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot iterate %T as %s", page, tt)
+	}
+
+	var list reflect.Value
+	for i := range pv.NumField() {
+		f := pv.Type().Field(i)
+		if f.IsExported() && f.Name != "Included" && f.Type.Kind() == reflect.Slice {
+			list = pv.Field(i)
+			break
+		}
+	}
+	if !list.IsValid() {
+		return nil, nil
+	}
+
+	field, ok := -1, false
+	for i := range tt.NumField() {
+		if f := tt.Field(i); f.IsExported() && f.Type == list.Type().Elem() {
+			field, ok = i, true
+			break
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s has no field of type %s", tt, list.Type().Elem())
+	}
+
+	included := pv.FieldByName("Included")
+	items := make([]T, list.Len())
+	for i := range items {
+		v := reflect.ValueOf(&items[i]).Elem()
+		v.Field(field).Set(list.Index(i))
+		if f := v.FieldByName("Included"); included.IsValid() && f.IsValid() && f.Type() == included.Type() {
+			f.Set(included)
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestServiceIter(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		_ = json.NewEncoder(w).Encode(models.TicketsResponse{
+			Tickets: []models.Ticket{
+				{BaseEntity: models.BaseEntity{ID: page*10 + 1}},
+				{BaseEntity: models.BaseEntity{ID: page*10 + 2}},
+			},
+			Included:   models.IncludedData{Tags: []models.Tag{{BaseEntity: models.BaseEntity{ID: page}}}},
+			Pagination: models.Pagination{Page: page, HasMorePages: page < 3},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	var ids []int
+	for ticket, err := range c.Tickets.Iter(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(ticket.Included.Tags) != 1 || ticket.Included.Tags[0].ID != ticket.Ticket.ID/10 {
+			t.Errorf("expected ticket %d to carry its page's included data, got %+v", ticket.Ticket.ID, ticket.Included.Tags)
+		}
+		ids = append(ids, ticket.Ticket.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	if len(ids) != 3 || ids[0] != 11 || ids[2] != 21 {
+		t.Errorf("expected tickets 11, 12 and 21, got %v", ids)
+	}
+	if requests != 2 {
+		t.Errorf("expected breaking out to stop paging after 2 requests, got %d", requests)
+	}
+}
+
+func TestServiceIterError(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusInternalServerError, `{}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	var errs int
+	for _, err := range c.Tickets.Iter(context.Background(), nil) {
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected exactly one error, got %d", errs)
+	}
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JournalMiddlewareName is the name the change journal is registered under
+const JournalMiddlewareName = "journal"
+
+// JournalAction is the kind of change a journal entry records
+type JournalAction string
+
+const (
+	JournalActionCreate JournalAction = "create"
+	JournalActionUpdate JournalAction = "update"
+	JournalActionDelete JournalAction = "delete"
+	// JournalActionInvoke is a POST to an action endpoint, such as
+	// tickets/{id}/merge or users/{id}/deactivate, rather than to a
+	// collection
+	JournalActionInvoke JournalAction = "invoke"
+)
+
+// actionEndpoints are the POST endpoints under a resource ID that act on the
+// resource instead of creating one, such as tickets/{id}/merge
+var actionEndpoints = map[string]bool{
+	"deactivate":         true,
+	"domainverification": true,
+	"forward":            true,
+	"merge":              true,
+	"reactivate":         true,
+	"resetpassword":      true,
+	"split":              true,
+	"welcome":            true,
+}
+
+// JournalEntry records one successful change made through the client
+type JournalEntry struct {
+	At     time.Time     `json:"at"`
+	Action JournalAction `json:"action"`
+	// Resource is the kind of resource changed, for example "tickets" or
+	// "messages"
+	Resource string `json:"resource"`
+	// ID is the ID of the changed resource, taken from the path or, for
+	// creates, from the response. It is 0 when neither holds one. For
+	// invokes, Resource and ID are those the action was called on.
+	ID     int    `json:"id,omitempty"`
+	Method string `json:"method"`
+	// Path is the request path relative to the base URL
+	Path string `json:"path"`
+	// PayloadDigest is the hex SHA-256 of the request body, empty when the
+	// request had none. Payloads are not stored as they may hold personal
+	// data.
+	PayloadDigest string `json:"payloadDigest,omitempty"`
+	RequestID     string `json:"requestId,omitempty"`
+}
+
+// Journal is the list of changes a client has made, oldest first
+type Journal []JournalEntry
+
+// WriteJSON writes the journal as JSON Lines, one entry per line
+func (j Journal) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, e := range j {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Created returns the entries for resources that were created, for example
+// to clean up after a seed run. Invoked actions such as merges are not
+// included, even when they were POSTs.
+func (j Journal) Created() Journal {
+	var out Journal
+	for _, e := range j {
+		if e.Action == JournalActionCreate {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+type journal struct {
+	prefix string
+
+	mu      sync.Mutex
+	entries Journal
+}
+
+// WithJournal records every successful create, update and delete the client
+// makes. Read the changes with Client.Journal.
+func WithJournal() Option {
+	return func(c *Client) {
+		prefix := ""
+		if u, err := url.Parse(c.baseURL); err == nil {
+			prefix = strings.TrimSuffix(u.Path, "/") + "/"
+		}
+
+		c.journal = &journal{prefix: prefix}
+		// Outermost, so only the final outcome of retried requests counts
+		c.UseMiddleware(JournalMiddlewareName, 0, c.journal.middleware())
+	}
+}
+
+// Journal returns a copy of the changes made so far, oldest first. It
+// returns nil unless the client was created with WithJournal.
+func (c *Client) Journal() Journal {
+	if c.journal == nil {
+		return nil
+	}
+
+	c.journal.mu.Lock()
+	defer c.journal.mu.Unlock()
+
+	return append(Journal{}, c.journal.entries...)
+}
+
+func (j *journal) middleware() MiddlewareFunc {
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		path := strings.TrimPrefix(req.URL.Path, j.prefix)
+		action, ok := journalAction(req.Method, path)
+		if !ok {
+			return next(ctx, req)
+		}
+
+		digest := payloadDigest(req)
+
+		resp, err := next(ctx, req)
+		if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp, err
+		}
+
+		target := path
+		if action == JournalActionInvoke {
+			// Record the resource the action was called on
+			target = target[:max(strings.LastIndex(target, "/"), 0)]
+		}
+		resource, id := journalTarget(target)
+		if id == 0 && action == JournalActionCreate {
+			id = createdID(resp)
+		}
+
+		j.mu.Lock()
+		j.entries = append(j.entries, JournalEntry{
+			At:            time.Now().UTC(),
+			Action:        action,
+			Resource:      resource,
+			ID:            id,
+			Method:        req.Method,
+			Path:          path,
+			PayloadDigest: digest,
+			RequestID:     req.Header.Get(RequestIDHeader),
+		})
+		j.mu.Unlock()
+
+		return resp, nil
+	}
+}
+
+func journalAction(method, path string) (JournalAction, bool) {
+	switch method {
+	case http.MethodPost:
+		if !isCollectionPath(path) {
+			return JournalActionInvoke, true
+		}
+		return JournalActionCreate, true
+	case http.MethodPut, http.MethodPatch:
+		return JournalActionUpdate, true
+	case http.MethodDelete:
+		return JournalActionDelete, true
+	default:
+		return "", false
+	}
+}
+
+// isCollectionPath reports whether path names a collection, such as
+// "tickets.json" or "tickets/12/messages.json", as opposed to an action such
+// as "users/invite.json" or "tickets/12/merge.json"
+func isCollectionPath(path string) bool {
+	segments := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+	last := len(segments) - 1
+	if actionEndpoints[segments[last]] {
+		return false
+	}
+	if last == 0 {
+		return true
+	}
+	_, err := strconv.Atoi(segments[last-1])
+	return err == nil
+}
+
+// journalTarget splits a path such as "tickets/12/messages.json" into the
+// last resource named in it and the ID that follows that resource, if any
+func journalTarget(path string) (string, int) {
+	segments := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(segments[i]); err == nil {
+			continue
+		}
+		id := 0
+		if i+1 < len(segments) {
+			id, _ = strconv.Atoi(segments[i+1])
+		}
+		return segments[i], id
+	}
+
+	return path, 0
+}
+
+// payloadDigest hashes the request body without consuming it
+func payloadDigest(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if n, err := io.Copy(h, body); err != nil || n == 0 {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// createdID reads the ID of the created resource from a response such as
+// {"ticket":{"id":1}}, leaving the body readable by the caller
+func createdID(resp *http.Response) int {
+	if resp.Body == nil {
+		return 0
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return 0
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return 0
+	}
+	for key, raw := range envelope {
+		if key == "included" || len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		var item struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &item); err == nil && item.ID > 0 {
+			return item.ID
+		}
+	}
+
+	return 0
+}
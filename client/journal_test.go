@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestJournal(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/desk/api/v2/tickets/5/messages.json", http.StatusCreated, `{"message":{"id":77}}`)
+	mockTransport.AddResponse(http.MethodPatch, "/desk/api/v2/customers/9.json", http.StatusOK, `{"customer":{"id":9}}`)
+	mockTransport.AddResponse(http.MethodDelete, "/desk/api/v2/tags/3.json", http.StatusNotFound, `{}`)
+	mockTransport.AddResponse(http.MethodGet, "/desk/api/v2/tags.json", http.StatusOK, `{"tags":[]}`)
+
+	c := NewClient("https://example.com/desk/api/v2", WithHTTPClient(&http.Client{Transport: mockTransport}), WithJournal())
+	ctx := context.Background()
+
+	body := "hello"
+	msg, err := c.Messages.CreateForTicket(ctx, 5, &models.MessageResponse{Message: models.Message{Message: &body}})
+	if err != nil {
+		t.Fatalf("CreateForTicket() returned error: %v", err)
+	}
+	if msg.Message.ID != 77 {
+		t.Errorf("expected the response to still be readable, got %+v", msg)
+	}
+	if _, err := c.Customers.SetCompany(ctx, 9, 2); err != nil {
+		t.Fatalf("SetCompany() returned error: %v", err)
+	}
+	_ = c.Tags.Delete(ctx, 3)
+	_, _ = c.Tags.List(ctx, nil)
+
+	journal := c.Journal()
+	if len(journal) != 2 {
+		t.Fatalf("expected only the 2 successful changes, got %+v", journal)
+	}
+
+	created := journal[0]
+	if created.Action != JournalActionCreate || created.Resource != "messages" || created.ID != 77 || created.Path != "tickets/5/messages.json" {
+		t.Errorf("unexpected create entry %+v", created)
+	}
+	if len(created.PayloadDigest) != 64 {
+		t.Errorf("expected a SHA-256 payload digest, got %q", created.PayloadDigest)
+	}
+
+	updated := journal[1]
+	if updated.Action != JournalActionUpdate || updated.Resource != "customers" || updated.ID != 9 {
+		t.Errorf("unexpected update entry %+v", updated)
+	}
+
+	if got := journal.Created(); len(got) != 1 || got[0].ID != 77 {
+		t.Errorf("expected Created to return the message, got %+v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := journal.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var entry JournalEntry
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &entry) != nil || entry.ID != 77 {
+		t.Errorf("expected one JSON entry per line, got %s", buf.String())
+	}
+}
+
+func TestJournalInvoke(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/5/merge.json", http.StatusOK, `{"ticket":{"id":5}}`)
+	mockTransport.AddResponse(http.MethodPost, "/users/8/deactivate.json", http.StatusOK, `{"user":{"id":8}}`)
+	mockTransport.AddResponse(http.MethodPost, "/tickets.json", http.StatusCreated, `{"ticket":{"id":6}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}), WithJournal())
+	ctx := context.Background()
+
+	if _, err := c.Tickets.Merge(ctx, 5, []int{4}); err != nil {
+		t.Fatalf("Merge() returned error: %v", err)
+	}
+	if _, err := c.Users.Deactivate(ctx, 8); err != nil {
+		t.Fatalf("Deactivate() returned error: %v", err)
+	}
+	if _, err := c.Tickets.Create(ctx, &models.TicketResponse{}); err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+
+	journal := c.Journal()
+	if len(journal) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", journal)
+	}
+	for i, want := range []JournalEntry{
+		{Action: JournalActionInvoke, Resource: "tickets", ID: 5, Path: "tickets/5/merge.json"},
+		{Action: JournalActionInvoke, Resource: "users", ID: 8, Path: "users/8/deactivate.json"},
+		{Action: JournalActionCreate, Resource: "tickets", ID: 6, Path: "tickets.json"},
+	} {
+		got := journal[i]
+		if got.Action != want.Action || got.Resource != want.Resource || got.ID != want.ID || got.Path != want.Path {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if got := journal.Created(); len(got) != 1 || got[0].ID != 6 {
+		t.Errorf("expected Created to return only the new ticket, got %+v", got)
+	}
+}
+
+func TestIsCollectionPath(t *testing.T) {
+	tests := map[string]bool{
+		"tickets.json":                    true,
+		"tickets/5/messages.json":         true,
+		"companies/2/domains.json":        true,
+		"users/invite.json":               false,
+		"tickets/5/merge.json":            false,
+		"tickets/5/messages/7/split.json": false,
+		"customers/3/welcome.json":        false,
+	}
+	for path, want := range tests {
+		if got := isCollectionPath(path); got != want {
+			t.Errorf("isCollectionPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ErrStopPaging can be returned from a ListAllFunc callback to stop paging
+// early. ListAllFunc then returns nil.
+const ErrStopPaging = pagingError("stop paging")
+
+type pagingError string
+
+func (e pagingError) Error() string { return string(e) }
+
+// ListAllFunc lists every page of resources matching params, calling fn with
+// each page as it arrives. Only one page is held in memory at a time. Paging
+// stops at the first error from a request or from fn; return ErrStopPaging
+// from fn to stop without an error. A page and pageSize in params are used as
+// the starting page and the page size.
+func (s *Service[T, L]) ListAllFunc(ctx context.Context, params url.Values, fn func(page *L) error) error {
+	return listPages(ctx, params, s.List, fn)
+}
+
+// listPages is ListAllFunc for any list function, so that endpoints nested
+// under another resource, such as a ticket's messages, page the same way
+func listPages[L any](ctx context.Context, params url.Values, list func(ctx context.Context, params url.Values) (*L, error), fn func(page *L) error) error {
+	if fn == nil {
+		return fmt.Errorf("fn is required")
+	}
+
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = append([]string(nil), v...)
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(q.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	for ; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		q.Set("page", strconv.Itoa(page))
+		resp, err := list(ctx, q)
+		if err != nil {
+			return fmt.Errorf("list page %d: %w", page, err)
+		}
+
+		if err := fn(resp); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		if !listPagination(resp).HasMorePages {
+			return nil
+		}
+	}
+}
+
+// ListAll lists every page of resources matching params and returns them as
+// a single list response. Resource slices and included data are concatenated
+// in page order; Pagination and Meta are those of the last page. Included
+// records referenced from several pages may appear more than once. Use
+// ListAllFunc to stream pages instead of holding them all in memory.
+func (s *Service[T, L]) ListAll(ctx context.Context, params url.Values) (*L, error) {
+	var all *L
+	err := s.ListAllFunc(ctx, params, func(page *L) error {
+		if all == nil {
+			all = page
+			return nil
+		}
+		appendList(reflect.ValueOf(all).Elem(), reflect.ValueOf(page).Elem())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// ListIDs returns the IDs of every resource matching params, across all
+// pages
+func (s *Service[T, L]) ListIDs(ctx context.Context, params url.Values) ([]int, error) {
+	var ids []int
+	err := s.ListAllFunc(ctx, params, func(page *L) error {
+		ids = append(ids, listIDs(page)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// listPagination returns the Pagination field of a list response, or the
+// zero value when it has none, which ends paging after the first page
+func listPagination(list any) models.Pagination {
+	v := reflect.Indirect(reflect.ValueOf(list))
+	if v.Kind() != reflect.Struct {
+		return models.Pagination{}
+	}
+
+	f := v.FieldByName("Pagination")
+	if !f.IsValid() {
+		return models.Pagination{}
+	}
+
+	p, _ := f.Interface().(models.Pagination)
+	return p
+}
+
+// listIDs returns the IDs of the resources in a list response, taken from
+// its resource slice: the slice field that is not part of the included data
+func listIDs(list any) []int {
+	v := reflect.Indirect(reflect.ValueOf(list))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := range v.NumField() {
+		if !v.Type().Field(i).IsExported() || v.Field(i).Kind() != reflect.Slice {
+			continue
+		}
+
+		items := v.Field(i)
+		elem := items.Type().Elem()
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+		if f, ok := elem.FieldByName("ID"); !ok || f.Type.Kind() != reflect.Int {
+			continue
+		}
+
+		ids := make([]int, 0, items.Len())
+		for j := range items.Len() {
+			ids = append(ids, int(items.Index(j).FieldByName("ID").Int()))
+		}
+		return ids
+	}
+
+	return nil
+}
+
+// appendList appends the slice fields of src to those of dst, including those
+// of the included data. Any other field is overwritten with src.
+func appendList(dst, src reflect.Value) {
+	for i := range dst.NumField() {
+		if !dst.Type().Field(i).IsExported() {
+			continue
+		}
+
+		d, sv := dst.Field(i), src.Field(i)
+		switch d.Kind() {
+		case reflect.Slice:
+			d.Set(reflect.AppendSlice(d, sv))
+		case reflect.Struct:
+			if d.Type() == reflect.TypeFor[models.IncludedData]() {
+				appendList(d, sv)
+				continue
+			}
+			d.Set(sv)
+		default:
+			d.Set(sv)
+		}
+	}
+}
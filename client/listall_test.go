@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestServiceListAllFunc(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tags.json", http.StatusOK, models.TagsResponse{
+		Tags:       []models.Tag{{BaseEntity: models.BaseEntity{ID: 1}}},
+		Pagination: models.Pagination{HasMorePages: true},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	var pages int
+	err := c.Tags.ListAllFunc(context.Background(), nil, func(page *models.TagsResponse) error {
+		pages++
+		if pages == 3 {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	if got := requests[2].URL.Query().Get("page"); got != "3" {
+		t.Errorf("expected the last request for page 3, got %q", got)
+	}
+}
+
+func TestServiceListAll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		_ = json.NewEncoder(w).Encode(models.TagsResponse{
+			Tags:       []models.Tag{{BaseEntity: models.BaseEntity{ID: page}}},
+			Included:   models.IncludedData{Users: []models.User{{BaseEntity: models.BaseEntity{ID: 10 + page}}}},
+			Pagination: models.Pagination{Page: page, Pages: 3, HasMorePages: page < 3},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	all, err := c.Tags.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(all.Tags) != 3 || all.Tags[0].ID != 1 || all.Tags[2].ID != 3 {
+		t.Errorf("expected tags 1 to 3 in order, got %+v", all.Tags)
+	}
+	if len(all.Included.Users) != 3 {
+		t.Errorf("expected included users from every page, got %d", len(all.Included.Users))
+	}
+	if all.Pagination.Page != 3 || all.Pagination.HasMorePages {
+		t.Errorf("expected the last page's pagination, got %+v", all.Pagination)
+	}
+}
+
+func TestListIDs(t *testing.T) {
+	statuses := &models.TicketStatusesResponse{
+		TicketStatuses: []models.TicketStatus{{BaseEntity: models.BaseEntity{ID: 3}}, {BaseEntity: models.BaseEntity{ID: 5}}},
+		Included:       models.IncludedData{Users: []models.User{{BaseEntity: models.BaseEntity{ID: 9}}}},
+	}
+	if got := listIDs(statuses); len(got) != 2 || got[0] != 3 || got[1] != 5 {
+		t.Errorf("expected IDs [3 5], got %v", got)
+	}
+
+	domains := &models.CompanyDomainsResponse{Items: []models.Domain{{BaseEntity: models.BaseEntity{ID: 7}}}}
+	if got := listIDs(domains); len(got) != 1 || got[0] != 7 {
+		t.Errorf("expected IDs [7], got %v", got)
+	}
+
+	if got := listIDs(&models.TicketStatusesResponse{}); len(got) != 0 {
+		t.Errorf("expected no IDs for an empty page, got %v", got)
+	}
+}
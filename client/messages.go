@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -36,6 +37,25 @@ func (s *MessageService) List(ctx context.Context, params url.Values) (*models.M
 	return s.Service.List(ctx, params)
 }
 
+// ListForTicket retrieves the messages and notes on a ticket
+func (s *MessageService) ListForTicket(ctx context.Context, ticketID int, params url.Values) (*models.MessagesResponse, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("tickets/%d/messages.json", ticketID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.MessagesResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Create creates a new message
 func (s *MessageService) Create(ctx context.Context, message *models.MessageResponse) (*models.MessageResponse, error) {
 	if message == nil {
@@ -49,6 +69,12 @@ func (s *MessageService) Create(ctx context.Context, message *models.MessageResp
 	return s.CreateForTicket(ctx, message.Message.Ticket.ID, message)
 }
 
+// CreateBatch creates messages in parallel, validating each one as Create does.
+// See Service.CreateBatch.
+func (s *MessageService) CreateBatch(ctx context.Context, items []*models.MessageResponse, opts BatchOptions) ([]BatchResult[models.MessageResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
 // CreateForTicket creates a new message scoped to a ticket
 func (s *MessageService) CreateForTicket(ctx context.Context, ticketID int, message *models.MessageResponse) (*models.MessageResponse, error) {
 	if ticketID <= 0 {
@@ -70,7 +96,7 @@ func (s *MessageService) CreateForTicket(ctx context.Context, ticketID int, mess
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +108,7 @@ func (s *MessageService) CreateForTicket(ctx context.Context, ticketID int, mess
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(b))
+		return nil, newAPIError(req, resp, b)
 	}
 
 	var createdMessage models.MessageResponse
@@ -93,7 +119,127 @@ func (s *MessageService) CreateForTicket(ctx context.Context, ticketID int, mess
 	return &createdMessage, nil
 }
 
+// ReplyOptions are the optional parts of a reply or forward
+type ReplyOptions struct {
+	CC  []string
+	BCC []string
+	// FileIDs are previously uploaded files to attach, see FileService
+	FileIDs []int
+}
+
+// apply copies the options onto m
+func (o ReplyOptions) apply(m *models.Message) {
+	m.CC = o.CC
+	m.BCC = o.BCC
+	for _, id := range o.FileIDs {
+		m.Files = append(m.Files, models.EntityRef{ID: id, Type: "files"})
+	}
+}
+
+// Reply sends an HTML reply to the customer on a ticket
+func (s *MessageService) Reply(ctx context.Context, ticketID int, htmlBody string, opts ReplyOptions) (*models.MessageResponse, error) {
+	if htmlBody == "" {
+		return nil, fmt.Errorf("htmlBody is required")
+	}
+
+	threadType := models.ThreadTypeMessage
+	message := models.Message{Message: &htmlBody, ThreadType: &threadType}
+	opts.apply(&message)
+
+	return s.CreateForTicket(ctx, ticketID, &models.MessageResponse{Message: message})
+}
+
+// Forward sends a ticket's conversation to addresses outside the ticket,
+// with htmlBody as the covering message. The customer does not receive it.
+func (s *MessageService) Forward(ctx context.Context, ticketID int, to []string, htmlBody string, opts ReplyOptions) (*models.MessageResponse, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("to is required")
+	}
+
+	message := models.Message{Message: &htmlBody, To: to}
+	opts.apply(&message)
+
+	var resp models.MessageResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("tickets/%d/forward.json", ticketID), map[string]models.Message{"message": message}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Thread retrieves every message on a ticket, across all pages, oldest
+// first, with any missing a creation time at the end. Notes are included only when includeNotes is set.
+func (s *MessageService) Thread(ctx context.Context, ticketID int, includeNotes bool) ([]models.Message, error) {
+	list := func(ctx context.Context, params url.Values) (*models.MessagesResponse, error) {
+		return s.ListForTicket(ctx, ticketID, params)
+	}
+
+	var thread []models.Message
+	err := listPages(ctx, nil, list, func(page *models.MessagesResponse) error {
+		for _, m := range page.Messages {
+			if !includeNotes && m.IsNote() {
+				continue
+			}
+			thread = append(thread, m)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(thread, func(a, b models.Message) int {
+		switch {
+		case a.CreatedAt == nil && b.CreatedAt == nil:
+			return 0
+		case a.CreatedAt == nil:
+			return 1
+		case b.CreatedAt == nil:
+			return -1
+		}
+		return a.CreatedAt.Compare(*b.CreatedAt)
+	})
+
+	return thread, nil
+}
+
 // Update updates an existing message
 func (s *MessageService) Update(ctx context.Context, id int, message *models.MessageResponse) (*models.MessageResponse, error) {
 	return s.Service.Update(ctx, id, message)
 }
+
+// SetPortalVisibility shows or hides a message in the customer portal. Hiding
+// a message does not unsend any email the customer already received.
+func (s *MessageService) SetPortalVisibility(ctx context.Context, messageID int, visible bool) (*models.MessageResponse, error) {
+	if messageID <= 0 {
+		return nil, fmt.Errorf("messageID must be greater than 0")
+	}
+
+	body := map[string]map[string]bool{"message": {"visibleInPortal": visible}}
+
+	var resp models.MessageResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("messages/%d.json", messageID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// RawSource retrieves the original raw email (headers and MIME body) that a
+// message was created from. Messages that did not arrive by email, such as
+// agent replies or notes, have no source and the API returns an error.
+func (s *MessageService) RawSource(ctx context.Context, messageID int) ([]byte, error) {
+	if messageID <= 0 {
+		return nil, fmt.Errorf("messageID must be greater than 0")
+	}
+
+	var resp models.MessageSourceResponse
+	if err := s.request(ctx, http.MethodGet, fmt.Sprintf("messages/%d/source.json", messageID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return []byte(resp.MessageSource.Source), nil
+}
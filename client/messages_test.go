@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -87,3 +91,136 @@ func TestMessageServiceCreateRequiresTicketID(t *testing.T) {
 		t.Fatal("expected error when ticket ID is missing")
 	}
 }
+
+func TestMessageServiceSetPortalVisibility(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/messages/9.json", http.StatusOK, models.MessageResponse{
+		Message: models.Message{
+			BaseEntity:      models.BaseEntity{ID: 9},
+			VisibleInPortal: ptr(false),
+		},
+	})
+
+	client := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := client.Messages.SetPortalVisibility(context.Background(), 9, false)
+	if err != nil {
+		t.Fatalf("SetPortalVisibility() returned error: %v", err)
+	}
+
+	if resp.Message.VisibleInPortal == nil || *resp.Message.VisibleInPortal {
+		t.Fatalf("expected message to be hidden from the portal, got %v", resp.Message.VisibleInPortal)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	b, err := io.ReadAll(requests[0].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+
+	// false must be sent explicitly rather than dropped by omitempty
+	if got, want := string(b), `{"message":{"visibleInPortal":false}}`; got != want {
+		t.Fatalf("expected request body %s, got %s", want, got)
+	}
+}
+
+func TestMessageServiceReply(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/5/messages.json", http.StatusCreated, models.MessageResponse{})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	_, err := c.Messages.Reply(context.Background(), 5, "<p>Fixed</p>", ReplyOptions{CC: []string{"boss@example.com"}, FileIDs: []int{8}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	var sent models.Message
+	b, _ := io.ReadAll(requests[0].Body)
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if sent.ThreadType == nil || *sent.ThreadType != models.ThreadTypeMessage {
+		t.Errorf("expected a customer-visible message, got %v", sent.ThreadType)
+	}
+	if len(sent.CC) != 1 || len(sent.Files) != 1 || sent.Files[0].ID != 8 {
+		t.Errorf("expected the CC and attachment to be sent, got %s", b)
+	}
+}
+
+func TestMessageServiceThread(t *testing.T) {
+	at := func(h int) *time.Time {
+		v := time.Date(2025, 1, 1, h, 0, 0, 0, time.UTC)
+		return &v
+	}
+	pages := map[string]models.MessagesResponse{
+		"1": {
+			Messages: []models.Message{
+				{BaseEntity: models.BaseEntity{ID: 4}},
+				{BaseEntity: models.BaseEntity{ID: 3, CreatedAt: at(3)}},
+				{BaseEntity: models.BaseEntity{ID: 2, CreatedAt: at(2)}, ThreadType: ptr(models.ThreadTypeNote)},
+			},
+			Pagination: models.Pagination{HasMorePages: true},
+		},
+		"2": {
+			Messages: []models.Message{{BaseEntity: models.BaseEntity{ID: 1, CreatedAt: at(1)}}},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("page")])
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	thread, err := c.Messages.Thread(context.Background(), 5, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(thread) != 3 || thread[0].ID != 1 || thread[1].ID != 3 || thread[2].ID != 4 {
+		t.Errorf("expected messages 1 and 3 oldest first then undated 4, without the note, got %+v", thread)
+	}
+
+	thread, err = c.Messages.Thread(context.Background(), 5, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(thread) != 4 || thread[1].ID != 2 || thread[3].ID != 4 {
+		t.Errorf("expected the note to be included in order, got %+v", thread)
+	}
+}
+
+func TestMessageServiceRawSource(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/messages/12/source.json", http.StatusOK, `{"messageSource":{"source":"From: jo@example.com\r\nSubject: Help\r\n\r\nIt broke"}}`)
+	mockTransport.AddResponse(http.MethodGet, "/messages/13/source.json", http.StatusNotFound, `{"message":"message has no source"}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	source, err := c.Messages.RawSource(context.Background(), 12)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := "From: jo@example.com\r\nSubject: Help\r\n\r\nIt broke"; string(source) != want {
+		t.Errorf("expected source %q, got %q", want, source)
+	}
+
+	if _, err := c.Messages.RawSource(context.Background(), 13); err == nil {
+		t.Error("expected an error for a message without a source")
+	}
+	if _, err := c.Messages.RawSource(context.Background(), 0); err == nil {
+		t.Error("expected an error for messageID 0")
+	}
+	if n := len(mockTransport.GetRequests()); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
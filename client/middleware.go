@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
@@ -16,6 +17,7 @@ func LoggingMiddleware(logger *slog.Logger) MiddlewareFunc {
 		logger.InfoContext(ctx, "Making HTTP request",
 			slog.String("method", req.Method),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 		)
 
 		resp, err := next(ctx, req)
@@ -25,6 +27,7 @@ func LoggingMiddleware(logger *slog.Logger) MiddlewareFunc {
 			logger.ErrorContext(ctx, "HTTP request failed",
 				slog.String("method", req.Method),
 				slog.String("url", req.URL.String()),
+				requestIDAttr(req),
 				slog.Duration("duration", duration),
 				slog.String("error", err.Error()),
 			)
@@ -32,6 +35,7 @@ func LoggingMiddleware(logger *slog.Logger) MiddlewareFunc {
 			logger.InfoContext(ctx, "HTTP request completed",
 				slog.String("method", req.Method),
 				slog.String("url", req.URL.String()),
+				requestIDAttr(req),
 				slog.Int("status", resp.StatusCode),
 				slog.Duration("duration", duration),
 			)
@@ -41,22 +45,71 @@ func LoggingMiddleware(logger *slog.Logger) MiddlewareFunc {
 	}
 }
 
-// RetryMiddleware creates middleware that retries requests on failure
+// RetryError is returned by RetryMiddleware and RetryPolicyMiddleware once
+// they give up on a request. It wraps the last attempt's error and records
+// how the attempts went, so a persistent failure can be told apart from a
+// flaky network.
+type RetryError struct {
+	// Attempts is the number of requests sent
+	Attempts int
+	// Statuses holds the response status of each attempt, or 0 when the
+	// attempt got no response
+	Statuses []int
+	// Elapsed is the time from the first attempt to the last failure,
+	// including the delays between attempts
+	Elapsed time.Duration
+	// Err is the last attempt's error, an *APIError when the attempt got a
+	// response
+	Err error
+}
+
+// Error implements error
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts in %s: %v", e.Attempts, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+// Unwrap returns the last attempt's error
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryMiddleware creates middleware that retries requests that fail with a
+// transport error or a 429 response, and GET, PUT and DELETE requests that
+// get a 5xx. POST and PATCH requests are not retried on a 5xx, since Desk may
+// already have acted on them; use RetryPolicyMiddleware with
+// RetryNonIdempotent to opt in. When maxRetries is greater than 0 and every
+// attempt fails, the error is a *RetryError; after a failed response it wraps
+// the last attempt's *APIError.
 func RetryMiddleware(maxRetries int, retryDelay time.Duration) MiddlewareFunc {
 	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
 		var resp *http.Response
 		var err error
 
+		start := time.Now()
+		statuses := make([]int, 0, maxRetries+1)
 		for attempt := 0; attempt <= maxRetries; attempt++ {
-			// Clone the request for retry attempts
+			// Clone the request for retry attempts, rewinding the body so
+			// every attempt sends it in full
 			clonedReq := req.Clone(ctx)
+			if attempt > 0 && req.GetBody != nil {
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return nil, bodyErr
+				}
+				clonedReq.Body = body
+			}
 
 			resp, err = next(ctx, clonedReq)
+			statuses = append(statuses, responseStatus(resp))
 
 			// If successful or on last attempt, return the result
-			if err == nil || attempt == maxRetries {
+			if (err == nil && !retryableStatus(req, resp.StatusCode)) || attempt == maxRetries {
 				break
 			}
+			if err == nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
 
 			// Wait before retrying (except on last attempt)
 			if attempt < maxRetries {
@@ -68,10 +121,54 @@ func RetryMiddleware(maxRetries int, retryDelay time.Duration) MiddlewareFunc {
 			}
 		}
 
+		if maxRetries > 0 && (err != nil || retryableStatus(req, resp.StatusCode)) {
+			return nil, newRetryError(req, resp, err, statuses, start)
+		}
+
 		return resp, err
 	}
 }
 
+// retryableStatus reports whether RetryMiddleware retries a response status.
+// Like RetryPolicy, a 429 is always retried but a 5xx only for methods that
+// are safe to repeat.
+func retryableStatus(req *http.Request, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= http.StatusInternalServerError && req.Method != http.MethodPost && req.Method != http.MethodPatch
+}
+
+// responseStatus returns the status of resp, or 0 when there is no response
+func responseStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// newRetryError builds the *RetryError for a request that is given up on.
+// When the last attempt got a response rather than an error, its body is read
+// and closed and the RetryError wraps the resulting *APIError.
+func newRetryError(req *http.Request, resp *http.Response, err error, statuses []int, start time.Time) *RetryError {
+	if err == nil && resp != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			err = readErr
+		} else {
+			err = newAPIError(req, resp, body)
+		}
+	}
+
+	return &RetryError{
+		Attempts: len(statuses),
+		Statuses: statuses,
+		Elapsed:  time.Since(start),
+		Err:      err,
+	}
+}
+
 // AuthMiddleware creates middleware that adds authentication headers
 func AuthMiddleware(token string) MiddlewareFunc {
 	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
@@ -118,12 +215,23 @@ func RateLimitMiddleware(requestsPerSecond float64) MiddlewareFunc {
 	}
 }
 
-// RequestIDMiddleware creates middleware that adds a unique request ID header
+// RequestIDMiddleware creates middleware that adds an X-Request-ID header to
+// every request. An ID already on the request or in the context (see
+// WithRequestID) is kept; otherwise a new UUIDv7 is generated. The ID is
+// added to the context passed down the chain, so retries of the request
+// share it.
 func RequestIDMiddleware() MiddlewareFunc {
 	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
-		requestID := fmt.Sprintf("req_%d", time.Now().UnixNano())
-		req.Header.Set("X-Request-ID", requestID)
-		return next(ctx, req)
+		requestID := req.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			if id, ok := RequestIDFromContext(ctx); ok {
+				requestID = id
+			} else {
+				requestID = NewRequestID()
+			}
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+		return next(WithRequestID(ctx, requestID), req)
 	}
 }
 
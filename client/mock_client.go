@@ -14,10 +14,18 @@ import (
 type MockRoundTripper struct {
 	mu        sync.Mutex
 	requests  []*http.Request
-	responses map[string]*http.Response
+	responses map[string]mockResponse
 	basePath  string
 }
 
+// mockResponse is a canned response. A new http.Response is built from it for
+// every request, so the same response can be served any number of times and
+// to concurrent callers.
+type mockResponse struct {
+	statusCode int
+	body       []byte
+}
+
 // MockReadCloser implements io.ReadCloser for testing
 type MockReadCloser struct {
 	io.Reader
@@ -40,7 +48,7 @@ func NewMockReadCloser(s string) *MockReadCloser {
 // NewMockRoundTripper creates a new mock round tripper
 func NewMockRoundTripper() *MockRoundTripper {
 	return &MockRoundTripper{
-		responses: make(map[string]*http.Response),
+		responses: make(map[string]mockResponse),
 	}
 }
 
@@ -49,7 +57,7 @@ func (m *MockRoundTripper) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.requests = nil
-	m.responses = make(map[string]*http.Response)
+	m.responses = make(map[string]mockResponse)
 }
 
 // getPathKey returns a consistent key for the given method and path
@@ -88,7 +96,12 @@ func (m *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 		}
 	}
 
-	return resp, nil
+	return &http.Response{
+		StatusCode: resp.statusCode,
+		Body:       io.NopCloser(bytes.NewReader(resp.body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
 }
 
 // AddResponse adds a mock response for a given method and path
@@ -96,28 +109,24 @@ func (m *MockRoundTripper) AddResponse(method, urlPath string, statusCode int, b
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var respBody io.ReadCloser
+	var respBody []byte
 	switch b := body.(type) {
 	case io.ReadCloser:
-		respBody = b
+		respBody, _ = io.ReadAll(b)
+		b.Close()
 	case string:
-		respBody = io.NopCloser(strings.NewReader(b))
+		respBody = []byte(b)
 	default:
-		bodyBytes, _ := json.Marshal(body)
-		respBody = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		respBody, _ = json.Marshal(body)
 	}
 
 	key := getPathKey(method, urlPath)
-	m.responses[key] = &http.Response{
-		StatusCode: statusCode,
-		Body:       respBody,
-		Header:     make(http.Header),
-	}
+	m.responses[key] = mockResponse{statusCode: statusCode, body: respBody}
 }
 
 // GetRequests returns all requests made to the mock
 func (m *MockRoundTripper) GetRequests() []*http.Request {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.requests
+	return append([]*http.Request(nil), m.requests...)
 }
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// NoteService handles internal notes on tickets. Notes are messages with the
+// note thread type, so it works through the message endpoints.
+type NoteService struct {
+	client *Client
+}
+
+// NewNoteService creates a new note service
+func NewNoteService(client *Client) *NoteService {
+	return &NoteService{client: client}
+}
+
+// Create adds an internal note to a ticket. When note.Agent is set the note
+// is attributed to that agent, which migrations use to keep the original
+// author; otherwise it is attributed to the API key's user. note.CreatedAt is
+// sent the same way.
+func (s *NoteService) Create(ctx context.Context, ticketID int, note *models.Note) (*models.Note, error) {
+	if note == nil {
+		return nil, fmt.Errorf("note is required")
+	}
+	if note.Body == "" {
+		return nil, fmt.Errorf("note.body is required")
+	}
+
+	message := note.Message()
+	message.ID = 0
+	message.Ticket = models.EntityRef{ID: ticketID}
+
+	resp, err := s.client.Messages.CreateForTicket(ctx, ticketID, &models.MessageResponse{Message: message})
+	if err != nil {
+		return nil, err
+	}
+
+	created := models.NoteFromMessage(resp.Message)
+	return &created, nil
+}
+
+// List retrieves every note on a ticket, oldest first
+func (s *NoteService) List(ctx context.Context, ticketID int) ([]models.Note, error) {
+	thread, err := s.client.Messages.Thread(ctx, ticketID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []models.Note
+	for _, m := range thread {
+		if m.IsNote() {
+			notes = append(notes, models.NoteFromMessage(m))
+		}
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestNoteService(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/5/messages.json", http.StatusCreated, `{"message":{"id":10,"htmlBody":"<p>Called them</p>","threadType":"note","ticket":{"id":5},"createdBy":{"id":3,"type":"users"}}}`)
+	mockTransport.AddResponse(http.MethodGet, "/tickets/5/messages.json", http.StatusOK, models.MessagesResponse{
+		Messages: []models.Message{
+			{BaseEntity: models.BaseEntity{ID: 9}, Message: ptr("Hello")},
+			{BaseEntity: models.BaseEntity{ID: 10, CreatedBy: &models.UserRef{ID: 3}}, Message: ptr("<p>Called them</p>"), ThreadType: ptr(models.ThreadTypeNote)},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	note, err := c.Notes.Create(context.Background(), 5, &models.Note{Body: "<p>Called them</p>", Agent: &models.UserRef{ID: 3, Type: "users"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if note.ID != 10 || note.TicketID != 5 || note.Agent == nil || note.Agent.ID != 3 || note.Body != "<p>Called them</p>" {
+		t.Errorf("unexpected note %+v", note)
+	}
+
+	var sent models.Message
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if !sent.IsNote() || sent.CreatedBy == nil || sent.CreatedBy.ID != 3 {
+		t.Errorf("expected a note attributed to agent 3, got %s", b)
+	}
+
+	notes, err := c.Notes.List(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != 10 {
+		t.Errorf("expected only note 10, got %+v", notes)
+	}
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// OrderMode is the direction list results are sorted in
+type OrderMode string
+
+const (
+	OrderModeAsc  OrderMode = "asc"
+	OrderModeDesc OrderMode = "desc"
+)
+
+// PageParams holds the paging, ordering and include parameters accepted by
+// every list endpoint. It is embedded in the per-resource list parameters.
+type PageParams struct {
+	Page      int
+	PageSize  int
+	OrderBy   string
+	OrderMode OrderMode
+	// Includes lists the related resources to side-load, for example
+	// "customers" or "tags"
+	Includes []string
+}
+
+func (p PageParams) values() url.Values {
+	v := url.Values{}
+	if p.Page > 0 {
+		v.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		v.Set("pageSize", strconv.Itoa(p.PageSize))
+	}
+	if p.OrderBy != "" {
+		v.Set("orderBy", p.OrderBy)
+	}
+	if p.OrderMode != "" {
+		v.Set("orderMode", string(p.OrderMode))
+	}
+	if len(p.Includes) > 0 {
+		v.Set("includes", strings.Join(p.Includes, ","))
+	}
+	return v
+}
+
+// TicketListParams are the parameters accepted by TicketService.List.
+// Conditions are combined with AND; the IDs within a field with OR.
+//
+//	resp, err := c.Tickets.List(ctx, (&client.TicketListParams{
+//		InboxIDs:   []int{3},
+//		Unassigned: true,
+//	}).Values())
+type TicketListParams struct {
+	PageParams
+	InboxIDs    []int
+	StatusIDs   []int
+	StatusCodes []string
+	PriorityIDs []int
+	TypeIDs     []int
+	CustomerIDs []int
+	TagIDs      []int
+	// AssignedTo limits the list to tickets assigned to these agents
+	AssignedTo []int
+	// Unassigned limits the list to tickets without an agent. Combined with
+	// AssignedTo it matches tickets assigned to those agents or to nobody.
+	Unassigned bool
+	// UpdatedSince limits the list to tickets updated at or after this time
+	UpdatedSince time.Time
+}
+
+// Values encodes the parameters as the query string TicketService.List
+// expects
+func (p *TicketListParams) Values() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	var conditions []*FilterBuilder
+	conditions = appendIn(conditions, "inbox", p.InboxIDs)
+	conditions = appendIn(conditions, "status", p.StatusIDs)
+	conditions = appendIn(conditions, "status.code", p.StatusCodes)
+	conditions = appendIn(conditions, "priority", p.PriorityIDs)
+	conditions = appendIn(conditions, "type", p.TypeIDs)
+	conditions = appendIn(conditions, "customer", p.CustomerIDs)
+	conditions = appendIn(conditions, "tags", p.TagIDs)
+
+	switch {
+	case p.Unassigned && len(p.AssignedTo) > 0:
+		conditions = append(conditions, NewFilter().Or(
+			NewFilter().In("agent", anySlice(p.AssignedTo)),
+			NewFilter().Eq("agent", nil),
+		))
+	case p.Unassigned:
+		conditions = append(conditions, NewFilter().Eq("agent", nil))
+	default:
+		conditions = appendIn(conditions, "agent", p.AssignedTo)
+	}
+
+	if !p.UpdatedSince.IsZero() {
+		conditions = append(conditions, NewFilter().Gte("updatedAt", p.UpdatedSince.UTC().Format(time.RFC3339)))
+	}
+
+	return withConditions(p.PageParams.values(), conditions)
+}
+
+// CustomerListParams are the parameters accepted by CustomerService.List
+type CustomerListParams struct {
+	PageParams
+	CompanyIDs []int
+	Emails     []string
+	ExternalID string
+	// UpdatedSince limits the list to customers updated at or after this
+	// time
+	UpdatedSince time.Time
+}
+
+// Values encodes the parameters as the query string CustomerService.List
+// expects
+func (p *CustomerListParams) Values() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	var conditions []*FilterBuilder
+	conditions = appendIn(conditions, "company", p.CompanyIDs)
+	conditions = appendIn(conditions, "email", p.Emails)
+	if p.ExternalID != "" {
+		conditions = append(conditions, NewFilter().Eq("externalId", p.ExternalID))
+	}
+	if !p.UpdatedSince.IsZero() {
+		conditions = append(conditions, NewFilter().Gte("updatedAt", p.UpdatedSince.UTC().Format(time.RFC3339)))
+	}
+
+	return withConditions(p.PageParams.values(), conditions)
+}
+
+// CompanyListParams are the parameters accepted by CompanyService.List
+type CompanyListParams struct {
+	PageParams
+	Names  []string
+	TagIDs []int
+}
+
+// Values encodes the parameters as the query string CompanyService.List
+// expects
+func (p *CompanyListParams) Values() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	var conditions []*FilterBuilder
+	conditions = appendIn(conditions, "name", p.Names)
+	conditions = appendIn(conditions, "tags", p.TagIDs)
+
+	return withConditions(p.PageParams.values(), conditions)
+}
+
+// UserListParams are the parameters accepted by UserService.List
+type UserListParams struct {
+	PageParams
+	Emails   []string
+	InboxIDs []int
+}
+
+// Values encodes the parameters as the query string UserService.List expects
+func (p *UserListParams) Values() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	var conditions []*FilterBuilder
+	conditions = appendIn(conditions, "email", p.Emails)
+	conditions = appendIn(conditions, "inboxes", p.InboxIDs)
+
+	return withConditions(p.PageParams.values(), conditions)
+}
+
+// HappinessListParams are the parameters accepted by HappinessService.List
+type HappinessListParams struct {
+	PageParams
+	Ratings     []models.HappinessRating
+	TicketIDs   []int
+	CustomerIDs []int
+	AgentIDs    []int
+	// Since and Until limit the list to surveys answered at or after Since
+	// and before Until. Zero values leave the range open.
+	Since time.Time
+	Until time.Time
+}
+
+// Values encodes the parameters as the query string HappinessService.List
+// expects
+func (p *HappinessListParams) Values() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+
+	var conditions []*FilterBuilder
+	conditions = appendIn(conditions, "rating", p.Ratings)
+	conditions = appendIn(conditions, "ticket", p.TicketIDs)
+	conditions = appendIn(conditions, "customer", p.CustomerIDs)
+	conditions = appendIn(conditions, "agent", p.AgentIDs)
+	if !p.Since.IsZero() {
+		conditions = append(conditions, NewFilter().Gte("ratedAt", p.Since.UTC().Format(time.RFC3339)))
+	}
+	if !p.Until.IsZero() {
+		conditions = append(conditions, NewFilter().Lt("ratedAt", p.Until.UTC().Format(time.RFC3339)))
+	}
+
+	return withConditions(p.PageParams.values(), conditions)
+}
+
+// appendIn adds an $in condition on field when values is not empty
+func appendIn[V any](conditions []*FilterBuilder, field string, values []V) []*FilterBuilder {
+	if len(values) == 0 {
+		return conditions
+	}
+	return append(conditions, NewFilter().In(field, anySlice(values)))
+}
+
+func anySlice[V any](values []V) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// withConditions sets the filter parameter to conditions combined with AND.
+// A single condition is sent on its own to keep the query readable.
+func withConditions(v url.Values, conditions []*FilterBuilder) url.Values {
+	switch len(conditions) {
+	case 0:
+		return v
+	case 1:
+		v.Set("filter", conditions[0].Build())
+	default:
+		v.Set("filter", NewFilter().And(conditions...).Build())
+	}
+	return v
+}
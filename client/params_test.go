@@ -0,0 +1,92 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTicketListParamsValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *TicketListParams
+		want   url.Values
+	}{
+		{
+			name:   "nil",
+			params: nil,
+			want:   url.Values{},
+		},
+		{
+			name:   "paging only",
+			params: &TicketListParams{PageParams: PageParams{Page: 2, PageSize: 50, OrderBy: "updatedAt", OrderMode: OrderModeDesc, Includes: []string{"customers", "tags"}}},
+			want: url.Values{
+				"page":      {"2"},
+				"pageSize":  {"50"},
+				"orderBy":   {"updatedAt"},
+				"orderMode": {"desc"},
+				"includes":  {"customers,tags"},
+			},
+		},
+		{
+			name:   "single condition",
+			params: &TicketListParams{InboxIDs: []int{3, 4}},
+			want:   url.Values{"filter": {`{"inbox":{"$in":[3,4]}}`}},
+		},
+		{
+			name:   "unassigned",
+			params: &TicketListParams{Unassigned: true},
+			want:   url.Values{"filter": {`{"agent":{"$eq":null}}`}},
+		},
+		{
+			name:   "assigned or unassigned",
+			params: &TicketListParams{AssignedTo: []int{7}, Unassigned: true},
+			want:   url.Values{"filter": {`{"$or":[{"agent":{"$in":[7]}},{"agent":{"$eq":null}}]}`}},
+		},
+		{
+			name: "combined",
+			params: &TicketListParams{
+				StatusCodes:  []string{"active"},
+				AssignedTo:   []int{7},
+				UpdatedSince: time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("", 3600)),
+			},
+			want: url.Values{"filter": {`{"$and":[{"status.code":{"$in":["active"]}},{"agent":{"$in":[7]}},{"updatedAt":{"$gte":"2024-05-01T11:00:00Z"}}]}`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.Values(); got.Encode() != tt.want.Encode() {
+				t.Errorf("expected %s, got %s", tt.want.Encode(), got.Encode())
+			}
+		})
+	}
+}
+
+func TestHappinessListParamsValues(t *testing.T) {
+	params := &HappinessListParams{
+		Ratings: []models.HappinessRating{models.HappinessRatingUnhappy},
+		Since:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Until:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	want := `{"$and":[{"rating":{"$in":["unhappy"]}},{"ratedAt":{"$gte":"2024-05-01T00:00:00Z"}},{"ratedAt":{"$lt":"2024-06-01T00:00:00Z"}}]}`
+	if got := params.Values().Get("filter"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestListOptionsValues(t *testing.T) {
+	opts := &ListOptions{Page: 2, PerPage: 50, SortBy: "updatedAt", SortDir: "desc", Includes: []string{"tags"}}
+	want := url.Values{
+		"page":      {"2"},
+		"pageSize":  {"50"},
+		"orderBy":   {"updatedAt"},
+		"orderMode": {"desc"},
+		"includes":  {"tags"},
+	}
+	if got := opts.Values(); got.Encode() != want.Encode() {
+		t.Errorf("expected %s, got %s", want.Encode(), got.Encode())
+	}
+}
@@ -38,6 +38,10 @@ func (d DefaultPathHandler) Update(id int) string {
 	return d.base + "/" + strconv.Itoa(id)
 }
 
+func (d DefaultPathHandler) Delete(id int) string {
+	return d.base + "/" + strconv.Itoa(id)
+}
+
 func (d DefaultPathHandler) UpdateMethod() string {
 	if d.updateMethod == "" {
 		return http.MethodPut
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+const (
+	defaultRealtimeInterval   = 10 * time.Second
+	defaultRealtimeMaxBackoff = time.Minute
+)
+
+// TicketEventType identifies what happened to a ticket
+type TicketEventType string
+
+const (
+	TicketEventCreated TicketEventType = "created"
+	TicketEventUpdated TicketEventType = "updated"
+)
+
+// TicketEvent is a change to a ticket seen by Realtime
+type TicketEvent struct {
+	Type   TicketEventType
+	Ticket models.Ticket
+	// ResumeToken can be passed in RealtimeOptions to pick up the stream
+	// after this event
+	ResumeToken string
+}
+
+// RealtimeOptions configures NewRealtime
+type RealtimeOptions struct {
+	// Filter narrows the tickets watched, for example to a set of inboxes.
+	// Paging and LastUpdated are managed by Realtime.
+	Filter *models.SearchTicketsFilter
+
+	// Interval is how often Desk is checked for changes. Defaults to 10
+	// seconds.
+	Interval time.Duration
+
+	// MaxBackoff caps the delay between attempts while requests are
+	// failing. Defaults to one minute.
+	MaxBackoff time.Duration
+
+	// ResumeToken continues from a previous stream's TicketEvent. Without
+	// it only changes made after Run is called are reported.
+	ResumeToken string
+}
+
+// Realtime streams ticket changes as typed events. Desk has no push or
+// long-poll events endpoint, so Realtime polls ticket search for tickets
+// updated since the last event it delivered. Failed requests are retried
+// with backoff until the context passed to Run is done. Delivery is at least
+// once: after resuming, tickets updated at the exact instant of the resume
+// token may be delivered again.
+type Realtime struct {
+	client     *Client
+	filter     models.SearchTicketsFilter
+	interval   time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+
+	tickets chan TicketEvent
+	errors  chan error
+
+	mu      sync.Mutex
+	started bool
+	cursor  time.Time
+	// seen holds the tickets already delivered at exactly cursor, since
+	// LastUpdated includes them in the next search
+	seen map[int]bool
+}
+
+// NewRealtime creates a ticket stream. Nothing is requested until Run is
+// called.
+func NewRealtime(c *Client, opts RealtimeOptions) (*Realtime, error) {
+	r := &Realtime{
+		client:     c,
+		interval:   opts.Interval,
+		maxBackoff: opts.MaxBackoff,
+		now:        time.Now,
+		tickets:    make(chan TicketEvent),
+		errors:     make(chan error, 1),
+		seen:       map[int]bool{},
+	}
+	if opts.Filter != nil {
+		r.filter = *opts.Filter
+	}
+	if r.interval <= 0 {
+		r.interval = defaultRealtimeInterval
+	}
+	if r.maxBackoff <= 0 {
+		r.maxBackoff = defaultRealtimeMaxBackoff
+	}
+
+	if opts.ResumeToken != "" {
+		cursor, err := time.Parse(time.RFC3339Nano, opts.ResumeToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resume token: %w", err)
+		}
+		r.cursor = cursor
+	}
+
+	return r, nil
+}
+
+// Tickets returns the channel ticket events are delivered on. It is closed
+// when Run returns.
+func (r *Realtime) Tickets() <-chan TicketEvent {
+	return r.tickets
+}
+
+// Errors returns a channel reporting failed requests. Errors are dropped
+// when nobody is receiving; Run keeps retrying regardless.
+func (r *Realtime) Errors() <-chan error {
+	return r.errors
+}
+
+// ResumeToken returns a token for the last delivered event
+func (r *Realtime) ResumeToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cursor.Format(time.RFC3339Nano)
+}
+
+// Run streams events until ctx is done, then closes the channels and
+// returns ctx's error. A Realtime can only be run once; later calls return an
+// error straight away. Create a new one from ResumeToken to start again.
+func (r *Realtime) Run(ctx context.Context) error {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return fmt.Errorf("realtime stream has already been run")
+	}
+	r.started = true
+	if r.cursor.IsZero() {
+		r.cursor = r.now()
+	}
+	r.mu.Unlock()
+
+	defer close(r.tickets)
+	defer close(r.errors)
+
+	delay := r.interval
+	for {
+		if err := r.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			select {
+			case r.errors <- err:
+			default:
+			}
+			delay = min(delay*2, r.maxBackoff)
+		} else {
+			delay = r.interval
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Realtime) poll(ctx context.Context) error {
+	r.mu.Lock()
+	cursor := r.cursor
+	r.mu.Unlock()
+
+	filter := r.filter
+	filter.LastUpdated = &cursor
+	filter.Page = 1
+
+	var tickets []models.Ticket
+	for {
+		resp, err := r.client.Tickets.Search(ctx, &filter)
+		if err != nil {
+			return fmt.Errorf("search tickets: %w", err)
+		}
+		tickets = append(tickets, resp.Tickets...)
+
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+		filter.Page++
+	}
+
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return lastUpdated(tickets[i].BaseEntity).Before(lastUpdated(tickets[j].BaseEntity))
+	})
+
+	for _, t := range tickets {
+		updated := lastUpdated(t.BaseEntity)
+
+		r.mu.Lock()
+		skip := updated.Before(r.cursor) || (updated.Equal(r.cursor) && r.seen[t.ID])
+		r.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		event := TicketEvent{Type: TicketEventUpdated, Ticket: t, ResumeToken: updated.Format(time.RFC3339Nano)}
+		if t.CreatedAt != nil && !t.CreatedAt.Before(cursor) {
+			event.Type = TicketEventCreated
+		}
+
+		select {
+		case r.tickets <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		r.mu.Lock()
+		if updated.After(r.cursor) {
+			r.cursor = updated
+			r.seen = map[int]bool{}
+		}
+		r.seen[t.ID] = true
+		r.mu.Unlock()
+	}
+
+	return nil
+}
+
+// lastUpdated returns when an entity last changed, falling back to when it
+// was created
+func lastUpdated(e models.BaseEntity) time.Time {
+	if e.UpdatedAt != nil {
+		return *e.UpdatedAt
+	}
+	return eventTime(e)
+}
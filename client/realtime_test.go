@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestRealtimeDeliversEachChangeOnce(t *testing.T) {
+	start := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+	created := start.Add(time.Minute)
+	updated := start.Add(2 * time.Minute)
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 2, CreatedAt: &before, UpdatedAt: &updated}},
+			{BaseEntity: models.BaseEntity{ID: 1, CreatedAt: &created, UpdatedAt: &created}},
+			{BaseEntity: models.BaseEntity{ID: 3, CreatedAt: &before, UpdatedAt: &before}},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	rt, err := NewRealtime(c, RealtimeOptions{
+		Filter:      &models.SearchTicketsFilter{Inboxes: []int64{3}},
+		Interval:    time.Millisecond,
+		ResumeToken: start.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		t.Fatalf("NewRealtime() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	go rt.Run(ctx)
+
+	var events []TicketEvent
+	for e := range rt.Tickets() {
+		events = append(events, e)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(events), events)
+	}
+
+	if events[0].Ticket.ID != 1 || events[0].Type != TicketEventCreated {
+		t.Errorf("expected ticket 1 created first, got ticket %d %s", events[0].Ticket.ID, events[0].Type)
+	}
+
+	if events[1].Ticket.ID != 2 || events[1].Type != TicketEventUpdated {
+		t.Errorf("expected ticket 2 updated second, got ticket %d %s", events[1].Ticket.ID, events[1].Type)
+	}
+
+	if want := updated.Format(time.RFC3339Nano); rt.ResumeToken() != want {
+		t.Errorf("expected resume token %s, got %s", want, rt.ResumeToken())
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) < 2 {
+		t.Fatalf("expected repeated polling, got %d requests", len(requests))
+	}
+	if got := requests[0].URL.Query().Get("inboxes"); got != "3" {
+		t.Errorf("expected the inbox filter to be sent, got %q", got)
+	}
+}
+
+func TestNewRealtimeRejectsBadResumeToken(t *testing.T) {
+	if _, err := NewRealtime(NewClient("https://example.com"), RealtimeOptions{ResumeToken: "yesterday"}); err == nil {
+		t.Fatal("expected error for an invalid resume token")
+	}
+}
+
+func TestRealtimeRunOnce(t *testing.T) {
+	rt, err := NewRealtime(NewClient("https://example.com"), RealtimeOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := rt.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if err := rt.Run(context.Background()); err == nil || errors.Is(err, context.Canceled) {
+		t.Errorf("expected an error for a second Run, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ReportService pulls reporting metrics. Reports are a beta endpoint, so the
+// client must be created with WithBetaEndpoints; otherwise every method
+// fails with ErrBetaDisabled.
+type ReportService struct {
+	client *Client
+}
+
+// NewReportService creates a new report service
+func NewReportService(client *Client) *ReportService {
+	return &ReportService{client: client}
+}
+
+// ReportOptions are the parameters shared by every report
+type ReportOptions struct {
+	// Since and Until are the range the report covers, from Since up to but
+	// not including Until. Both are required.
+	Since time.Time
+	Until time.Time
+	// Interval is the period of each row of time series reports. Defaults to
+	// a day.
+	Interval models.ReportInterval
+	InboxIDs []int
+	AgentIDs []int
+}
+
+// Values encodes the options as the query string the report endpoints
+// expect
+func (o ReportOptions) Values() url.Values {
+	v := url.Values{}
+	v.Set("startDate", o.Since.UTC().Format(time.RFC3339))
+	v.Set("endDate", o.Until.UTC().Format(time.RFC3339))
+	if o.Interval != "" {
+		v.Set("interval", string(o.Interval))
+	}
+	if len(o.InboxIDs) > 0 {
+		v.Set("inboxes", joinIDs(o.InboxIDs))
+	}
+	if len(o.AgentIDs) > 0 {
+		v.Set("agents", joinIDs(o.AgentIDs))
+	}
+	return v
+}
+
+func (o ReportOptions) validate() error {
+	if o.Since.IsZero() || o.Until.IsZero() {
+		return fmt.Errorf("since and until are required")
+	}
+	if !o.Until.After(o.Since) {
+		return fmt.Errorf("until must be after since")
+	}
+	return nil
+}
+
+// TicketVolume reports how many tickets were created, solved, closed and
+// reopened in each interval
+func (s *ReportService) TicketVolume(ctx context.Context, opts ReportOptions) ([]models.TicketVolumeRow, error) {
+	return fetchReport[models.TicketVolumeRow](ctx, s.client, "reports/tickets/volume.json", opts)
+}
+
+// AgentPerformance reports each agent's workload and response times over the
+// whole range. The interval is ignored.
+func (s *ReportService) AgentPerformance(ctx context.Context, opts ReportOptions) ([]models.AgentPerformanceRow, error) {
+	return fetchReport[models.AgentPerformanceRow](ctx, s.client, "reports/agents/performance.json", opts)
+}
+
+// HappinessSummary reports the number of happy, neutral and unhappy ratings
+// in each interval
+func (s *ReportService) HappinessSummary(ctx context.Context, opts ReportOptions) ([]models.HappinessSummaryRow, error) {
+	return fetchReport[models.HappinessSummaryRow](ctx, s.client, "reports/happiness/summary.json", opts)
+}
+
+func fetchReport[R any](ctx context.Context, c *Client, path string, opts ReportOptions) ([]R, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Rows []R `json:"rows"`
+	}
+	if err := c.request(ctx, http.MethodGet, path+"?"+opts.Values().Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Rows, nil
+}
+
+func joinIDs(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReportServiceHappinessSummary(t *testing.T) {
+	mock := NewMockRoundTripper()
+	mock.AddResponse(http.MethodGet, "/reports/happiness/summary.json", http.StatusOK,
+		`{"rows":[{"date":"2024-05-01T00:00:00Z","happy":3,"neutral":0,"unhappy":1},{"date":"2024-05-02T00:00:00Z"}]}`)
+
+	opts := ReportOptions{
+		Since:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Until:    time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		InboxIDs: []int{2, 5},
+	}
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mock}))
+	if _, err := c.Reports.HappinessSummary(context.Background(), opts); !errors.Is(err, ErrBetaDisabled) {
+		t.Fatalf("expected ErrBetaDisabled, got %v", err)
+	}
+
+	c = NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mock}), WithBetaEndpoints())
+	rows, err := c.Reports.HappinessSummary(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rows) != 2 || rows[0].Score() != 2.5 || rows[1].Score() != 0 {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+
+	q := mock.GetRequests()[0].URL.Query()
+	if q.Get("startDate") != "2024-05-01T00:00:00Z" || q.Get("endDate") != "2024-05-03T00:00:00Z" || q.Get("inboxes") != "2,5" {
+		t.Errorf("unexpected query %s", q.Encode())
+	}
+
+	if _, err := c.Reports.TicketVolume(context.Background(), ReportOptions{Since: opts.Until, Until: opts.Since}); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader is the header carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying a request ID. Requests made with
+// the context send it in the X-Request-ID header, so a caller that already
+// has an ID for the work it is doing, such as one from an incoming request,
+// can use it to trace the call through Desk.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// NewRequestID returns a new UUIDv7. The IDs are random but sort by creation
+// time, which keeps them readable in logs.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[6:])
+
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(b[0:], uint16(ms>>32))
+	binary.BigEndian.PutUint32(b[2:], uint32(ms))
+	b[6] = b[6]&0x0f | 0x70 // version 7
+	b[8] = b[8]&0x3f | 0x80 // RFC 9562 variant
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
+
+// requestIDAttr returns the request's ID as a log attribute, or an empty
+// attribute, which slog drops, when it has none
+func requestIDAttr(req *http.Request) slog.Attr {
+	if id := req.Header.Get(RequestIDHeader); id != "" {
+		return slog.String("request_id", id)
+	}
+	return slog.Attr{}
+}
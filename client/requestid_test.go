@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestNewRequestID(t *testing.T) {
+	uuidv7 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		seen = map[string]bool{}
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				id := NewRequestID()
+				if !uuidv7.MatchString(id) {
+					t.Errorf("%q is not a UUIDv7", id)
+				}
+
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate request ID %q", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRequestIDMiddlewareUsesContextID(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tags/1.json", http.StatusOK, `{"tag":{"id":1}}`)
+
+	c := NewClient("https://example.com",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithNamedMiddleware("request-id", RequestIDMiddleware()),
+	)
+
+	ctx := WithRequestID(context.Background(), "trace-123")
+	if _, err := c.Tags.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.Tags.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if got := requests[0].Header.Get(RequestIDHeader); got != "trace-123" {
+		t.Errorf("expected the context request ID, got %q", got)
+	}
+	if got := requests[1].Header.Get(RequestIDHeader); got == "" || got == "trace-123" {
+		t.Errorf("expected a generated request ID, got %q", got)
+	}
+}
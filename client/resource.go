@@ -9,12 +9,18 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
+	"sync"
 )
 
 // Service handles generic resource operations
 type Service[T any, L any] struct {
 	client *Client
 	router PathHandler
+
+	mu    sync.RWMutex
+	hooks []hooks
 }
 
 type PathHandler interface {
@@ -22,6 +28,7 @@ type PathHandler interface {
 	List() string
 	Create() string
 	Update(id int) string
+	Delete(id int) string
 }
 
 type updateMethodProvider interface {
@@ -43,7 +50,10 @@ func (s *Service[T, L]) logError(msg string, attrs ...slog.Attr) {
 	}
 }
 
-// Get retrieves a resource by ID
+// Get retrieves a resource by ID. Nil params side-load every related
+// resource; use GetOptions to choose which.
+//
+//	c.Tickets.Get(ctx, id, (&client.GetOptions{Includes: []string{"customers"}}).Values())
 func (s *Service[T, L]) Get(ctx context.Context, id int, params url.Values) (*T, error) {
 	if params == nil {
 		params = (&GetOptions{}).Values()
@@ -55,9 +65,9 @@ func (s *Service[T, L]) Get(ctx context.Context, id int, params url.Values) (*T,
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
-		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodGet), slog.String("url", req.URL.String()))
+		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodGet), slog.String("url", req.URL.String()), requestIDAttr(req))
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -68,9 +78,10 @@ func (s *Service[T, L]) Get(ctx context.Context, id int, params url.Values) (*T,
 			slog.Int("status_code", resp.StatusCode),
 			slog.String("method", http.MethodGet),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 			slog.String("response_body", string(body)),
 		)
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(req, resp, body)
 	}
 
 	var resource T
@@ -79,6 +90,7 @@ func (s *Service[T, L]) Get(ctx context.Context, id int, params url.Values) (*T,
 			slog.Any("error", err),
 			slog.String("method", http.MethodGet),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 		)
 		return nil, err
 	}
@@ -86,7 +98,9 @@ func (s *Service[T, L]) Get(ctx context.Context, id int, params url.Values) (*T,
 	return &resource, nil
 }
 
-// List retrieves a list of resources with optional filters
+// List retrieves a list of resources with optional filters. Related
+// resources are only side-loaded when params ask for them, through
+// ListOptions, PageParams or the typed list parameters.
 func (s *Service[T, L]) List(ctx context.Context, params url.Values) (*L, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		fmt.Sprintf("%s/%s.json?%s", s.client.baseURL, s.router.List(), params.Encode()), nil)
@@ -95,9 +109,9 @@ func (s *Service[T, L]) List(ctx context.Context, params url.Values) (*L, error)
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
-		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodGet), slog.String("url", req.URL.String()))
+		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodGet), slog.String("url", req.URL.String()), requestIDAttr(req))
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -108,9 +122,10 @@ func (s *Service[T, L]) List(ctx context.Context, params url.Values) (*L, error)
 			slog.Int("status_code", resp.StatusCode),
 			slog.String("method", http.MethodGet),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 			slog.String("response_body", string(body)),
 		)
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(req, resp, body)
 	}
 
 	var resources L
@@ -119,6 +134,7 @@ func (s *Service[T, L]) List(ctx context.Context, params url.Values) (*L, error)
 			slog.Any("error", err),
 			slog.String("method", http.MethodGet),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 		)
 		return nil, err
 	}
@@ -141,9 +157,9 @@ func (s *Service[T, L]) Create(ctx context.Context, resource *T) (*T, error) {
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
-		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodPost), slog.String("url", req.URL.String()))
+		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodPost), slog.String("url", req.URL.String()), requestIDAttr(req))
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -156,6 +172,7 @@ func (s *Service[T, L]) Create(ctx context.Context, resource *T) (*T, error) {
 				slog.Int("status_code", resp.StatusCode),
 				slog.String("method", http.MethodPost),
 				slog.String("url", req.URL.String()),
+				requestIDAttr(req),
 			)
 			return nil, err
 		}
@@ -164,9 +181,10 @@ func (s *Service[T, L]) Create(ctx context.Context, resource *T) (*T, error) {
 			slog.Int("status_code", resp.StatusCode),
 			slog.String("method", http.MethodPost),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 			slog.String("response_body", string(b)),
 		)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(b))
+		return nil, newAPIError(req, resp, b)
 	}
 
 	var createdResource T
@@ -175,6 +193,7 @@ func (s *Service[T, L]) Create(ctx context.Context, resource *T) (*T, error) {
 			slog.Any("error", err),
 			slog.String("method", http.MethodPost),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 		)
 		return nil, err
 	}
@@ -197,6 +216,71 @@ func (s *Service[T, L]) Update(ctx context.Context, id int, resource *T) (*T, er
 		}
 	}
 
+	return s.update(ctx, method, id, body)
+}
+
+// Patch changes only the fields in partial, leaving the rest of the resource
+// as it is, so a single field can be updated without fetching and resending
+// the whole model. partial is either a map keyed by JSON field name or a
+// sparse struct whose fields are all omitempty, such as
+// models.InboxSpamSettings. Models themselves are not sparse: some of their
+// fields are always sent and would be overwritten. partial is wrapped in the
+// resource's envelope key, so
+//
+//	c.Tickets.Patch(ctx, id, map[string]any{"status": models.EntityRef{ID: 3}})
+//
+// sends {"ticket":{"status":{"id":3,...}}}.
+func (s *Service[T, L]) Patch(ctx context.Context, id int, partial any) (*T, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+	if partial == nil {
+		return nil, fmt.Errorf("partial is required")
+	}
+
+	var payload any = partial
+	if key := envelopeKey[T](); key != "" {
+		payload = map[string]any{key: partial}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logError("failed to marshal request body", slog.Any("error", err))
+		return nil, err
+	}
+
+	return s.update(ctx, http.MethodPatch, id, body)
+}
+
+// envelopeKey returns the JSON key a single-resource response wraps its
+// resource in, for example "ticket" for models.TicketResponse: the key of a
+// models.Single envelope, or else the first field that is not the included
+// data. It is empty for types that are not such a wrapper.
+func envelopeKey[T any]() string {
+	var zero T
+	if e, ok := any(zero).(interface{ EnvelopeKey() string }); ok {
+		return e.EnvelopeKey()
+	}
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.IsExported() && name != "" && name != "-" && name != "included" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// update sends an already encoded update with the given method and decodes
+// the updated resource
+func (s *Service[T, L]) update(ctx context.Context, method string, id int, body []byte) (*T, error) {
 	req, err := http.NewRequestWithContext(ctx, method,
 		fmt.Sprintf("%s/%s.json", s.client.baseURL, s.router.Update(id)), bytes.NewBuffer(body))
 	if err != nil {
@@ -204,9 +288,9 @@ func (s *Service[T, L]) Update(ctx context.Context, id int, resource *T) (*T, er
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
-		s.logError("request failed", slog.Any("error", err), slog.String("method", method), slog.String("url", req.URL.String()))
+		s.logError("request failed", slog.Any("error", err), slog.String("method", method), slog.String("url", req.URL.String()), requestIDAttr(req))
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -217,9 +301,10 @@ func (s *Service[T, L]) Update(ctx context.Context, id int, resource *T) (*T, er
 			slog.Int("status_code", resp.StatusCode),
 			slog.String("method", method),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 			slog.String("response_body", string(body)),
 		)
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(req, resp, body)
 	}
 
 	var updatedResource T
@@ -228,9 +313,45 @@ func (s *Service[T, L]) Update(ctx context.Context, id int, resource *T) (*T, er
 			slog.Any("error", err),
 			slog.String("method", method),
 			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
 		)
 		return nil, err
 	}
 
 	return &updatedResource, nil
 }
+
+// Delete deletes a resource by ID
+func (s *Service[T, L]) Delete(ctx context.Context, id int) error {
+	if id <= 0 {
+		return fmt.Errorf("id must be greater than 0")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+		fmt.Sprintf("%s/%s.json", s.client.baseURL, s.router.Delete(id)), nil)
+	if err != nil {
+		s.logError("failed to create request", slog.Any("error", err))
+		return err
+	}
+
+	resp, err := s.do(ctx, req)
+	if err != nil {
+		s.logError("request failed", slog.Any("error", err), slog.String("method", http.MethodDelete), slog.String("url", req.URL.String()), requestIDAttr(req))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		s.logError("unexpected status code",
+			slog.Int("status_code", resp.StatusCode),
+			slog.String("method", http.MethodDelete),
+			slog.String("url", req.URL.String()),
+			requestIDAttr(req),
+			slog.String("response_body", string(body)),
+		)
+		return newAPIError(req, resp, body)
+	}
+
+	return nil
+}
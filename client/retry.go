@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicyMiddlewareName is the name the retry policy is registered under
+const RetryPolicyMiddlewareName = "retry"
+
+// retryPolicyPriority places the retry policy just inside middleware added
+// with the default priority 0, such as the journal and usage tracking, so
+// those see one request per call. Middleware that should run on every
+// attempt, such as a rate limiter, needs a priority above 1.
+const retryPolicyPriority = 1
+
+// RetryPolicy controls how the client retries requests that the API turned
+// away because it was overloaded or rate limited. See WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. 1 or
+	// less disables retrying.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the second attempt. Later delays
+	// double, up to MaxBackoff, and are jittered so that clients that failed
+	// together don't retry together.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Statuses are the response statuses that are retried
+	Statuses []int
+
+	// RetryNonIdempotent also retries POST and PATCH requests on statuses
+	// other than 429. A 429 is always safe to retry because the request was
+	// not processed, but a 502 or 503 may come from a proxy after Desk has
+	// already acted on the request, so retrying could create duplicates.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy that makes up to 4 attempts on 429, 502
+// and 503, backing off from half a second up to 30 seconds
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Statuses:       []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable},
+	}
+}
+
+// WithRetryPolicy retries requests according to policy, usually
+// DefaultRetryPolicy(). Unlike RetryMiddleware, which retries transport
+// errors after a fixed delay, it retries overloaded and rate limited
+// responses and honors Retry-After.
+//
+//	c := client.NewClient(baseURL, client.WithRetryPolicy(client.DefaultRetryPolicy()))
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return WithMiddlewarePriority(RetryPolicyMiddlewareName, retryPolicyPriority, RetryPolicyMiddleware(policy))
+}
+
+// RetryPolicyMiddleware retries requests that fail with one of the policy's
+// statuses. It waits for the Retry-After header when the response has one
+// and backs off exponentially with jitter otherwise. When the attempts run
+// out, or the wait would run past the context's deadline, it gives up with a
+// *RetryError wrapping the last response's *APIError, like RetryMiddleware.
+// Cancelling the context stops the wait.
+func RetryPolicyMiddleware(policy RetryPolicy) MiddlewareFunc {
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		start := time.Now()
+		var statuses []int
+		for attempt := 1; ; attempt++ {
+			attemptReq := req
+			if attempt > 1 {
+				attemptReq = req.Clone(ctx)
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					attemptReq.Body = body
+				}
+			}
+
+			resp, err := next(ctx, attemptReq)
+			statuses = append(statuses, responseStatus(resp))
+			if err != nil {
+				if attempt > 1 {
+					return nil, newRetryError(req, resp, err, statuses, start)
+				}
+				return resp, err
+			}
+			if !policy.retries(req, resp.StatusCode) {
+				return resp, nil
+			}
+			if attempt >= policy.MaxAttempts {
+				return policy.giveUp(req, resp, statuses, start)
+			}
+			if req.Body != nil && req.GetBody == nil {
+				// The body has been consumed and cannot be sent again
+				return resp, nil
+			}
+
+			delay, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if !ok {
+				delay = policy.backoff(attempt)
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return policy.giveUp(req, resp, statuses, start)
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+// giveUp ends retrying on a response with a retried status. With retrying
+// disabled the response is returned as it is.
+func (p RetryPolicy) giveUp(req *http.Request, resp *http.Response, statuses []int, start time.Time) (*http.Response, error) {
+	if p.MaxAttempts <= 1 {
+		return resp, nil
+	}
+	return nil, newRetryError(req, resp, nil, statuses, start)
+}
+
+// retries reports whether a response with the given status is retried
+func (p RetryPolicy) retries(req *http.Request, status int) bool {
+	if !slices.Contains(p.Statuses, status) {
+		return false
+	}
+	if status == http.StatusTooManyRequests || p.RetryNonIdempotent {
+		return true
+	}
+	return req.Method != http.MethodPost && req.Method != http.MethodPatch
+}
+
+// backoff returns the jittered delay after the given failed attempt, between
+// half and all of the exponential delay
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
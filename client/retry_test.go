@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		attempts = append(attempts, string(body))
+
+		switch len(attempts) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"tag":{"id":1}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:        3,
+		InitialBackoff:     time.Millisecond,
+		Statuses:           []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		RetryNonIdempotent: true,
+	}))
+
+	name := "billing"
+	tag, err := c.Tags.Create(context.Background(), &models.TagResponse{Tag: models.Tag{Name: &name}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tag.Tag.ID != 1 {
+		t.Errorf("expected tag 1, got %d", tag.Tag.ID)
+	}
+
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(attempts))
+	}
+	for i, body := range attempts {
+		if !strings.Contains(body, name) {
+			t.Errorf("expected attempt %d to resend the body, got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Statuses:       []int{http.StatusBadGateway},
+	}))
+
+	name := "billing"
+	if _, err := c.Tags.Create(context.Background(), &models.TagResponse{Tag: models.Tag{Name: &name}}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a POST not to be retried on 502, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	_, err := c.Tags.Get(context.Background(), 1, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 3 {
+		t.Errorf("expected a GET to be retried on 502, got %d attempts", attempts)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected a *RetryError, got %v", err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", retryErr.Attempts)
+	}
+	if want := []int{502, 502, 502}; !slices.Equal(retryErr.Statuses, want) {
+		t.Errorf("expected statuses %v, got %v", want, retryErr.Statuses)
+	}
+	if retryErr.Elapsed <= 0 {
+		t.Errorf("expected the elapsed time to be recorded, got %s", retryErr.Elapsed)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected the last 502 to be wrapped as an *APIError, got %v", err)
+	}
+}
+
+func TestRetryPolicyDeadline(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithRetryPolicy(DefaultRetryPolicy()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := c.Tags.Get(ctx, 1, nil)
+	if attempts != 1 {
+		t.Errorf("expected no retry when Retry-After passes the deadline, got %d attempts", attempts)
+	}
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || !slices.Equal(retryErr.Statuses, []int{429}) {
+		t.Fatalf("expected a *RetryError for the single 429, got %v", err)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	responses := []struct {
+		status int
+		err    error
+	}{
+		{err: fmt.Errorf("connection reset")},
+		{status: http.StatusServiceUnavailable},
+		{status: http.StatusInternalServerError},
+	}
+
+	var attempts int
+	next := func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		r := responses[attempts]
+		attempts++
+		if r.err != nil {
+			return nil, r.err
+		}
+		return &http.Response{
+			StatusCode: r.status,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{"message":"upstream down"}`)),
+		}, nil
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com/tickets/1.json", nil)
+	resp, err := RetryMiddleware(2, time.Millisecond)(context.Background(), req, next)
+	if resp != nil {
+		t.Errorf("expected no response once retries are exhausted, got %d", resp.StatusCode)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected a *RetryError, got %v", err)
+	}
+	if retryErr.Attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", retryErr.Attempts)
+	}
+	if want := []int{0, 503, 500}; !slices.Equal(retryErr.Statuses, want) {
+		t.Errorf("expected statuses %v, got %v", want, retryErr.Statuses)
+	}
+	if retryErr.Elapsed < 2*time.Millisecond {
+		t.Errorf("expected the elapsed time to include the delays, got %s", retryErr.Elapsed)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected the last attempt's *APIError to be wrapped, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError || apiErr.Message != "upstream down" {
+		t.Errorf("unexpected APIError %+v", apiErr)
+	}
+}
+
+func TestRetryMiddlewareRecovers(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMiddleware(RetryMiddleware(3, 0)))
+
+	_, err := c.Tags.Get(context.Background(), 1, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the 404 to be returned as it is, got %v", err)
+	}
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("expected no *RetryError for a status that is not retried, got %v", retryErr)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the 429 to be retried once, got %d attempts", attempts)
+	}
+}
+
+func TestRetryMiddlewareNonIdempotent(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMiddleware(RetryMiddleware(3, 0)))
+
+	_, err := c.Tags.Create(context.Background(), &models.TagResponse{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected the 502 to be returned, got %v", err)
+	}
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("expected no *RetryError for a POST that got a 5xx, got %v", retryErr)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the 429 to be retried and the 502 not, got %d attempts", attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Duration{
+		"3":                             3 * time.Second,
+		"Wed, 01 Jan 2025 12:00:10 GMT": 10 * time.Second,
+		"Wed, 01 Jan 2025 11:00:00 GMT": 0,
+	}
+	for header, want := range tests {
+		got, ok := retryAfter(header, now)
+		if !ok || got != want {
+			t.Errorf("retryAfter(%q) = %s, %v, want %s", header, got, ok, want)
+		}
+	}
+
+	for _, header := range []string{"", "-1", "soon"} {
+		if _, ok := retryAfter(header, now); ok {
+			t.Errorf("expected %q to be rejected", header)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// RoleService handles agent roles and the permissions they grant. Give a
+// user a role with UserService.SetRole.
+type RoleService struct {
+	*Service[models.RoleResponse, models.RolesResponse]
+}
+
+// NewRoleService creates a new role service
+func NewRoleService(client *Client) *RoleService {
+	return &RoleService{
+		Service: NewService[models.RoleResponse, models.RolesResponse](client, NewDefaultPathHandler("roles")),
+	}
+}
+
+// Get retrieves a role by ID
+func (s *RoleService) Get(ctx context.Context, id int, params url.Values) (*models.RoleResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of roles with optional filters
+func (s *RoleService) List(ctx context.Context, params url.Values) (*models.RolesResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Create creates a new role. The name is required.
+func (s *RoleService) Create(ctx context.Context, role *models.RoleResponse) (*models.RoleResponse, error) {
+	if role == nil {
+		return nil, fmt.Errorf("role is required")
+	}
+	if role.Item.Name == nil || *role.Item.Name == "" {
+		return nil, fmt.Errorf("role.name is required")
+	}
+
+	return s.Service.Create(ctx, role)
+}
+
+// CreateBatch creates roles in parallel, validating each one as Create does.
+// See Service.CreateBatch.
+func (s *RoleService) CreateBatch(ctx context.Context, items []*models.RoleResponse, opts BatchOptions) ([]BatchResult[models.RoleResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing role. Its permissions are replaced with
+// role.Permissions when that is not empty.
+func (s *RoleService) Update(ctx context.Context, id int, role *models.RoleResponse) (*models.RoleResponse, error) {
+	return s.Service.Update(ctx, id, role)
+}
+
+// Delete deletes a role
+func (s *RoleService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
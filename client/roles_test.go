@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestUserServiceSetRole(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/users/4.json", http.StatusOK,
+		`{"user":{"id":4,"agentRole":{"id":2,"type":"roles"},"permissions":["reports.view"]}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := c.Users.SetRole(context.Background(), 4, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !resp.User.HasPermission(models.PermissionReportsView) || resp.User.HasPermission(models.PermissionUsersManage) {
+		t.Errorf("unexpected permissions %v", resp.User.Permissions)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if want := `{"user":{"agentRole":{"id":2,"type":"roles","meta":null}}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+
+	if _, err := c.Users.SetRole(context.Background(), 4, 0); err == nil {
+		t.Error("expected an error without a role")
+	}
+}
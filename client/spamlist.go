@@ -2,7 +2,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/mail"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -29,12 +34,74 @@ func (s *SpamlistService) List(ctx context.Context, params url.Values) (*models.
 	return s.Service.List(ctx, params)
 }
 
-// Create creates a new spamlist
+// Create creates a new spamlist. The term must be an email address, domain,
+// IP address or CIDR range, and the type whitelist or blacklist.
 func (s *SpamlistService) Create(ctx context.Context, spamlist *models.SpamlistResponse) (*models.SpamlistResponse, error) {
+	if spamlist == nil {
+		return nil, fmt.Errorf("spamlist is required")
+	}
+
+	if spamlist.Spamlist.Term == nil || *spamlist.Spamlist.Term == "" {
+		return nil, fmt.Errorf("spamlist.term is required")
+	}
+
+	if spamlist.Spamlist.Type == nil {
+		return nil, fmt.Errorf("spamlist.type is required")
+	}
+
+	if err := validateSpamlist(spamlist.Spamlist); err != nil {
+		return nil, err
+	}
+
 	return s.Service.Create(ctx, spamlist)
 }
 
-// Update updates an existing spamlist
+// CreateBatch creates spamlist entries in parallel, validating each one as Create does.
+// See Service.CreateBatch.
+func (s *SpamlistService) CreateBatch(ctx context.Context, items []*models.SpamlistResponse, opts BatchOptions) ([]BatchResult[models.SpamlistResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing spamlist. A term or type that is set is validated
+// as in Create.
 func (s *SpamlistService) Update(ctx context.Context, id int, spamlist *models.SpamlistResponse) (*models.SpamlistResponse, error) {
+	if spamlist != nil {
+		if err := validateSpamlist(spamlist.Spamlist); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.Service.Update(ctx, id, spamlist)
 }
+
+// validateSpamlist checks the fields of a spamlist that are set
+func validateSpamlist(spamlist models.Spamlist) error {
+	if t := spamlist.Type; t != nil && *t != models.SpamlistTypeWhitelist && *t != models.SpamlistTypeBlacklist {
+		return fmt.Errorf("spamlist.type %q is invalid, must be %q or %q", *t, models.SpamlistTypeWhitelist, models.SpamlistTypeBlacklist)
+	}
+
+	if spamlist.Term == nil {
+		return nil
+	}
+
+	term := strings.TrimSpace(*spamlist.Term)
+	switch {
+	case net.ParseIP(term) != nil:
+		return nil
+	case strings.Contains(term, "/"):
+		if _, _, err := net.ParseCIDR(term); err == nil {
+			return nil
+		}
+	case strings.Contains(term, "@"):
+		if addr, err := mail.ParseAddress(term); err == nil && addr.Address == term {
+			return nil
+		}
+	default:
+		domain := regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+		if domain.MatchString(term) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("spamlist.term %q is not a valid email address, domain, IP address or CIDR range", *spamlist.Term)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestValidateSpamlist(t *testing.T) {
+	tests := []struct {
+		term    string
+		wantErr bool
+	}{
+		{"spammer@example.com", false},
+		{"example.com", false},
+		{"mail.example.co.uk", false},
+		{"192.168.0.1", false},
+		{"2001:db8::1", false},
+		{"10.0.0.0/8", false},
+		{"spammer@", true},
+		{"Spammer <spammer@example.com>", true},
+		{"example", true},
+		{"exa mple.com", true},
+		{"300.1.1.1/40", true},
+		{"-example.com", true},
+	}
+
+	for _, tt := range tests {
+		err := validateSpamlist(models.Spamlist{Term: ptr(tt.term)})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateSpamlist(%q) error = %v, wantErr %v", tt.term, err, tt.wantErr)
+		}
+	}
+
+	if err := validateSpamlist(models.Spamlist{Type: ptr(models.SpamlistType("blocklist"))}); err == nil {
+		t.Errorf("expected an error for an unknown type")
+	}
+}
+
+func TestSpamlistServiceCreateValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	_, err := c.Spamlists.Create(context.Background(), &models.SpamlistResponse{Spamlist: models.Spamlist{
+		Term: ptr("spammer@@example.com"),
+		Type: ptr(models.SpamlistTypeBlacklist),
+	}})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid term")
+	}
+
+	if len(mockTransport.GetRequests()) != 0 {
+		t.Errorf("expected no request to be sent")
+	}
+}
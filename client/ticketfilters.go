@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// TicketFilterService handles saved ticket views, so dashboards can show the
+// same tickets agents see in a view
+type TicketFilterService struct {
+	*Service[models.TicketFilterResponse, models.TicketFiltersResponse]
+}
+
+// NewTicketFilterService creates a new ticket filter service
+func NewTicketFilterService(client *Client) *TicketFilterService {
+	return &TicketFilterService{
+		Service: NewService[models.TicketFilterResponse, models.TicketFiltersResponse](client, NewDefaultPathHandler("ticketfilters")),
+	}
+}
+
+// Get retrieves a saved view by ID
+func (s *TicketFilterService) Get(ctx context.Context, id int, params url.Values) (*models.TicketFilterResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves the saved views visible to the API key's user
+func (s *TicketFilterService) List(ctx context.Context, params url.Values) (*models.TicketFiltersResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Tickets retrieves a page of the tickets in a saved view, in the view's
+// order. params takes the usual paging and include parameters.
+func (s *TicketFilterService) Tickets(ctx context.Context, id int, params url.Values) (*models.TicketsResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	path := fmt.Sprintf("ticketfilters/%d/tickets.json", id)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.TicketsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
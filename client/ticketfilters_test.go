@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTicketFilterServiceTickets(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/ticketfilters.json", http.StatusOK, `{"ticketFilters":[{"id":2,"name":"My tickets","isDefault":true}]}`)
+	mockTransport.AddResponse(http.MethodGet, "/ticketfilters/2/tickets.json", http.StatusOK, `{"tickets":[{"id":10},{"id":11}],"pagination":{"page":2}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	views, err := c.TicketFilters.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(views.Items) != 1 || *views.Items[0].Name != "My tickets" {
+		t.Fatalf("unexpected views %+v", views.Items)
+	}
+
+	tickets, err := c.TicketFilters.Tickets(context.Background(), views.Items[0].ID, url.Values{"page": {"2"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(tickets.Tickets) != 2 {
+		t.Errorf("expected 2 tickets, got %d", len(tickets.Tickets))
+	}
+	if got := mockTransport.GetRequests()[1].URL.Query().Get("page"); got != "2" {
+		t.Errorf("expected the page to be passed on, got %q", got)
+	}
+}
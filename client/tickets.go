@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/sonh/qs"
 	"github.com/teamwork/desksdkgo/models"
@@ -51,14 +56,15 @@ func (s *TicketService) Search(ctx context.Context, filter *models.SearchTickets
 		return nil, err
 	}
 
-	resp, err := s.client.doRequest(ctx, req)
+	resp, err := s.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(req, resp, body)
 	}
 
 	var resources models.TicketsResponse
@@ -69,6 +75,24 @@ func (s *TicketService) Search(ctx context.Context, filter *models.SearchTickets
 	return &resources, nil
 }
 
+// Count returns the number of tickets matching filter without fetching them.
+// The filter's paging fields are ignored.
+func (s *TicketService) Count(ctx context.Context, filter *models.SearchTicketsFilter) (int, error) {
+	f := models.SearchTicketsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.Page = 1
+	f.PageSize = 1
+
+	resp, err := s.Search(ctx, &f)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Pagination.Records, nil
+}
+
 // Create creates a new ticket
 func (s *TicketService) Create(ctx context.Context, ticket *models.TicketResponse) (*models.TicketResponse, error) {
 	return s.Service.Create(ctx, ticket)
@@ -78,3 +102,446 @@ func (s *TicketService) Create(ctx context.Context, ticket *models.TicketRespons
 func (s *TicketService) Update(ctx context.Context, id int, ticket *models.TicketResponse) (*models.TicketResponse, error) {
 	return s.Service.Update(ctx, id, ticket)
 }
+
+// FindByExternalID returns the ticket created with the given external ID, or
+// nil if there is none. Importers set Ticket.ExternalID on create and look it
+// up before creating again, which makes reruns idempotent.
+func (s *TicketService) FindByExternalID(ctx context.Context, externalID string) (*models.Ticket, error) {
+	if externalID == "" {
+		return nil, fmt.Errorf("externalID is required")
+	}
+
+	resp, err := s.List(ctx, externalIDParams(externalID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Tickets {
+		if t := &resp.Tickets[i]; t.ExternalID != nil && *t.ExternalID == externalID {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Delete deletes a ticket
+func (s *TicketService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
+
+// ListDeleted retrieves tickets that have been deleted but can still be
+// restored. Any filter in params is combined with the deleted state.
+func (s *TicketService) ListDeleted(ctx context.Context, params url.Values) (*models.TicketsResponse, error) {
+	params, err := withStateFilter(params, models.StateDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Service.List(ctx, params)
+}
+
+// Restore brings back a deleted ticket
+func (s *TicketService) Restore(ctx context.Context, id int) (*models.TicketResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	body := map[string]map[string]models.State{"ticket": {"state": models.StateActive}}
+
+	var resp models.TicketResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("tickets/%d.json", id), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SetPortalVisibility shows or hides a ticket, and with it all of its
+// messages, in the customer portal
+func (s *TicketService) SetPortalVisibility(ctx context.Context, ticketID int, visible bool) (*models.TicketResponse, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	body := map[string]map[string]bool{"ticket": {"visibleInPortal": visible}}
+
+	var resp models.TicketResponse
+	if err := s.request(ctx, http.MethodPatch, fmt.Sprintf("tickets/%d.json", ticketID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Assign assigns a ticket to an agent
+func (s *TicketService) Assign(ctx context.Context, id, agentID int) (*models.TicketResponse, error) {
+	if agentID <= 0 {
+		return nil, fmt.Errorf("agentID must be greater than 0")
+	}
+
+	return s.Patch(ctx, id, map[string]*models.EntityRef{"agent": {ID: agentID, Type: "users"}})
+}
+
+// Unassign removes the agent from a ticket
+func (s *TicketService) Unassign(ctx context.Context, id int) (*models.TicketResponse, error) {
+	return s.Patch(ctx, id, map[string]*models.EntityRef{"agent": nil})
+}
+
+// SetStatus moves a ticket to the status with the given ID
+func (s *TicketService) SetStatus(ctx context.Context, id, statusID int) (*models.TicketResponse, error) {
+	if statusID <= 0 {
+		return nil, fmt.Errorf("statusID must be greater than 0")
+	}
+
+	return s.Patch(ctx, id, map[string]*models.EntityRef{"status": {ID: statusID, Type: "ticketstatuses"}})
+}
+
+// SetStatusByCode moves a ticket to the status with the given code, such as
+// "solved". Looking up the code costs an extra request.
+func (s *TicketService) SetStatusByCode(ctx context.Context, id int, code string) (*models.TicketResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	status, err := s.client.TicketStatuses.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SetStatus(ctx, id, status.ID)
+}
+
+// Snooze hides a ticket from the inbox until the given time
+func (s *TicketService) Snooze(ctx context.Context, id int, until time.Time) (*models.TicketResponse, error) {
+	if !until.After(time.Now()) {
+		return nil, fmt.Errorf("until must be in the future")
+	}
+
+	return s.Patch(ctx, id, map[string]time.Time{"snoozedUntil": until.UTC()})
+}
+
+// AddTags adds tags to a ticket, keeping the tags it already has
+func (s *TicketService) AddTags(ctx context.Context, id int, tagIDs ...int) (*models.Ticket, error) {
+	tags, err := tagRefs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateOne(ctx, id, models.TicketBulkChanges{AddTags: tags})
+}
+
+// RemoveTags removes tags from a ticket, keeping its other tags. Tags the
+// ticket does not have are ignored.
+func (s *TicketService) RemoveTags(ctx context.Context, id int, tagIDs ...int) (*models.Ticket, error) {
+	tags, err := tagRefs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateOne(ctx, id, models.TicketBulkChanges{RemoveTags: tags})
+}
+
+// updateOne applies incremental changes to a single ticket through the bulk
+// endpoint, which is the only one that can add or remove tags without
+// resending the full set
+func (s *TicketService) updateOne(ctx context.Context, id int, changes models.TicketBulkChanges) (*models.Ticket, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("id must be greater than 0")
+	}
+
+	updated, err := s.BulkUpdate(ctx, []int{id}, changes)
+	if err != nil {
+		return nil, err
+	}
+	if len(updated) == 0 {
+		return nil, fmt.Errorf("ticket %d was not returned by the update", id)
+	}
+
+	return &updated[0], nil
+}
+
+func tagRefs(tagIDs []int) ([]models.EntityRef, error) {
+	if len(tagIDs) == 0 {
+		return nil, fmt.Errorf("tagIDs is required")
+	}
+
+	tags := make([]models.EntityRef, len(tagIDs))
+	for i, id := range tagIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("tagIDs[%d] must be greater than 0", i)
+		}
+		tags[i] = models.EntityRef{ID: id, Type: "tags"}
+	}
+	return tags, nil
+}
+
+// Merge merges duplicate tickets into primaryID. The duplicates' messages,
+// notes and participants move to the primary ticket and the duplicates are
+// closed as merged. The merged ticket is returned.
+func (s *TicketService) Merge(ctx context.Context, primaryID int, duplicateIDs []int) (*models.TicketResponse, error) {
+	if err := validateMergeIDs(primaryID, duplicateIDs); err != nil {
+		return nil, err
+	}
+
+	body := map[string][]int{"ids": duplicateIDs}
+
+	var resp models.TicketResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("tickets/%d/merge.json", primaryID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// SplitMessage moves a message off a ticket into a new ticket for the same
+// customer, for when a customer raises an unrelated issue in an existing
+// conversation. The new ticket is returned.
+func (s *TicketService) SplitMessage(ctx context.Context, ticketID, messageID int) (*models.TicketResponse, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	if messageID <= 0 {
+		return nil, fmt.Errorf("messageID must be greater than 0")
+	}
+
+	var resp models.TicketResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("tickets/%d/messages/%d/split.json", ticketID, messageID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ForwardRequest is a ticket forward. See TicketService.Forward.
+type ForwardRequest struct {
+	// To are the addresses the conversation is forwarded to
+	To []string
+	// Body is the covering message as HTML
+	Body string
+	ReplyOptions
+}
+
+// Forward sends a ticket's conversation to addresses outside the ticket. The
+// customer does not receive it. It is the same as MessageService.Forward.
+func (s *TicketService) Forward(ctx context.Context, ticketID int, req ForwardRequest) (*models.MessageResponse, error) {
+	return s.client.Messages.Forward(ctx, ticketID, req.To, req.Body, req.ReplyOptions)
+}
+
+// Participants retrieves the CC and BCC recipients of a ticket thread
+func (s *TicketService) Participants(ctx context.Context, ticketID int) (*models.TicketParticipants, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	var resp models.TicketParticipantsResponse
+	if err := s.request(ctx, http.MethodGet, fmt.Sprintf("tickets/%d/participants.json", ticketID), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Participants, nil
+}
+
+// SetCC replaces the CC and BCC recipients of a ticket thread. Passing nil for
+// either list clears it.
+func (s *TicketService) SetCC(ctx context.Context, ticketID int, cc, bcc []string) (*models.TicketParticipants, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	if cc == nil {
+		cc = []string{}
+	}
+	if bcc == nil {
+		bcc = []string{}
+	}
+
+	body := models.TicketParticipantsResponse{
+		Participants: models.TicketParticipants{CC: cc, BCC: bcc},
+	}
+
+	var resp models.TicketParticipantsResponse
+	if err := s.request(ctx, http.MethodPut, fmt.Sprintf("tickets/%d/participants.json", ticketID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Participants, nil
+}
+
+// AddParticipant adds a single CC or BCC recipient to a ticket thread without
+// affecting the existing recipients
+func (s *TicketService) AddParticipant(ctx context.Context, ticketID int, email string, participantType models.ParticipantType) (*models.TicketParticipants, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+
+	if participantType == "" {
+		participantType = models.ParticipantTypeCC
+	}
+
+	body := map[string]models.TicketParticipant{
+		"participant": {Email: email, Type: participantType},
+	}
+
+	var resp models.TicketParticipantsResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("tickets/%d/participants.json", ticketID), body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Participants, nil
+}
+
+// RemoveParticipant removes a CC or BCC recipient from a ticket thread
+func (s *TicketService) RemoveParticipant(ctx context.Context, ticketID int, email string) error {
+	if ticketID <= 0 {
+		return fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+
+	params := url.Values{}
+	params.Set("email", email)
+
+	return s.request(ctx, http.MethodDelete, fmt.Sprintf("tickets/%d/participants.json?%s", ticketID, params.Encode()), nil, nil)
+}
+
+// Timeline merges a ticket's messages, notes and activity log (status
+// changes, SLA events and everything else the activity log records) into a
+// single list ordered by time
+func (s *TicketService) Timeline(ctx context.Context, ticketID int) (*models.Timeline, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	activities, err := s.client.Activities.ListByTicket(ctx, ticketID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &models.Timeline{TicketID: ticketID}
+	for i := range activities {
+		a := &activities[i]
+		timeline.Events = append(timeline.Events, models.TimelineEvent{
+			Type:     activityEventType(a),
+			At:       eventTime(a.BaseEntity),
+			Activity: a,
+		})
+	}
+
+	messages, err := s.client.Messages.Thread(ctx, ticketID, true)
+	if err != nil {
+		return nil, fmt.Errorf("list messages: %w", err)
+	}
+
+	for i := range messages {
+		m := &messages[i]
+		eventType := models.TimelineEventTypeMessage
+		if m.IsNote() {
+			eventType = models.TimelineEventTypeNote
+		}
+		timeline.Events = append(timeline.Events, models.TimelineEvent{
+			Type:    eventType,
+			At:      eventTime(m.BaseEntity),
+			Message: m,
+		})
+	}
+
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		return timeline.Events[i].At.Before(timeline.Events[j].At)
+	})
+
+	return timeline, nil
+}
+
+// activityEventType classifies an activity log entry. The activity log does
+// not have a fixed set of event types, so status and SLA events are picked
+// out by name.
+func activityEventType(a *models.TicketActivity) models.TimelineEventType {
+	var eventType string
+	if a.EventType != nil {
+		eventType = strings.ToLower(*a.EventType)
+	}
+
+	switch {
+	case strings.Contains(eventType, "sla"):
+		return models.TimelineEventTypeSLA
+	case strings.Contains(eventType, "status"):
+		return models.TimelineEventTypeStatusChange
+	default:
+		return models.TimelineEventTypeActivity
+	}
+}
+
+func eventTime(e models.BaseEntity) time.Time {
+	if e.CreatedAt != nil {
+		return *e.CreatedAt
+	}
+	return time.Time{}
+}
+
+// ticketBulkLimit is the most tickets the bulk endpoints accept per request
+const ticketBulkLimit = 100
+
+// BulkUpdate applies the same changes to every ticket in ids, such as a
+// status change or adding a tag, using the bulk endpoint rather than one
+// update per ticket. Large lists are sent in chunks of 100; an error stops
+// the remaining chunks, so the tickets in earlier chunks stay updated. The
+// updated tickets are returned.
+func (s *TicketService) BulkUpdate(ctx context.Context, ids []int, changes models.TicketBulkChanges) ([]models.Ticket, error) {
+	if err := validateBulkIDs(ids); err != nil {
+		return nil, err
+	}
+
+	var updated []models.Ticket
+	for chunk := range slices.Chunk(ids, ticketBulkLimit) {
+		body := struct {
+			IDs    []int                    `json:"ids"`
+			Ticket models.TicketBulkChanges `json:"ticket"`
+		}{IDs: chunk, Ticket: changes}
+
+		var resp models.TicketsResponse
+		if err := s.request(ctx, http.MethodPatch, "tickets/bulk.json", body, &resp); err != nil {
+			return updated, err
+		}
+		updated = append(updated, resp.Tickets...)
+	}
+
+	return updated, nil
+}
+
+// BulkDelete deletes every ticket in ids using the bulk endpoint. Large lists
+// are sent in chunks of 100; an error stops the remaining chunks. Deleted
+// tickets can be brought back with Restore.
+func (s *TicketService) BulkDelete(ctx context.Context, ids []int) error {
+	if err := validateBulkIDs(ids); err != nil {
+		return err
+	}
+
+	for chunk := range slices.Chunk(ids, ticketBulkLimit) {
+		body := map[string][]int{"ids": chunk}
+		if err := s.request(ctx, http.MethodDelete, "tickets/bulk.json", body, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBulkIDs(ids []int) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("ids is required")
+	}
+	for _, id := range ids {
+		if id <= 0 {
+			return fmt.Errorf("ids must be greater than 0")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,524 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTicketServiceSetCC(t *testing.T) {
+	cc := []string{gofakeit.Email(), gofakeit.Email()}
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPut, "/tickets/42/participants.json", http.StatusOK, models.TicketParticipantsResponse{
+		Participants: models.TicketParticipants{CC: cc, BCC: []string{}},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	participants, err := c.Tickets.SetCC(context.Background(), 42, cc, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(participants.CC) != 2 {
+		t.Fatalf("expected 2 CC recipients, got %d", len(participants.CC))
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	b, err := io.ReadAll(requests[0].Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+
+	var sent models.TicketParticipantsResponse
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+
+	if sent.Participants.BCC == nil || len(sent.Participants.BCC) != 0 {
+		t.Errorf("expected BCC to be sent as an empty list, got %v", sent.Participants.BCC)
+	}
+}
+
+func TestTicketServiceAddParticipantValidation(t *testing.T) {
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: NewMockRoundTripper()}))
+
+	if _, err := c.Tickets.AddParticipant(context.Background(), 0, gofakeit.Email(), models.ParticipantTypeCC); err == nil {
+		t.Error("expected error when ticket ID is missing")
+	}
+
+	if _, err := c.Tickets.AddParticipant(context.Background(), 1, "", models.ParticipantTypeCC); err == nil {
+		t.Error("expected error when email is missing")
+	}
+}
+
+func TestTicketServiceTimeline(t *testing.T) {
+	base := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		v := base.Add(time.Duration(minutes) * time.Minute)
+		return &v
+	}
+
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/9.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 9}},
+		Included: models.IncludedData{
+			Ticketactivities: []models.TicketActivity{
+				{BaseEntity: models.BaseEntity{ID: 1, CreatedAt: at(30)}, EventType: ptr("statusChanged"), Ticket: models.EntityRef{ID: 9}},
+				{BaseEntity: models.BaseEntity{ID: 2, CreatedAt: at(90)}, EventType: ptr("slaBreached"), Ticket: models.EntityRef{ID: 9}},
+			},
+		},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/tickets/9/messages.json", http.StatusOK, models.MessagesResponse{
+		Messages: []models.Message{
+			{BaseEntity: models.BaseEntity{ID: 11, CreatedAt: at(60)}, ThreadType: ptr(models.ThreadTypeNote)},
+			{BaseEntity: models.BaseEntity{ID: 10, CreatedAt: at(0)}, ThreadType: ptr(models.ThreadTypeMessage)},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	timeline, err := c.Tickets.Timeline(context.Background(), 9)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []models.TimelineEventType{
+		models.TimelineEventTypeMessage,
+		models.TimelineEventTypeStatusChange,
+		models.TimelineEventTypeNote,
+		models.TimelineEventTypeSLA,
+	}
+	if len(timeline.Events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(timeline.Events))
+	}
+	for i, e := range timeline.Events {
+		if e.Type != want[i] {
+			t.Errorf("event %d: expected type %q, got %q", i, want[i], e.Type)
+		}
+	}
+
+	if got := mockTransport.GetRequests()[0].URL.Query().Get("includes"); got != "activities" {
+		t.Errorf("expected activities to be included, got %q", got)
+	}
+}
+
+func TestTicketServiceFindByExternalID(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 3}, ExternalID: ptr("zendesk-100")},
+			{BaseEntity: models.BaseEntity{ID: 4}, ExternalID: ptr("zendesk-1001")},
+		},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	ticket, err := c.Tickets.FindByExternalID(context.Background(), "zendesk-1001")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if ticket == nil || ticket.ID != 4 {
+		t.Fatalf("expected ticket 4, got %+v", ticket)
+	}
+
+	filter := mockTransport.GetRequests()[0].URL.Query().Get("filter")
+	if filter != `{"externalId":{"$eq":"zendesk-1001"}}` {
+		t.Errorf("unexpected filter %s", filter)
+	}
+}
+
+func TestTicketServicePatch(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/42.json", http.StatusOK, `{"ticket":{"id":42,"subject":"Unchanged"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	resp, err := c.Tickets.Patch(context.Background(), 42, map[string]any{"status": map[string]int{"id": 3}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.Ticket.ID != 42 {
+		t.Errorf("expected the updated ticket, got %+v", resp.Ticket)
+	}
+
+	sparse := struct {
+		Subject  string `json:"subject,omitempty"`
+		Priority string `json:"priority,omitempty"`
+	}{Subject: "Renamed"}
+	if _, err := c.Tickets.Patch(context.Background(), 42, sparse); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	for i, want := range []string{`{"ticket":{"status":{"id":3}}}`, `{"ticket":{"subject":"Renamed"}}`} {
+		body, err := io.ReadAll(requests[i].Body)
+		if err != nil {
+			t.Fatalf("failed to read request body: %v", err)
+		}
+		if string(body) != want {
+			t.Errorf("expected body %s, got %s", want, body)
+		}
+	}
+}
+
+func TestTicketServiceGetIncludes(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/1.json", http.StatusOK, models.TicketResponse{})
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, models.TicketsResponse{})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	if _, err := c.Tickets.Get(ctx, 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.Tickets.Get(ctx, 1, (&GetOptions{Includes: []string{"customers", "tags"}}).Values()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.Tickets.Get(ctx, 1, (&GetOptions{Includes: []string{}}).Values()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := c.Tickets.List(ctx, (&ListOptions{Includes: []string{"users"}}).Values()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"all", "customers,tags", "", "users"}
+	for i, req := range mockTransport.GetRequests() {
+		if got := req.URL.Query().Get("includes"); got != want[i] {
+			t.Errorf("request %d: expected includes %q, got %q", i, want[i], got)
+		}
+	}
+}
+
+func TestTicketServiceBulkUpdate(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/bulk.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{{BaseEntity: models.BaseEntity{ID: 1}}},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	ids := make([]int, 150)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	updated, err := c.Tickets.BulkUpdate(context.Background(), ids, models.TicketBulkChanges{
+		Status:  &models.EntityRef{ID: 3},
+		AddTags: []models.EntityRef{{ID: 9}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(updated) != 2 {
+		t.Errorf("expected the tickets from both chunks, got %d", len(updated))
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 chunked requests, got %d", len(requests))
+	}
+
+	var body struct {
+		IDs    []int                    `json:"ids"`
+		Ticket models.TicketBulkChanges `json:"ticket"`
+	}
+	b, _ := io.ReadAll(requests[1].Body)
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(body.IDs) != 50 || body.IDs[0] != 101 {
+		t.Errorf("expected the second chunk to hold tickets 101 to 150, got %v", body.IDs)
+	}
+	if body.Ticket.Status == nil || body.Ticket.Status.ID != 3 || len(body.Ticket.AddTags) != 1 {
+		t.Errorf("expected the changes in every chunk, got %+v", body.Ticket)
+	}
+
+	if _, err := c.Tickets.BulkUpdate(context.Background(), []int{1, 0}, models.TicketBulkChanges{}); err == nil {
+		t.Error("expected an error for an invalid ID")
+	}
+}
+
+func TestTicketServiceBulkDelete(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodDelete, "/tickets/bulk.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	if err := c.Tickets.BulkDelete(context.Background(), []int{4, 5}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	b, _ := io.ReadAll(requests[0].Body)
+	if string(b) != `{"ids":[4,5]}` {
+		t.Errorf("unexpected body %s", b)
+	}
+
+	if err := c.Tickets.BulkDelete(context.Background(), nil); err == nil {
+		t.Error("expected an error for no IDs")
+	}
+}
+
+func TestTicketServiceAssignmentAndStatus(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/3.json", http.StatusOK, `{"ticket":{"id":3}}`)
+	mockTransport.AddResponse(http.MethodGet, "/ticketstatuses.json", http.StatusOK, `{"ticketstatuses":[{"id":1,"code":"active"},{"id":4,"code":"solved"}]}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	if _, err := c.Tickets.Assign(ctx, 3, 7); err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+	if _, err := c.Tickets.Unassign(ctx, 3); err != nil {
+		t.Fatalf("Unassign: %v", err)
+	}
+	if _, err := c.Tickets.SetStatusByCode(ctx, 3, "solved"); err != nil {
+		t.Fatalf("SetStatusByCode: %v", err)
+	}
+	if _, err := c.Tickets.SetStatusByCode(ctx, 3, "archived"); err == nil {
+		t.Error("expected an error for an unknown status code")
+	}
+	if _, err := c.Tickets.Snooze(ctx, 3, time.Now().Add(-time.Hour)); err == nil {
+		t.Error("expected an error snoozing into the past")
+	}
+
+	var bodies []string
+	for _, req := range mockTransport.GetRequests() {
+		if req.Method != http.MethodPatch {
+			continue
+		}
+		b, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(b))
+	}
+	want := []string{
+		`{"ticket":{"agent":{"id":7,"type":"users","meta":null}}}`,
+		`{"ticket":{"agent":null}}`,
+		`{"ticket":{"status":{"id":4,"type":"ticketstatuses","meta":null}}}`,
+	}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Errorf("expected patches %v, got %v", want, bodies)
+	}
+}
+
+func TestTicketServiceMerge(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/10/merge.json", http.StatusOK, `{"ticket":{"id":10}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	merged, err := c.Tickets.Merge(context.Background(), 10, []int{11, 12})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if merged.Ticket.ID != 10 {
+		t.Errorf("expected the primary ticket, got %d", merged.Ticket.ID)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	if want := `{"ids":[11,12]}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+
+	for name, call := range map[string]struct {
+		primary    int
+		duplicates []int
+	}{
+		"no primary":        {0, []int{11}},
+		"no duplicates":     {10, nil},
+		"invalid duplicate": {10, []int{-1}},
+		"primary in list":   {10, []int{11, 10}},
+		"repeated":          {10, []int{11, 11}},
+	} {
+		if _, err := c.Tickets.Merge(context.Background(), call.primary, call.duplicates); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 1 {
+		t.Errorf("expected rejected merges not to send requests, got %d requests", n)
+	}
+}
+
+func TestTicketServiceSplitMessage(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/10/messages/55/split.json", http.StatusCreated, `{"ticket":{"id":99}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	split, err := c.Tickets.SplitMessage(context.Background(), 10, 55)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if split.Ticket.ID != 99 {
+		t.Errorf("expected the new ticket, got %d", split.Ticket.ID)
+	}
+	if req := mockTransport.GetRequests()[0]; req.ContentLength > 0 {
+		t.Errorf("expected no body, got %d bytes", req.ContentLength)
+	}
+
+	if _, err := c.Tickets.SplitMessage(context.Background(), 0, 55); err == nil {
+		t.Error("expected an error for ticketID 0")
+	}
+	if _, err := c.Tickets.SplitMessage(context.Background(), 10, 0); err == nil {
+		t.Error("expected an error for messageID 0")
+	}
+}
+
+func TestTicketServiceForward(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/tickets/10/forward.json", http.StatusCreated, `{"message":{"id":7}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	msg, err := c.Tickets.Forward(context.Background(), 10, ForwardRequest{
+		To:           []string{"billing@example.com"},
+		Body:         "<p>Can you take this one?</p>",
+		ReplyOptions: ReplyOptions{CC: []string{"lead@example.com"}, FileIDs: []int{3}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg.Message.ID != 7 {
+		t.Errorf("expected message 7, got %d", msg.Message.ID)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	// Decoded without models.Message, which reads the body from htmlBody
+	var sent struct {
+		Message struct {
+			To      []string           `json:"to"`
+			CC      []string           `json:"cc"`
+			Message string             `json:"message"`
+			Files   []models.EntityRef `json:"files"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if !reflect.DeepEqual(sent.Message.To, []string{"billing@example.com"}) ||
+		!reflect.DeepEqual(sent.Message.CC, []string{"lead@example.com"}) ||
+		sent.Message.Message != "<p>Can you take this one?</p>" ||
+		len(sent.Message.Files) != 1 || sent.Message.Files[0].ID != 3 {
+		t.Errorf("unexpected forward body %s", b)
+	}
+
+	if _, err := c.Tickets.Forward(context.Background(), 10, ForwardRequest{Body: "hi"}); err == nil {
+		t.Error("expected an error without recipients")
+	}
+	if _, err := c.Tickets.Forward(context.Background(), 0, ForwardRequest{To: []string{"a@example.com"}}); err == nil {
+		t.Error("expected an error for ticketID 0")
+	}
+}
+
+func TestTicketServiceTags(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/bulk.json", http.StatusOK, `{"tickets":[{"id":42}]}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	ticket, err := c.Tickets.AddTags(ctx, 42, 7, 8)
+	if err != nil {
+		t.Fatalf("AddTags() returned error: %v", err)
+	}
+	if ticket.ID != 42 {
+		t.Errorf("expected ticket 42, got %d", ticket.ID)
+	}
+	if _, err := c.Tickets.RemoveTags(ctx, 42, 8); err != nil {
+		t.Fatalf("RemoveTags() returned error: %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for i, want := range []string{
+		`{"ids":[42],"ticket":{"addTags":[{"id":7,"type":"tags","meta":null},{"id":8,"type":"tags","meta":null}]}}`,
+		`{"ids":[42],"ticket":{"removeTags":[{"id":8,"type":"tags","meta":null}]}}`,
+	} {
+		b, _ := io.ReadAll(requests[i].Body)
+		if string(b) != want {
+			t.Errorf("request %d: expected body %s, got %s", i, want, b)
+		}
+	}
+
+	for name, call := range map[string]func() error{
+		"no ticket":   func() error { _, err := c.Tickets.AddTags(ctx, 0, 7); return err },
+		"no tags":     func() error { _, err := c.Tickets.AddTags(ctx, 42); return err },
+		"invalid tag": func() error { _, err := c.Tickets.RemoveTags(ctx, 42, 7, -1); return err },
+	} {
+		if call() == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 2 {
+		t.Errorf("expected invalid calls not to send requests, got %d requests", n)
+	}
+}
+
+func TestTicketServiceTagsNotReturned(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/bulk.json", http.StatusOK, `{"tickets":[]}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	_, err := c.Tickets.AddTags(context.Background(), 42, 7)
+	if err == nil || !strings.Contains(err.Error(), "ticket 42 was not returned") {
+		t.Errorf("expected a not returned error, got %v", err)
+	}
+}
+
+func TestTicketServiceListDeletedAndRestore(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, `{"tickets":[{"id":42,"state":"deleted"}]}`)
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/42.json", http.StatusOK, `{"ticket":{"id":42,"state":"active"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	deleted, err := c.Tickets.ListDeleted(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListDeleted() returned error: %v", err)
+	}
+	if len(deleted.Tickets) != 1 || deleted.Tickets[0].ID != 42 {
+		t.Errorf("expected deleted ticket 42, got %+v", deleted.Tickets)
+	}
+
+	if _, err := c.Tickets.Restore(ctx, 42); err != nil {
+		t.Fatalf("Restore() returned error: %v", err)
+	}
+	if _, err := c.Tickets.Restore(ctx, 0); err == nil {
+		t.Error("expected an error for id 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if want := `{"state":{"$eq":"deleted"}}`; requests[0].URL.Query().Get("filter") != want {
+		t.Errorf("expected filter %s, got %s", want, requests[0].URL.Query().Get("filter"))
+	}
+	b, _ := io.ReadAll(requests[1].Body)
+	if want := `{"ticket":{"state":"active"}}`; string(b) != want {
+		t.Errorf("expected body %s, got %s", want, b)
+	}
+}
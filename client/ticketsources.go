@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"sort"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -10,12 +12,14 @@ import (
 // TicketSourceService handles ticket-related operations
 type TicketSourceService struct {
 	*Service[models.TicketSourceResponse, models.TicketSourcesResponse]
+	client *Client
 }
 
 // NewTicketSourceService creates a new ticket service
 func NewTicketSourceService(client *Client) *TicketSourceService {
 	return &TicketSourceService{
 		Service: NewService[models.TicketSourceResponse, models.TicketSourcesResponse](client, NewDefaultPathHandler("ticketsources")),
+		client:  client,
 	}
 }
 
@@ -38,3 +42,47 @@ func (s *TicketSourceService) Create(ctx context.Context, ticketsource *models.T
 func (s *TicketSourceService) Update(ctx context.Context, id int, ticketsource *models.TicketSourceResponse) (*models.TicketSourceResponse, error) {
 	return s.Service.Update(ctx, id, ticketsource)
 }
+
+// TicketCounts counts the tickets matching filter for every ticket source,
+// busiest source first. Each count is a single one-row search, so a channel
+// mix report costs one request per source instead of exporting every ticket.
+// Any Sources set on filter are ignored.
+func (s *TicketSourceService) TicketCounts(ctx context.Context, filter *models.SearchTicketsFilter) ([]models.TicketSourceCount, error) {
+	var sources []models.TicketSource
+	err := s.ListAllFunc(ctx, nil, func(page *models.TicketSourcesResponse) error {
+		sources = append(sources, page.TicketSources...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f := models.SearchTicketsFilter{}
+	if filter != nil {
+		f = *filter
+	}
+
+	var total int
+	counts := make([]models.TicketSourceCount, 0, len(sources))
+	for _, src := range sources {
+		f.Sources = []int64{int64(src.ID)}
+		n, err := s.client.Tickets.Count(ctx, &f)
+		if err != nil {
+			return nil, fmt.Errorf("count tickets for source %d: %w", src.ID, err)
+		}
+		counts = append(counts, models.TicketSourceCount{Source: src, Count: n})
+		total += n
+	}
+
+	if total > 0 {
+		for i := range counts {
+			counts[i].Share = float64(counts[i].Count) / float64(total)
+		}
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].Count > counts[j].Count
+	})
+
+	return counts, nil
+}
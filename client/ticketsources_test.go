@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTicketSourceServiceTicketCounts(t *testing.T) {
+	counts := map[string]int{"1": 30, "2": 0, "3": 10}
+
+	var searches []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ticketsources.json":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			sources := map[int][]models.TicketSource{
+				1: {{BaseEntity: models.BaseEntity{ID: 1}}, {BaseEntity: models.BaseEntity{ID: 2}}},
+				2: {{BaseEntity: models.BaseEntity{ID: 3}}},
+			}
+			_ = json.NewEncoder(w).Encode(models.TicketSourcesResponse{
+				TicketSources: sources[page],
+				Pagination:    models.Pagination{Page: page, Pages: 2, HasMorePages: page < 2},
+			})
+		case "/search/tickets.json":
+			searches = append(searches, r.URL.RawQuery)
+			var source string
+			for k, v := range r.URL.Query() {
+				if strings.HasPrefix(k, "sources") {
+					source = v[0]
+				}
+			}
+			_ = json.NewEncoder(w).Encode(models.TicketsResponse{Pagination: models.Pagination{Records: counts[source]}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	got, err := c.TicketSources.TicketCounts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected a count for each of the 3 sources, got %+v", got)
+	}
+	for i, want := range []struct {
+		id, count int
+		share     float64
+	}{{1, 30, 0.75}, {3, 10, 0.25}, {2, 0, 0}} {
+		if got[i].Source.ID != want.id || got[i].Count != want.count || got[i].Share != want.share {
+			t.Errorf("position %d: expected source %d with %d tickets (%.2f), got source %d with %d (%.2f)",
+				i, want.id, want.count, want.share, got[i].Source.ID, got[i].Count, got[i].Share)
+		}
+	}
+
+	if len(searches) != 3 {
+		t.Errorf("expected one search per source, got %d", len(searches))
+	}
+	for _, q := range searches {
+		if !strings.Contains(q, "pageSize=1") {
+			t.Errorf("expected one-row searches, got %s", q)
+		}
+	}
+}
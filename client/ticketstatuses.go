@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/teamwork/desksdkgo/models"
 )
@@ -38,3 +40,117 @@ func (s *TicketStatusService) Create(ctx context.Context, ticketstatus *models.T
 func (s *TicketStatusService) Update(ctx context.Context, id int, ticketstatus *models.TicketStatusResponse) (*models.TicketStatusResponse, error) {
 	return s.Service.Update(ctx, id, ticketstatus)
 }
+
+// DefaultTicketStatuses returns the canonical set of ticket statuses a new
+// installation starts with, in display order
+func DefaultTicketStatuses() []models.TicketStatus {
+	status := func(code, name, color, icon string, order int) models.TicketStatus {
+		return models.TicketStatus{
+			Code:         &code,
+			Name:         &name,
+			Color:        &color,
+			Icon:         &icon,
+			DisplayOrder: &order,
+		}
+	}
+
+	return []models.TicketStatus{
+		status("active", "Active", "#4461d7", "inbox", 0),
+		status("waiting", "Waiting on customer", "#f5a623", "clock", 1),
+		status("onhold", "On hold", "#9b9b9b", "pause", 2),
+		status("solved", "Solved", "#4caf50", "check", 3),
+		status("closed", "Closed", "#5c5c5c", "lock", 4),
+	}
+}
+
+// EnsureDefaults creates any of DefaultTicketStatuses that are missing, and
+// fills in the color and icon of existing ones that have none. Statuses are
+// matched by code, falling back to a case-insensitive name match. Existing
+// colors, icons and names are never overwritten. The resulting default
+// statuses are returned in display order.
+func (s *TicketStatusService) EnsureDefaults(ctx context.Context) ([]models.TicketStatus, error) {
+	var existing []models.TicketStatus
+	err := s.ListAllFunc(ctx, nil, func(page *models.TicketStatusesResponse) error {
+		existing = append(existing, page.TicketStatuses...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list ticket statuses: %w", err)
+	}
+
+	defaults := DefaultTicketStatuses()
+	statuses := make([]models.TicketStatus, 0, len(defaults))
+	for _, d := range defaults {
+		current := findTicketStatus(existing, d)
+		if current == nil {
+			resp, err := s.Create(ctx, &models.TicketStatusResponse{TicketStatus: d})
+			if err != nil {
+				return statuses, fmt.Errorf("create ticket status %q: %w", *d.Code, err)
+			}
+			statuses = append(statuses, resp.TicketStatus)
+			continue
+		}
+
+		var update models.TicketStatus
+		if current.Color == nil || *current.Color == "" {
+			update.Color = d.Color
+		}
+		if current.Icon == nil || *current.Icon == "" {
+			update.Icon = d.Icon
+		}
+		if update.Color == nil && update.Icon == nil {
+			statuses = append(statuses, *current)
+			continue
+		}
+
+		resp, err := s.Update(ctx, current.ID, &models.TicketStatusResponse{TicketStatus: update})
+		if err != nil {
+			return statuses, fmt.Errorf("update ticket status %d: %w", current.ID, err)
+		}
+		statuses = append(statuses, resp.TicketStatus)
+	}
+
+	return statuses, nil
+}
+
+// FindByCode returns the ticket status with the given code, such as "solved"
+func (s *TicketStatusService) FindByCode(ctx context.Context, code string) (*models.TicketStatus, error) {
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+
+	var found *models.TicketStatus
+	err := s.ListAllFunc(ctx, nil, func(page *models.TicketStatusesResponse) error {
+		for i := range page.TicketStatuses {
+			if status := &page.TicketStatuses[i]; status.Code != nil && *status.Code == code {
+				found = status
+				return ErrStopPaging
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list ticket statuses: %w", err)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no ticket status with code %q", code)
+	}
+
+	return found, nil
+}
+
+func findTicketStatus(statuses []models.TicketStatus, want models.TicketStatus) *models.TicketStatus {
+	for i := range statuses {
+		if s := &statuses[i]; s.Code != nil && *s.Code == *want.Code {
+			return s
+		}
+	}
+
+	for i := range statuses {
+		if s := &statuses[i]; s.Name != nil && strings.EqualFold(*s.Name, *want.Name) {
+			return s
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTicketStatusServiceEnsureDefaults(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/ticketstatuses.json", http.StatusOK, models.TicketStatusesResponse{
+		TicketStatuses: []models.TicketStatus{
+			{BaseEntity: models.BaseEntity{ID: 1}, Code: ptr("active"), Name: ptr("Open"), Color: ptr("#000000"), Icon: ptr("star")},
+			{BaseEntity: models.BaseEntity{ID: 2}, Name: ptr("solved")},
+			{BaseEntity: models.BaseEntity{ID: 3}, Code: ptr("waiting"), Name: ptr("Waiting on customer"), Color: ptr("#f5a623"), Icon: ptr("clock")},
+			{BaseEntity: models.BaseEntity{ID: 4}, Code: ptr("onhold"), Name: ptr("On hold"), Color: ptr("#9b9b9b"), Icon: ptr("pause")},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPut, "/ticketstatuses/2.json", http.StatusOK, models.TicketStatusResponse{
+		TicketStatus: models.TicketStatus{BaseEntity: models.BaseEntity{ID: 2}, Name: ptr("solved"), Color: ptr("#4caf50"), Icon: ptr("check")},
+	})
+	mockTransport.AddResponse(http.MethodPost, "/ticketstatuses.json", http.StatusCreated, models.TicketStatusResponse{
+		TicketStatus: models.TicketStatus{BaseEntity: models.BaseEntity{ID: 5}, Code: ptr("closed"), Name: ptr("Closed")},
+	})
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	statuses, err := c.TicketStatuses.EnsureDefaults(context.Background())
+	if err != nil {
+		t.Fatalf("EnsureDefaults() returned error: %v", err)
+	}
+
+	wantIDs := []int{1, 3, 4, 2, 5}
+	if len(statuses) != len(wantIDs) {
+		t.Fatalf("expected %d statuses, got %d", len(wantIDs), len(statuses))
+	}
+	for i, id := range wantIDs {
+		if statuses[i].ID != id {
+			t.Errorf("status %d: expected ID %d, got %d", i, id, statuses[i].ID)
+		}
+	}
+
+	if *statuses[0].Color != "#000000" {
+		t.Errorf("expected existing color to be kept, got %s", *statuses[0].Color)
+	}
+
+	var methods []string
+	for _, req := range mockTransport.GetRequests() {
+		methods = append(methods, req.Method+" "+req.URL.Path)
+	}
+	want := []string{"GET /ticketstatuses.json", "PUT /ticketstatuses/2.json", "POST /ticketstatuses.json"}
+	if len(methods) != len(want) {
+		t.Fatalf("expected requests %v, got %v", want, methods)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Errorf("request %d: expected %s, got %s", i, want[i], methods[i])
+		}
+	}
+}
+
+func TestTicketStatusServiceFindByCode(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		codes := map[int]string{1: "active", 2: "solved", 3: "closed"}
+		_ = json.NewEncoder(w).Encode(models.TicketStatusesResponse{
+			TicketStatuses: []models.TicketStatus{{BaseEntity: models.BaseEntity{ID: page}, Code: ptr(codes[page])}},
+			Pagination:     models.Pagination{Page: page, Pages: 3, HasMorePages: page < 3},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	status, err := c.TicketStatuses.FindByCode(context.Background(), "solved")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.ID != 2 {
+		t.Errorf("expected status 2, got %d", status.ID)
+	}
+	if requests != 2 {
+		t.Errorf("expected paging to stop once the status was found, got %d requests", requests)
+	}
+
+	requests = 0
+	if _, err := c.TicketStatuses.FindByCode(context.Background(), "spam"); err == nil {
+		t.Error("expected an error for an unknown code")
+	}
+	if requests != 3 {
+		t.Errorf("expected every page to be searched, got %d requests", requests)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// TimeLogService handles time tracked against tickets
+type TimeLogService struct {
+	*Service[models.TimeLogResponse, models.TimeLogsResponse]
+}
+
+// NewTimeLogService creates a new time log service
+func NewTimeLogService(client *Client) *TimeLogService {
+	return &TimeLogService{
+		Service: NewService[models.TimeLogResponse, models.TimeLogsResponse](client, NewDefaultPathHandler("timelogs")),
+	}
+}
+
+// Get retrieves a time log by ID
+func (s *TimeLogService) Get(ctx context.Context, id int, params url.Values) (*models.TimeLogResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of time logs with optional filters
+func (s *TimeLogService) List(ctx context.Context, params url.Values) (*models.TimeLogsResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// ListForTicket retrieves the time logged against a ticket
+func (s *TimeLogService) ListForTicket(ctx context.Context, ticketID int, params url.Values) (*models.TimeLogsResponse, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	path := fmt.Sprintf("tickets/%d/timelogs.json", ticketID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp models.TimeLogsResponse
+	if err := s.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Create logs time against a ticket. The ticket and a positive number of
+// seconds are required. No user is sent unless timeLog.User is set; set
+// AssignToCurrentUser instead to log the time for the API key's user.
+func (s *TimeLogService) Create(ctx context.Context, timeLog *models.TimeLogResponse) (*models.TimeLogResponse, error) {
+	if timeLog == nil {
+		return nil, fmt.Errorf("timeLog is required")
+	}
+	if timeLog.TimeLog.Ticket.ID <= 0 {
+		return nil, fmt.Errorf("timeLog.ticket.id is required")
+	}
+	if timeLog.TimeLog.Seconds == nil || *timeLog.TimeLog.Seconds <= 0 {
+		return nil, fmt.Errorf("timeLog.seconds must be greater than 0")
+	}
+
+	return s.Service.Create(ctx, timeLog)
+}
+
+// CreateBatch creates time logs in parallel, validating each one as Create
+// does. See Service.CreateBatch.
+func (s *TimeLogService) CreateBatch(ctx context.Context, items []*models.TimeLogResponse, opts BatchOptions) ([]BatchResult[models.TimeLogResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing time log
+func (s *TimeLogService) Update(ctx context.Context, id int, timeLog *models.TimeLogResponse) (*models.TimeLogResponse, error) {
+	return s.Service.Update(ctx, id, timeLog)
+}
+
+// Delete deletes a time log
+func (s *TimeLogService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
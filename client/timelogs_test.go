@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTimeLogServiceListForTicket(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42/timelogs.json", http.StatusOK, `{"timeLogs":[{"id":1,"seconds":900}]}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	logs, err := c.TimeLogs.ListForTicket(context.Background(), 42, url.Values{"page": {"3"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(logs.TimeLogs) != 1 || *logs.TimeLogs[0].Seconds != 900 {
+		t.Errorf("unexpected time logs %+v", logs.TimeLogs)
+	}
+	if got := mockTransport.GetRequests()[0].URL.Query().Get("page"); got != "3" {
+		t.Errorf("expected the params to be passed on, got page %q", got)
+	}
+
+	if _, err := c.TimeLogs.ListForTicket(context.Background(), 0, nil); err == nil {
+		t.Error("expected an error for ticketID 0")
+	}
+}
+
+func TestTimeLogServiceCreate(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/timelogs.json", http.StatusCreated, `{"timeLog":{"id":5}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	seconds := 600
+	created, err := c.TimeLogs.Create(context.Background(), &models.TimeLogResponse{
+		TimeLog: models.TimeLog{Ticket: models.EntityRef{ID: 42}, Seconds: &seconds},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if created.TimeLog.ID != 5 {
+		t.Errorf("expected time log 5, got %d", created.TimeLog.ID)
+	}
+
+	b, _ := io.ReadAll(mockTransport.GetRequests()[0].Body)
+	var sent map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if _, ok := sent["timeLog"]["user"]; ok {
+		t.Errorf("expected no user to be sent when none is set, got %s", b)
+	}
+
+	zero := 0
+	for name, timeLog := range map[string]*models.TimeLogResponse{
+		"nil":          nil,
+		"no ticket":    {TimeLog: models.TimeLog{Seconds: &seconds}},
+		"no seconds":   {TimeLog: models.TimeLog{Ticket: models.EntityRef{ID: 42}}},
+		"zero seconds": {TimeLog: models.TimeLog{Ticket: models.EntityRef{ID: 42}, Seconds: &zero}},
+	} {
+		if _, err := c.TimeLogs.Create(context.Background(), timeLog); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if n := len(mockTransport.GetRequests()); n != 1 {
+		t.Errorf("expected invalid time logs not to be sent, got %d requests", n)
+	}
+}
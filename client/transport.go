@@ -3,9 +3,13 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 )
@@ -43,11 +47,20 @@ func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	start := time.Now()
 	resp, err := t.Transport.RoundTrip(req)
 	duration := time.Since(start)
+	if err != nil {
+		t.Logger.LogAttrs(context.Background(), slog.LevelDebug, "HTTP Request failed",
+			slog.String("error", err.Error()),
+			slog.String("duration", duration.String()),
+			requestIDAttr(req),
+		)
+		return nil, err
+	}
 
 	// Log response
 	respAttrs := []slog.Attr{
 		slog.Int("status_code", resp.StatusCode),
 		slog.String("duration", duration.String()),
+		requestIDAttr(req),
 		slog.Any("headers", resp.Header),
 	}
 
@@ -91,3 +104,57 @@ func NewLoggingClientWithLogger(level slog.Level, logger *slog.Logger) *http.Cli
 		Timeout:   time.Second * 30,
 	}
 }
+
+// LoadCABundle returns the system certificate pool with the PEM certificates
+// in path added, for networks where TLS is intercepted by a corporate proxy.
+// Pass the result to WithRootCAs.
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+
+	return pool, nil
+}
+
+// WithRootCAs sets the certificate authorities used to verify the API's TLS
+// certificate. It only applies to the client's default HTTP client and is
+// ignored when WithHTTPClient is used.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		c.rootCAs = pool
+	}
+}
+
+// WithProxy sets how the client picks a proxy for each request, for example
+// http.ProxyURL(u). By default the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables are honored. It only applies to the client's default
+// HTTP client and is ignored when WithHTTPClient is used.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(c *Client) {
+		c.proxy = proxy
+	}
+}
+
+// newTransport returns a copy of http.DefaultTransport using the given proxy
+// and root CAs. Like http.DefaultTransport, it reads the proxy from the
+// environment when proxy is nil.
+func newTransport(proxy func(*http.Request) (*url.URL, error), rootCAs *x509.CertPool) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		t.Proxy = proxy
+	}
+	if rootCAs != nil {
+		t.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+	return t
+}
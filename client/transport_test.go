@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tag":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, cert, 0o600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+
+	if _, err := NewClient(server.URL).Tags.Get(context.Background(), 1, nil); err == nil {
+		t.Fatal("expected the test server's certificate to be rejected without the bundle")
+	}
+
+	pool, err := LoadCABundle(bundle)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tag, err := NewClient(server.URL, WithRootCAs(pool)).Tags.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tag.Tag.ID != 1 {
+		t.Errorf("expected tag 1, got %d", tag.Tag.ID)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+	if _, err := LoadCABundle(empty); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Proxies receive the absolute target URL
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tag":{"id":1}}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	c := NewClient("http://desk.example.invalid/desk/api/v2", WithProxy(http.ProxyURL(proxyURL)))
+
+	if _, err := c.Tags.Get(context.Background(), 1, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if want := "http://desk.example.invalid/desk/api/v2/tags/1.json?includes=all"; proxied != want {
+		t.Errorf("expected the proxy to receive %q, got %q", want, proxied)
+	}
+}
+
+func TestNewTransportUsesEnvironmentProxy(t *testing.T) {
+	tr := newTransport(nil, nil)
+	if tr.Proxy == nil {
+		t.Fatal("expected the transport to read the proxy from the environment")
+	}
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UsageMiddlewareName is the name the usage tracker is registered under
+const UsageMiddlewareName = "usage"
+
+// EndpointUsage is the traffic recorded for one endpoint. Endpoints are
+// grouped by method and path with IDs replaced by {id}, for example
+// "GET tickets/{id}.json".
+type EndpointUsage struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	Requests int    `json:"requests"`
+	Errors   int    `json:"errors"`
+	// RateLimited counts responses with status 429
+	RateLimited int `json:"rateLimited"`
+}
+
+// Usage summarizes the requests a client has made since it was created. Every
+// request counts once against the API key's rate limit, so Requests is also
+// the estimated rate limit consumption. RateLimit and RateLimitRemaining are
+// taken from the most recent response that reported them, and are 0 if none
+// did.
+type Usage struct {
+	Requests           int             `json:"requests"`
+	RateLimit          int             `json:"rateLimit,omitempty"`
+	RateLimitRemaining int             `json:"rateLimitRemaining,omitempty"`
+	Endpoints          []EndpointUsage `json:"endpoints"`
+}
+
+// usageTracker accumulates Usage for a client
+type usageTracker struct {
+	prefix string
+
+	mu        sync.Mutex
+	endpoints map[string]*EndpointUsage
+	limit     int
+	remaining int
+}
+
+// WithUsageTracking records how many requests the client makes to each
+// endpoint. Read the totals with Client.Usage.
+func WithUsageTracking() Option {
+	return func(c *Client) {
+		prefix := ""
+		if u, err := url.Parse(c.baseURL); err == nil {
+			prefix = strings.TrimSuffix(u.Path, "/") + "/"
+		}
+
+		c.usage = &usageTracker{prefix: prefix, endpoints: make(map[string]*EndpointUsage)}
+		c.UseMiddleware(UsageMiddlewareName, 0, c.usage.middleware())
+	}
+}
+
+// Usage returns the requests made so far, busiest endpoint first. It returns
+// nil unless the client was created with WithUsageTracking.
+func (c *Client) Usage() *Usage {
+	if c.usage == nil {
+		return nil
+	}
+	return c.usage.snapshot()
+}
+
+func (u *usageTracker) middleware() MiddlewareFunc {
+	idSegment := regexp.MustCompile(`/\d+(\.json)?(/|$)`)
+
+	return func(ctx context.Context, req *http.Request, next RequestHandler) (*http.Response, error) {
+		endpoint := strings.TrimPrefix(req.URL.Path, u.prefix)
+		for idSegment.MatchString(endpoint) {
+			endpoint = idSegment.ReplaceAllString(endpoint, "/{id}$1$2")
+		}
+
+		resp, err := next(ctx, req)
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		key := req.Method + " " + endpoint
+		e, ok := u.endpoints[key]
+		if !ok {
+			e = &EndpointUsage{Method: req.Method, Endpoint: endpoint}
+			u.endpoints[key] = e
+		}
+		e.Requests++
+
+		switch {
+		case err != nil:
+			e.Errors++
+		case resp.StatusCode == http.StatusTooManyRequests:
+			e.RateLimited++
+			e.Errors++
+		case resp.StatusCode >= http.StatusBadRequest:
+			e.Errors++
+		}
+
+		if resp != nil {
+			if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+				u.limit = v
+			}
+			if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+				u.remaining = v
+			}
+		}
+
+		return resp, err
+	}
+}
+
+func (u *usageTracker) snapshot() *Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := &Usage{RateLimit: u.limit, RateLimitRemaining: u.remaining, Endpoints: []EndpointUsage{}}
+	for _, e := range u.endpoints {
+		usage.Requests += e.Requests
+		usage.Endpoints = append(usage.Endpoints, *e)
+	}
+
+	sort.Slice(usage.Endpoints, func(i, j int) bool {
+		a, b := usage.Endpoints[i], usage.Endpoints[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Method < b.Method
+	})
+
+	return usage
+}
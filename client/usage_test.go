@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestUsage(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/desk/api/v2/tickets/1.json", http.StatusOK, models.TicketResponse{})
+	mockTransport.AddResponse(http.MethodGet, "/desk/api/v2/tickets/2.json", http.StatusOK, models.TicketResponse{})
+	mockTransport.AddResponse(http.MethodGet, "/desk/api/v2/tickets/3/participants.json", http.StatusOK, models.TicketParticipantsResponse{})
+
+	c := NewClient("https://example.com/desk/api/v2",
+		WithHTTPClient(&http.Client{Transport: mockTransport}),
+		WithUsageTracking(),
+	)
+
+	ctx := context.Background()
+	c.Tickets.Get(ctx, 1, nil)
+	c.Tickets.Get(ctx, 2, nil)
+	c.Tickets.Participants(ctx, 3)
+	c.Customers.Get(ctx, 4, nil)
+
+	usage := c.Usage()
+	if usage.Requests != 4 {
+		t.Fatalf("expected 4 requests, got %d", usage.Requests)
+	}
+
+	want := []EndpointUsage{
+		{Method: http.MethodGet, Endpoint: "tickets/{id}.json", Requests: 2},
+		{Method: http.MethodGet, Endpoint: "customers/{id}.json", Requests: 1, Errors: 1},
+		{Method: http.MethodGet, Endpoint: "tickets/{id}/participants.json", Requests: 1},
+	}
+	if len(usage.Endpoints) != len(want) {
+		t.Fatalf("expected %d endpoints, got %+v", len(want), usage.Endpoints)
+	}
+	for i := range want {
+		if usage.Endpoints[i] != want[i] {
+			t.Errorf("endpoint %d: expected %+v, got %+v", i, want[i], usage.Endpoints[i])
+		}
+	}
+
+	if NewClient("https://example.com").Usage() != nil {
+		t.Errorf("expected no usage without WithUsageTracking")
+	}
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/teamwork/desksdkgo/models"
@@ -38,3 +40,64 @@ func (s *UserService) Create(ctx context.Context, user *models.UserResponse) (*m
 func (s *UserService) Update(ctx context.Context, id int, user *models.UserResponse) (*models.UserResponse, error) {
 	return s.Service.Update(ctx, id, user)
 }
+
+// SetRole gives a user a role, changing their permissions to the role's
+func (s *UserService) SetRole(ctx context.Context, userID, roleID int) (*models.UserResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("userID must be greater than 0")
+	}
+	if roleID <= 0 {
+		return nil, fmt.Errorf("roleID must be greater than 0")
+	}
+
+	return s.Patch(ctx, userID, map[string]models.EntityRef{"agentRole": {ID: roleID, Type: "roles"}})
+}
+
+// Invite creates an agent account for email and sends them an invitation to
+// set a password. roleID gives them a role straight away; 0 leaves them on
+// the default role.
+func (s *UserService) Invite(ctx context.Context, email string, roleID int) (*models.UserResponse, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if roleID < 0 {
+		return nil, fmt.Errorf("roleID must not be negative")
+	}
+
+	user := models.User{Email: &email}
+	if roleID > 0 {
+		user.AgentRole = &models.EntityRef{ID: roleID, Type: "roles"}
+	}
+
+	var resp models.UserResponse
+	if err := s.request(ctx, http.MethodPost, "users/invite.json", models.UserResponse{User: user}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Deactivate stops a user from logging in while keeping their tickets,
+// replies and history. Reassign their open tickets first, see the
+// offboarding package.
+func (s *UserService) Deactivate(ctx context.Context, userID int) (*models.UserResponse, error) {
+	return s.setActive(ctx, userID, "deactivate")
+}
+
+// Reactivate lets a deactivated user log in again
+func (s *UserService) Reactivate(ctx context.Context, userID int) (*models.UserResponse, error) {
+	return s.setActive(ctx, userID, "reactivate")
+}
+
+func (s *UserService) setActive(ctx context.Context, userID int, action string) (*models.UserResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("userID must be greater than 0")
+	}
+
+	var resp models.UserResponse
+	if err := s.request(ctx, http.MethodPost, fmt.Sprintf("users/%d/%s.json", userID, action), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUserServiceInvite(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/users/invite.json", http.StatusCreated, `{"user":{"id":9,"email":"jo@example.com"}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	user, err := c.Users.Invite(context.Background(), "jo@example.com", 4)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.User.ID != 9 {
+		t.Errorf("expected user 9, got %d", user.User.ID)
+	}
+
+	if _, err := c.Users.Invite(context.Background(), "sam@example.com", 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	var body struct {
+		User map[string]json.RawMessage `json:"user"`
+	}
+	b, _ := io.ReadAll(requests[0].Body)
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("failed to decode request body %s: %v", b, err)
+	}
+	if got := string(body.User["email"]); got != `"jo@example.com"` {
+		t.Errorf("expected the email to be sent, got %s", got)
+	}
+	if got := string(body.User["agentRole"]); got != `{"id":4,"type":"roles","meta":null}` {
+		t.Errorf("expected role 4 to be sent, got %s", got)
+	}
+
+	body.User = nil
+	b, _ = io.ReadAll(requests[1].Body)
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("failed to decode request body %s: %v", b, err)
+	}
+	if _, ok := body.User["agentRole"]; ok {
+		t.Errorf("expected no role for roleID 0, got %s", b)
+	}
+}
+
+func TestUserServiceInviteValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	if _, err := c.Users.Invite(context.Background(), "", 4); err == nil {
+		t.Error("expected an error for an empty email")
+	}
+	if _, err := c.Users.Invite(context.Background(), "jo@example.com", -1); err == nil {
+		t.Error("expected an error for a negative roleID")
+	}
+	if n := len(mockTransport.GetRequests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+func TestUserServiceSetActive(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/users/9/deactivate.json", http.StatusOK, `{"user":{"id":9}}`)
+	mockTransport.AddResponse(http.MethodPost, "/users/9/reactivate.json", http.StatusOK, `{"user":{"id":9}}`)
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	if _, err := c.Users.Deactivate(ctx, 9); err != nil {
+		t.Fatalf("Deactivate() returned error: %v", err)
+	}
+	if _, err := c.Users.Reactivate(ctx, 9); err != nil {
+		t.Fatalf("Reactivate() returned error: %v", err)
+	}
+	if _, err := c.Users.Deactivate(ctx, 0); err == nil {
+		t.Error("expected an error for userID 0")
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	for i, want := range []string{"/users/9/deactivate.json", "/users/9/reactivate.json"} {
+		if requests[i].URL.Path != want {
+			t.Errorf("request %d: expected path %s, got %s", i, want, requests[i].URL.Path)
+		}
+	}
+}
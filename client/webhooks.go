@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// WebhookService handles webhook subscriptions, so integrations can register
+// their endpoints without going through the UI. Deliveries are handled with
+// the webhooks package.
+type WebhookService struct {
+	*Service[models.WebhookResponse, models.WebhooksResponse]
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(client *Client) *WebhookService {
+	return &WebhookService{
+		Service: NewService[models.WebhookResponse, models.WebhooksResponse](client, NewDefaultPathHandler("webhooks")),
+	}
+}
+
+// Get retrieves a webhook by ID
+func (s *WebhookService) Get(ctx context.Context, id int, params url.Values) (*models.WebhookResponse, error) {
+	return s.Service.Get(ctx, id, params)
+}
+
+// List retrieves a list of webhooks with optional filters
+func (s *WebhookService) List(ctx context.Context, params url.Values) (*models.WebhooksResponse, error) {
+	return s.Service.List(ctx, params)
+}
+
+// Create subscribes an endpoint to events. The URL must be absolute and at
+// least one event is required.
+func (s *WebhookService) Create(ctx context.Context, webhook *models.WebhookResponse) (*models.WebhookResponse, error) {
+	if webhook == nil {
+		return nil, fmt.Errorf("webhook is required")
+	}
+	if err := validateWebhookURL(webhook.Item.URL); err != nil {
+		return nil, err
+	}
+	if len(webhook.Item.Events) == 0 {
+		return nil, fmt.Errorf("webhook.events is required")
+	}
+
+	return s.Service.Create(ctx, webhook)
+}
+
+// CreateBatch creates webhooks in parallel, validating each one as Create
+// does. See Service.CreateBatch.
+func (s *WebhookService) CreateBatch(ctx context.Context, items []*models.WebhookResponse, opts BatchOptions) ([]BatchResult[models.WebhookResponse], error) {
+	return runBatch(ctx, items, opts, s.Create)
+}
+
+// Update updates an existing webhook. The URL, when set, must be absolute.
+func (s *WebhookService) Update(ctx context.Context, id int, webhook *models.WebhookResponse) (*models.WebhookResponse, error) {
+	if webhook != nil && webhook.Item.URL != nil {
+		if err := validateWebhookURL(webhook.Item.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Service.Update(ctx, id, webhook)
+}
+
+// Delete deletes a webhook, stopping its deliveries
+func (s *WebhookService) Delete(ctx context.Context, id int) error {
+	return s.Service.Delete(ctx, id)
+}
+
+func validateWebhookURL(raw *string) error {
+	if raw == nil || *raw == "" {
+		return fmt.Errorf("webhook.url is required")
+	}
+	u, err := url.Parse(*raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("webhook.url must be an absolute URL")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestWebhookServiceCRUD(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/webhooks.json", http.StatusOK, `{"webhooks":[{"id":1,"url":"https://hooks.example.com/desk"}]}`)
+	mockTransport.AddResponse(http.MethodGet, "/webhooks/1.json", http.StatusOK, `{"webhook":{"id":1,"events":["ticket.created"]}}`)
+	mockTransport.AddResponse(http.MethodPost, "/webhooks.json", http.StatusCreated, `{"webhook":{"id":2}}`)
+	mockTransport.AddResponse(http.MethodPut, "/webhooks/2.json", http.StatusOK, `{"webhook":{"id":2}}`)
+	mockTransport.AddResponse(http.MethodDelete, "/webhooks/2.json", http.StatusNoContent, "")
+
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	list, err := c.Webhooks.List(ctx, nil)
+	if err != nil || len(list.Items) != 1 || *list.Items[0].URL != "https://hooks.example.com/desk" {
+		t.Fatalf("List() = %+v, %v", list, err)
+	}
+
+	webhook, err := c.Webhooks.Get(ctx, 1, nil)
+	if err != nil || !reflect.DeepEqual(webhook.Item.Events, []string{"ticket.created"}) {
+		t.Fatalf("Get() = %+v, %v", webhook, err)
+	}
+
+	hookURL, secret := "https://hooks.example.com/desk", "s3cret"
+	created, err := c.Webhooks.Create(ctx, &models.WebhookResponse{Item: models.Webhook{
+		URL:    &hookURL,
+		Events: []string{"ticket.created", "happiness.rated"},
+		Secret: &secret,
+	}})
+	if err != nil || created.Item.ID != 2 {
+		t.Fatalf("Create() = %+v, %v", created, err)
+	}
+
+	disabled := false
+	if _, err := c.Webhooks.Update(ctx, 2, &models.WebhookResponse{Item: models.Webhook{Enabled: &disabled}}); err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+
+	if err := c.Webhooks.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	var got []string
+	for _, r := range requests {
+		got = append(got, r.Method+" "+r.URL.Path)
+	}
+	want := []string{"GET /webhooks.json", "GET /webhooks/1.json", "POST /webhooks.json", "PUT /webhooks/2.json", "DELETE /webhooks/2.json"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected requests %v, got %v", want, got)
+	}
+
+	b, _ := io.ReadAll(requests[2].Body)
+	var sent models.WebhookResponse
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if *sent.Item.URL != hookURL || len(sent.Item.Events) != 2 || *sent.Item.Secret != secret {
+		t.Errorf("unexpected create body %s", b)
+	}
+}
+
+func TestWebhookServiceValidation(t *testing.T) {
+	mockTransport := NewMockRoundTripper()
+	c := NewClient("https://example.com", WithHTTPClient(&http.Client{Transport: mockTransport}))
+	ctx := context.Background()
+
+	webhook := func(u string, events ...string) *models.WebhookResponse {
+		return &models.WebhookResponse{Item: models.Webhook{URL: &u, Events: events}}
+	}
+
+	for name, w := range map[string]*models.WebhookResponse{
+		"nil":          nil,
+		"no url":       {Item: models.Webhook{Events: []string{"ticket.created"}}},
+		"empty url":    webhook("", "ticket.created"),
+		"relative url": webhook("/desk/hooks", "ticket.created"),
+		"no host":      webhook("https://", "ticket.created"),
+		"bad url":      webhook("https://exa mple.com/%zz", "ticket.created"),
+		"no events":    webhook("https://hooks.example.com/desk"),
+	} {
+		if _, err := c.Webhooks.Create(ctx, w); err == nil {
+			t.Errorf("Create %s: expected an error", name)
+		}
+	}
+
+	if _, err := c.Webhooks.Update(ctx, 2, webhook("hooks.example.com")); err == nil {
+		t.Error("expected Update to reject a relative URL")
+	}
+
+	if n := len(mockTransport.GetRequests()); n != 0 {
+		t.Errorf("expected invalid webhooks not to be sent, got %d requests", n)
+	}
+}
@@ -0,0 +1,184 @@
+// Package config resolves settings for the CLI and programs built on the SDK
+// from command-line flags, environment variables and a profile file, in that
+// order of precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config looks up settings by key. A key such as "api-key" is read from the
+// --api-key flag when it was set on the command line, then from the
+// DESK_API_KEY environment variable (with the default "DESK_" prefix), then
+// from DESK_API_KEY in the profile file, and finally falls back to the
+// default passed to the getter.
+//
+// Getters never fail. Values that cannot be parsed and required keys that
+// are missing are collected and reported together by Err.
+type Config struct {
+	flags   *flag.FlagSet
+	prefix  string
+	profile map[string]string
+
+	errs []error
+}
+
+// Option configures a Config
+type Option func(*Config) error
+
+// WithEnvPrefix sets the prefix of environment variable names. Defaults to
+// "DESK_".
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Config) error {
+		c.prefix = prefix
+		return nil
+	}
+}
+
+// WithProfile reads settings from a profile file of KEY=value lines, using
+// the same names as the environment variables. An empty path is ignored.
+func WithProfile(path string) Option {
+	return func(c *Config) error {
+		if path == "" {
+			return nil
+		}
+
+		profile, err := godotenv.Read(path)
+		if err != nil {
+			return fmt.Errorf("read profile %s: %w", path, err)
+		}
+		c.profile = profile
+		return nil
+	}
+}
+
+// New creates a Config. flags, which may be nil, must already be parsed.
+func New(flags *flag.FlagSet, opts ...Option) (*Config, error) {
+	c := &Config{flags: flags, prefix: "DESK_"}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// EnvName returns the environment variable and profile name for key
+func (c *Config) EnvName(key string) string {
+	return c.prefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+}
+
+// Lookup returns the raw value of key and whether it was set anywhere
+func (c *Config) Lookup(key string) (string, bool) {
+	if c.flags != nil {
+		var value string
+		var set bool
+		c.flags.Visit(func(f *flag.Flag) {
+			if f.Name == key {
+				value, set = f.Value.String(), true
+			}
+		})
+		if set {
+			return value, true
+		}
+	}
+
+	name := c.EnvName(key)
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+
+	if value, ok := c.profile[name]; ok {
+		return value, true
+	}
+
+	return "", false
+}
+
+// String returns key as a string, or def when it is not set
+func (c *Config) String(key, def string) string {
+	if value, ok := c.Lookup(key); ok {
+		return value
+	}
+	return def
+}
+
+// Int returns key as an int, or def when it is not set or invalid
+func (c *Config) Int(key string, def int) int {
+	value, ok := c.Lookup(key)
+	if !ok {
+		return def
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("%s: %q is not a whole number", c.describe(key), value))
+		return def
+	}
+	return n
+}
+
+// Bool returns key as a bool, or def when it is not set or invalid. Values
+// are parsed with strconv.ParseBool.
+func (c *Config) Bool(key string, def bool) bool {
+	value, ok := c.Lookup(key)
+	if !ok {
+		return def
+	}
+
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("%s: %q is not true or false", c.describe(key), value))
+		return def
+	}
+	return b
+}
+
+// Duration returns key as a duration such as "30s", or def when it is not
+// set or invalid
+func (c *Config) Duration(key string, def time.Duration) time.Duration {
+	value, ok := c.Lookup(key)
+	if !ok {
+		return def
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		c.errs = append(c.errs, fmt.Errorf("%s: %q is not a duration", c.describe(key), value))
+		return def
+	}
+	return d
+}
+
+// Require records an error for each key that is not set, or is set to an
+// empty value
+func (c *Config) Require(keys ...string) {
+	for _, key := range keys {
+		if value, ok := c.Lookup(key); !ok || strings.TrimSpace(value) == "" {
+			c.errs = append(c.errs, fmt.Errorf("%s is required", c.describe(key)))
+		}
+	}
+}
+
+// Err returns every problem found so far, or nil
+func (c *Config) Err() error {
+	return errors.Join(c.errs...)
+}
+
+// describe names every place key can be set, for error messages
+func (c *Config) describe(key string) string {
+	if c.flags != nil && c.flags.Lookup(key) != nil {
+		return fmt.Sprintf("--%s (or %s)", key, c.EnvName(key))
+	}
+	return c.EnvName(key)
+}
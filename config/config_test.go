@@ -0,0 +1,98 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigPrecedence(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "staging.env")
+	if err := os.WriteFile(profile, []byte("TEST_API_KEY=from-profile\nTEST_BASE_URL=from-profile\nTEST_RESOURCE=from-profile\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TEST_API_KEY", "from-env")
+	t.Setenv("TEST_BASE_URL", "from-env")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("api-key", "flag-default", "")
+	fs.String("base-url", "flag-default", "")
+	if err := fs.Parse([]string{"--api-key", "from-flag"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := New(fs, WithEnvPrefix("TEST_"), WithProfile(profile))
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"api-key", "from-flag"},
+		{"base-url", "from-env"},
+		{"resource", "from-profile"},
+		{"action", "default"},
+	}
+	for _, tt := range tests {
+		if got := cfg.String(tt.key, "default"); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if err := cfg.Err(); err != nil {
+		t.Errorf("expected no errors, got %v", err)
+	}
+}
+
+func TestConfigTypedGettersAndValidation(t *testing.T) {
+	t.Setenv("TEST_COUNT", "5")
+	t.Setenv("TEST_TIMEOUT", "1m30s")
+	t.Setenv("TEST_DEBUG", "true")
+	t.Setenv("TEST_RETRIES", "many")
+	t.Setenv("TEST_EMPTY", " ")
+
+	cfg, err := New(nil, WithEnvPrefix("TEST_"))
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	if got := cfg.Int("count", 1); got != 5 {
+		t.Errorf("count = %d, want 5", got)
+	}
+	if got := cfg.Duration("timeout", time.Second); got != 90*time.Second {
+		t.Errorf("timeout = %s, want 1m30s", got)
+	}
+	if got := cfg.Bool("debug", false); !got {
+		t.Error("debug = false, want true")
+	}
+	if got := cfg.Int("retries", 3); got != 3 {
+		t.Errorf("retries = %d, want the default 3 for an invalid value", got)
+	}
+
+	cfg.Require("count", "empty", "missing")
+
+	err = cfg.Err()
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{"TEST_RETRIES", "TEST_EMPTY is required", "TEST_MISSING is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "TEST_COUNT") {
+		t.Errorf("did not expect an error for TEST_COUNT, got:\n%v", err)
+	}
+}
+
+func TestWithProfileMissingFile(t *testing.T) {
+	if _, err := New(nil, WithProfile(filepath.Join(t.TempDir(), "missing.env"))); err == nil {
+		t.Fatal("expected error for a missing profile file")
+	}
+}
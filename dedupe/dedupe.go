@@ -0,0 +1,170 @@
+// Package dedupe finds existing tickets that are likely duplicates of an
+// incoming message, so ingestion services can add to an existing ticket
+// instead of opening a new one.
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+const (
+	defaultWindow    = 72 * time.Hour
+	defaultThreshold = 0.8
+)
+
+// Options configures FindDuplicates
+type Options struct {
+	// Window is how far back to look for candidate tickets. Defaults to 72
+	// hours.
+	Window time.Duration
+
+	// Threshold is the minimum similarity, between 0 and 1, for a ticket to
+	// be reported. Defaults to 0.8.
+	Threshold float64
+}
+
+// Candidate is an existing ticket that resembles the incoming one
+type Candidate struct {
+	Ticket models.Ticket
+	Score  float64
+}
+
+// FindDuplicates searches the customer's recent tickets and returns those
+// whose normalized subject is similar to subject, best match first
+func FindDuplicates(ctx context.Context, c *client.Client, customerID int, subject string, opts Options) ([]Candidate, error) {
+	if customerID <= 0 {
+		return nil, fmt.Errorf("customerID must be greater than 0")
+	}
+	if opts.Window <= 0 {
+		opts.Window = defaultWindow
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaultThreshold
+	}
+
+	since := time.Now().Add(-opts.Window)
+	filter := &models.SearchTicketsFilter{
+		Customers:  []int64{int64(customerID)},
+		StartDate:  &since,
+		OmitMerged: true,
+		Page:       1,
+	}
+
+	normalized := NormalizeSubject(subject)
+
+	var candidates []Candidate
+	for {
+		resp, err := c.Tickets.Search(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+
+		for _, t := range resp.Tickets {
+			if t.CreatedAt != nil && t.CreatedAt.Before(since) {
+				continue
+			}
+			if t.Subject == nil {
+				continue
+			}
+
+			score := Similarity(normalized, NormalizeSubject(*t.Subject))
+			if score >= opts.Threshold {
+				candidates = append(candidates, Candidate{Ticket: t, Score: score})
+			}
+		}
+
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+		filter.Page++
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates, nil
+}
+
+// NormalizeSubject lowercases a subject and strips reply/forward prefixes,
+// ticket number tags, punctuation and repeated whitespace, so that
+// "RE: Fwd: [Ticket #123] Login broken!" and "login broken" compare equal
+func NormalizeSubject(s string) string {
+	replyPrefix := regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|wg|sv|antw)\s*(\[\d+\])?\s*:\s*)+`)
+	ticketTag := regexp.MustCompile(`\[[^\]]*#\d+[^\]]*\]|#\d+`)
+
+	s = replyPrefix.ReplaceAllString(s, "")
+	s = ticketTag.ReplaceAllString(s, " ")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Similarity scores two normalized subjects between 0 and 1. It takes the
+// better of word overlap (robust to reordering) and edit distance (robust to
+// typos).
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+
+	return max(jaccard(strings.Fields(a), strings.Fields(b)), 1-float64(levenshtein(a, b))/float64(max(len([]rune(a)), len([]rune(b)))))
+}
+
+func jaccard(a, b []string) float64 {
+	set := make(map[string]int, len(a)+len(b))
+	for _, w := range a {
+		set[w] |= 1
+	}
+	for _, w := range b {
+		set[w] |= 2
+	}
+
+	var both int
+	for _, v := range set {
+		if v == 3 {
+			both++
+		}
+	}
+
+	return float64(both) / float64(len(set))
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
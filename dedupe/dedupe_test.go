@@ -0,0 +1,39 @@
+package dedupe
+
+import "testing"
+
+func TestNormalizeSubject(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"RE: Fwd: [Ticket #123] Login broken!", "login broken"},
+		{"re[2]: Invoice   question", "invoice question"},
+		{"Order #4411 hasn't arrived", "order hasn t arrived"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeSubject(tt.in); got != tt.want {
+			t.Errorf("NormalizeSubject(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("login broken", "login broken"); got != 1 {
+		t.Errorf("identical subjects: got %v, want 1", got)
+	}
+
+	if got := Similarity("broken login", "login broken"); got != 1 {
+		t.Errorf("reordered subjects: got %v, want 1", got)
+	}
+
+	if got := Similarity("password reset not working", "pasword reset not working"); got < 0.9 {
+		t.Errorf("typo should still score highly, got %v", got)
+	}
+
+	if got := Similarity("invoice question", "login broken"); got > 0.5 {
+		t.Errorf("unrelated subjects should score low, got %v", got)
+	}
+}
@@ -0,0 +1,239 @@
+// Package escalation evaluates open tickets against time-based rules (for
+// example "no reply in 48 hours") and applies the resulting priority and
+// assignment changes through the SDK.
+package escalation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Rule describes when a ticket should be escalated and what should change
+// when it is. A ticket matches a rule when it has been idle for at least
+// IdleFor and, if set, Match returns true.
+type Rule struct {
+	// Name identifies the rule in the returned changes
+	Name string
+
+	// IdleFor is the minimum time since the ticket was last updated. Desk
+	// bumps a ticket's updatedAt whenever a reply is added, so this
+	// approximates "no reply in".
+	IdleFor time.Duration
+
+	// Match optionally narrows the rule to a subset of tickets
+	Match func(models.Ticket) bool
+
+	// PriorityID, when non-zero, is the priority to move the ticket to
+	PriorityID int
+
+	// AgentID, when non-zero, is the agent to reassign the ticket to
+	AgentID int
+}
+
+// Change is the outcome of evaluating a single ticket against the rules
+type Change struct {
+	TicketID   int
+	Rule       string
+	PriorityID int
+	AgentID    int
+	Applied    bool
+	Err        error
+}
+
+// Engine evaluates tickets against an ordered list of rules. The first rule a
+// ticket matches wins.
+type Engine struct {
+	client *client.Client
+	rules  []Rule
+	dryRun bool
+	now    func() time.Time
+}
+
+// Option configures an Engine
+type Option func(*Engine)
+
+// WithDryRun makes Run report the changes it would make without applying them
+func WithDryRun() Option {
+	return func(e *Engine) {
+		e.dryRun = true
+	}
+}
+
+// WithClock overrides the time source used to measure idle time
+func WithClock(now func() time.Time) Option {
+	return func(e *Engine) {
+		e.now = now
+	}
+}
+
+// New creates a new escalation engine
+func New(c *client.Client, rules []Rule, opts ...Option) *Engine {
+	e := &Engine{
+		client: c,
+		rules:  rules,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Evaluate searches for tickets matching filter and returns the change each
+// one would receive. Tickets that match no rule, or already have the target
+// priority and agent, are omitted.
+func (e *Engine) Evaluate(ctx context.Context, filter *models.SearchTicketsFilter) ([]Change, error) {
+	if filter == nil {
+		filter = &models.SearchTicketsFilter{}
+	}
+
+	// Work on a copy so paging does not leak into the caller's filter
+	f := *filter
+	if f.Page == 0 {
+		f.Page = 1
+	}
+
+	var changes []Change
+	for {
+		resp, err := e.client.Tickets.Search(ctx, &f)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+
+		for _, t := range resp.Tickets {
+			if change, ok := e.evaluate(t); ok {
+				changes = append(changes, change)
+			}
+		}
+
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+		f.Page++
+	}
+
+	return changes, nil
+}
+
+// Run evaluates tickets matching filter and applies the resulting changes. In
+// dry-run mode it behaves like Evaluate. Tickets getting the same change are
+// updated with one bulk request. Failures on individual tickets are recorded
+// on the returned changes rather than aborting the run.
+func (e *Engine) Run(ctx context.Context, filter *models.SearchTicketsFilter) ([]Change, error) {
+	changes, err := e.Evaluate(ctx, filter)
+	if err != nil || e.dryRun {
+		return changes, err
+	}
+
+	// Tickets getting the same priority and agent are updated together
+	type target struct{ priorityID, agentID int }
+	var order []target
+	groups := map[target][]int{}
+	for i, change := range changes {
+		t := target{change.PriorityID, change.AgentID}
+		if _, ok := groups[t]; !ok {
+			order = append(order, t)
+		}
+		groups[t] = append(groups[t], i)
+	}
+
+	for _, t := range order {
+		e.apply(ctx, changes, groups[t])
+	}
+
+	return changes, nil
+}
+
+func (e *Engine) evaluate(t models.Ticket) (Change, bool) {
+	last := t.UpdatedAt
+	if last == nil {
+		last = t.CreatedAt
+	}
+	if last == nil {
+		return Change{}, false
+	}
+
+	idle := e.now().Sub(*last)
+	for _, rule := range e.rules {
+		if idle < rule.IdleFor {
+			continue
+		}
+		if rule.Match != nil && !rule.Match(t) {
+			continue
+		}
+
+		change := Change{TicketID: t.ID, Rule: rule.Name}
+		if rule.PriorityID != 0 && (t.Priority == nil || t.Priority.ID != rule.PriorityID) {
+			change.PriorityID = rule.PriorityID
+		}
+		if rule.AgentID != 0 && (t.Agent == nil || t.Agent.ID != rule.AgentID) {
+			change.AgentID = rule.AgentID
+		}
+
+		if change.PriorityID == 0 && change.AgentID == 0 {
+			return Change{}, false
+		}
+		return change, true
+	}
+
+	return Change{}, false
+}
+
+// apply makes the change shared by changes[idx]: a sparse patch for a single
+// ticket, otherwise one bulk update. Only the priority and agent are sent,
+// so the rest of each ticket is left as it is.
+func (e *Engine) apply(ctx context.Context, changes []Change, idx []int) {
+	first := changes[idx[0]]
+
+	if len(idx) == 1 {
+		partial := map[string]*models.EntityRef{}
+		if first.PriorityID != 0 {
+			partial["priority"] = &models.EntityRef{ID: first.PriorityID, Type: "ticketpriorities"}
+		}
+		if first.AgentID != 0 {
+			partial["agent"] = &models.EntityRef{ID: first.AgentID, Type: "users"}
+		}
+
+		_, err := e.client.Tickets.Patch(ctx, first.TicketID, partial)
+		changes[idx[0]].Err = err
+		changes[idx[0]].Applied = err == nil
+		return
+	}
+
+	var bulk models.TicketBulkChanges
+	if first.PriorityID != 0 {
+		bulk.Priority = &models.EntityRef{ID: first.PriorityID, Type: "ticketpriorities"}
+	}
+	if first.AgentID != 0 {
+		bulk.Agent = &models.EntityRef{ID: first.AgentID, Type: "users"}
+	}
+
+	ids := make([]int, len(idx))
+	for i, j := range idx {
+		ids[i] = changes[j].TicketID
+	}
+
+	// An error stops the remaining chunks, so tickets returned from earlier
+	// chunks are still applied
+	updated, err := e.client.Tickets.BulkUpdate(ctx, ids, bulk)
+	done := make(map[int]bool, len(updated))
+	for _, t := range updated {
+		done[t.ID] = true
+	}
+	for _, j := range idx {
+		switch {
+		case done[changes[j].TicketID]:
+			changes[j].Applied = true
+		case err != nil:
+			changes[j].Err = err
+		default:
+			changes[j].Err = fmt.Errorf("ticket %d was not returned by the bulk update", changes[j].TicketID)
+		}
+	}
+}
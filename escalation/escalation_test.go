@@ -0,0 +1,160 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestEngineRun(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-72 * time.Hour)
+	fresh := now.Add(-time.Hour)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 1, UpdatedAt: &stale}},
+			{BaseEntity: models.BaseEntity{ID: 2, UpdatedAt: &fresh}},
+			{BaseEntity: models.BaseEntity{ID: 3, UpdatedAt: &stale}, Priority: &models.EntityRef{ID: 9}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/1.json", http.StatusOK, models.TicketResponse{})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	engine := New(c, []Rule{{Name: "no-reply-48h", IdleFor: 48 * time.Hour, PriorityID: 9}}, WithClock(func() time.Time { return now }))
+
+	changes, err := engine.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+
+	if changes[0].TicketID != 1 || !changes[0].Applied {
+		t.Errorf("expected ticket 1 to be escalated, got %+v", changes[0])
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestEngineDryRun(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-72 * time.Hour)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{{BaseEntity: models.BaseEntity{ID: 1, UpdatedAt: &stale}}},
+	})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	engine := New(c, []Rule{{Name: "reassign", IdleFor: 24 * time.Hour, AgentID: 5}}, WithDryRun())
+
+	changes, err := engine.Run(context.Background(), &models.SearchTicketsFilter{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Applied {
+		t.Fatalf("expected 1 unapplied change, got %+v", changes)
+	}
+
+	if len(mockTransport.GetRequests()) != 1 {
+		t.Errorf("expected dry run to only search, got %d requests", len(mockTransport.GetRequests()))
+	}
+}
+
+func TestEngineRunBulk(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-72 * time.Hour)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 1, UpdatedAt: &stale}},
+			{BaseEntity: models.BaseEntity{ID: 2, UpdatedAt: &stale}},
+			{BaseEntity: models.BaseEntity{ID: 3, UpdatedAt: &stale}, Subject: ptr("VIP")},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/bulk.json", http.StatusOK, `{"tickets":[{"id":1},{"id":2}]}`)
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/3.json", http.StatusOK, `{"ticket":{"id":3}}`)
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	engine := New(c, []Rule{
+		{Name: "vip", IdleFor: time.Hour, AgentID: 5, Match: func(t models.Ticket) bool { return t.Subject != nil && *t.Subject == "VIP" }},
+		{Name: "no-reply-48h", IdleFor: 48 * time.Hour, PriorityID: 9},
+	}, WithClock(func() time.Time { return now }))
+
+	changes, err := engine.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, change := range changes {
+		if !change.Applied {
+			t.Errorf("expected ticket %d to be escalated, got %+v", change.TicketID, change)
+		}
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected a search, one bulk update and one patch, got %d requests", len(requests))
+	}
+
+	b, _ := io.ReadAll(requests[1].Body)
+	if want := `{"ids":[1,2],"ticket":{"priority":{"id":9,"type":"ticketpriorities","meta":null}}}`; string(b) != want {
+		t.Errorf("expected bulk body %s, got %s", want, b)
+	}
+
+	var patch struct {
+		Ticket map[string]json.RawMessage `json:"ticket"`
+	}
+	b, _ = io.ReadAll(requests[2].Body)
+	if err := json.Unmarshal(b, &patch); err != nil {
+		t.Fatalf("failed to decode patch body %s: %v", b, err)
+	}
+	if _, ok := patch.Ticket["agent"]; !ok || len(patch.Ticket) != 1 {
+		t.Errorf("expected only the agent to be sent, got %s", b)
+	}
+}
+
+func TestEngineRunBulkNotReturned(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-72 * time.Hour)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 1, UpdatedAt: &stale}},
+			{BaseEntity: models.BaseEntity{ID: 2, UpdatedAt: &stale}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPatch, "/tickets/bulk.json", http.StatusOK, `{"tickets":[{"id":1}]}`)
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	engine := New(c, []Rule{{Name: "reassign", IdleFor: time.Hour, AgentID: 5}}, WithClock(func() time.Time { return now }))
+
+	changes, err := engine.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(changes) != 2 || !changes[0].Applied || changes[1].Applied || changes[1].Err == nil {
+		t.Errorf("expected only ticket 1 to be applied, got %+v", changes)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,232 @@
+// Command server is a reference webhook receiver built on the SDK. It
+// accepts Desk webhook deliveries, queues them on a webhooks.Dispatcher and
+// handles them in the background:
+//
+//   - ticket.created: looks up the ticket's customer and adds an internal
+//     note summarizing who they are and how many tickets they have raised
+//   - happiness.rated: logs the rating together with the ticket and agent
+//
+// Configure it with DESK_API_KEY, DESK_BASE_URL, DESK_WEBHOOK_SECRET and
+// optionally DESK_ADDR (default :8080), or the matching flags or a profile
+// file, then point a Desk webhook with the same secret at
+// http://<host>/webhooks.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/config"
+	"github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/desksdkgo/webhooks"
+)
+
+func main() {
+	flag.String("api-key", "", "API key (can also be set via DESK_API_KEY env var)")
+	flag.String("base-url", "", "Base URL of the Desk API (can also be set via DESK_BASE_URL env var)")
+	flag.String("webhook-secret", "", "Secret the webhook deliveries are signed with (can also be set via DESK_WEBHOOK_SECRET env var)")
+	flag.String("addr", "", "Address to listen on (can also be set via DESK_ADDR env var, default :8080)")
+	profile := flag.String("profile", os.Getenv("DESK_PROFILE"), "File of DESK_* settings used when neither a flag nor an env var is set (can also be set via DESK_PROFILE env var)")
+	flag.Parse()
+
+	cfg, err := config.New(flag.CommandLine, config.WithProfile(*profile))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey := cfg.String("api-key", "")
+	baseURL := cfg.String("base-url", "")
+	secret := cfg.String("webhook-secret", "")
+	addr := cfg.String("addr", ":8080")
+	cfg.Require("api-key", "base-url", "webhook-secret")
+	if err := cfg.Err(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c := client.NewClient(baseURL, client.WithAPIKey(apiKey))
+	h := &handler{client: c, logger: slog.Default()}
+
+	d := webhooks.NewDispatcher(h,
+		webhooks.WithRetry(3, 5*time.Second),
+		webhooks.WithDeadLetter(func(_ context.Context, event webhooks.Event, err error) {
+			h.logger.Error("Dropping webhook event", "id", event.ID, "type", event.Type, "error", err)
+		}),
+	)
+	if err := d.Start(ctx); err != nil {
+		log.Fatalf("Failed to start dispatcher: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /webhooks", receive(d, secret))
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Stop accepting deliveries before draining the queue
+		srv.Shutdown(shutdownCtx)
+		if err := d.Shutdown(shutdownCtx); err != nil {
+			h.logger.Error("Queued webhook events were not all handled", "error", err)
+		}
+	}()
+
+	h.logger.Info("Listening for webhooks", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-drained
+}
+
+// receive checks the signature of webhook deliveries, queues them and
+// acknowledges them straight away, so Desk does not time out waiting for slow
+// handlers
+func receive(d *webhooks.Dispatcher, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := webhooks.ParseEvent(r, secret)
+		if errors.Is(err, webhooks.ErrInvalidSignature) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+			return
+		}
+
+		if err := d.Enqueue(*event); err != nil {
+			// Desk redelivers events that are not acknowledged
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+type handler struct {
+	client *client.Client
+	logger *slog.Logger
+}
+
+// Handle implements webhooks.Handler
+func (h *handler) Handle(ctx context.Context, event webhooks.Event) error {
+	switch event.Type {
+	case webhooks.EventTicketCreated:
+		return h.ticketCreated(ctx, event)
+	case webhooks.EventHappinessRated:
+		return h.happinessRated(ctx, event)
+	default:
+		h.logger.Debug("Ignoring webhook event", "id", event.ID, "type", event.Type)
+		return nil
+	}
+}
+
+// ticketCreated adds a note to the new ticket describing its customer
+func (h *handler) ticketCreated(ctx context.Context, event webhooks.Event) error {
+	payload, ok := event.Data.(*models.Ticket)
+	if !ok {
+		return fmt.Errorf("event has no ticket payload")
+	}
+	if payload.ID <= 0 {
+		return fmt.Errorf("ticket payload has no ID")
+	}
+
+	ticket, err := h.client.Tickets.Get(ctx, payload.ID, nil)
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+	if ticket.Ticket.Customer == nil {
+		return nil
+	}
+
+	customer, err := h.client.Customers.Get(ctx, ticket.Ticket.Customer.ID, nil)
+	if err != nil {
+		return fmt.Errorf("get customer: %w", err)
+	}
+
+	previous, err := h.client.Tickets.Count(ctx, &models.SearchTicketsFilter{
+		Customers: []int64{int64(customer.Customer.ID)},
+	})
+	if err != nil {
+		return fmt.Errorf("count customer tickets: %w", err)
+	}
+
+	body := customerNote(customer.Customer, previous-1)
+	note := models.ThreadTypeNote
+	_, err = h.client.Messages.CreateForTicket(ctx, payload.ID, &models.MessageResponse{
+		Message: models.Message{Message: &body, ThreadType: &note},
+	})
+	if err != nil {
+		return fmt.Errorf("add customer note: %w", err)
+	}
+
+	h.logger.Info("Added customer details to ticket", "ticket", payload.ID, "customer", customer.Customer.ID)
+	return nil
+}
+
+// happinessRated logs who was rated on what
+func (h *handler) happinessRated(ctx context.Context, event webhooks.Event) error {
+	p, err := webhooks.ParseHappiness(event)
+	if err != nil {
+		return err
+	}
+
+	details, err := webhooks.LookupHappiness(ctx, h.client, p)
+	if err != nil {
+		return err
+	}
+
+	agent := "unassigned"
+	if details.Agent != nil && details.Agent.Email != nil {
+		agent = *details.Agent.Email
+	}
+	h.logger.Info("Ticket rated",
+		"ticket", details.Ticket.ID,
+		"rating", details.Rating.Rating,
+		"agent", agent,
+		"comment", details.Rating.Comment,
+	)
+	return nil
+}
+
+// customerNote formats a customer summary as an HTML note
+func customerNote(c models.Customer, previousTickets int) string {
+	var name []string
+	for _, s := range []*string{c.FirstName, c.LastName} {
+		if s != nil && *s != "" {
+			name = append(name, *s)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<p><strong>About this customer</strong></p><ul>")
+	fmt.Fprintf(&b, "<li>Name: %s</li>", html.EscapeString(strings.Join(name, " ")))
+	if c.Email != nil {
+		fmt.Fprintf(&b, "<li>Email: %s</li>", html.EscapeString(*c.Email))
+	}
+	if c.Organization != nil && *c.Organization != "" {
+		fmt.Fprintf(&b, "<li>Organization: %s</li>", html.EscapeString(*c.Organization))
+	}
+	fmt.Fprintf(&b, "<li>Previous tickets: %d</li>", max(previousTickets, 0))
+	b.WriteString("</ul>")
+	return b.String()
+}
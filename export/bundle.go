@@ -0,0 +1,231 @@
+// Package export writes self-contained archives of Desk data for legal holds
+// and customer data requests.
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Paths of the files written to a ticket bundle
+const (
+	BundleTicketPath   = "ticket.json"
+	BundleTimelinePath = "timeline.json"
+	BundleManifestPath = "manifest.json"
+	bundleFilesDir     = "attachments"
+)
+
+// BundleManifest describes the contents of a ticket bundle. It is written to
+// the archive last, as manifest.json.
+type BundleManifest struct {
+	TicketID    int           `json:"ticketId"`
+	GeneratedAt time.Time     `json:"generatedAt"`
+	Files       []BundleFile  `json:"files"`
+	Failures    []BundleError `json:"failures,omitempty"`
+}
+
+// BundleFile is an attachment stored in the bundle
+type BundleFile struct {
+	FileID   int    `json:"fileId"`
+	Filename string `json:"filename"`
+	MIMEType string `json:"mimeType,omitempty"`
+	// Path is the file's location inside the archive
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BundleError is an attachment that could not be added to the bundle
+type BundleError struct {
+	FileID int    `json:"fileId"`
+	Error  string `json:"error"`
+}
+
+// TicketBundle writes a zip archive to w holding the ticket with all its
+// includes, its full timeline of messages, notes and activity, and every
+// attachment on the ticket and its messages. Attachments that cannot be
+// downloaded are listed in the manifest's failures, are left out of the
+// archive and do not stop the export; any other error leaves w holding an
+// incomplete archive.
+func TicketBundle(ctx context.Context, c *client.Client, ticketID int, w io.Writer) (*BundleManifest, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	ticket, err := c.Tickets.Get(ctx, ticketID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	timeline, err := c.Tickets.Timeline(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("get timeline: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	manifest := &BundleManifest{TicketID: ticketID, GeneratedAt: time.Now().UTC()}
+
+	if err := writeJSON(zw, BundleTicketPath, ticket); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, BundleTimelinePath, timeline); err != nil {
+		return nil, err
+	}
+
+	refs := ticket.Ticket.Files
+	for _, event := range timeline.Events {
+		if event.Message != nil {
+			refs = append(refs, event.Message.Files...)
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, ref := range refs {
+		if ref.ID <= 0 || seen[ref.ID] {
+			continue
+		}
+		seen[ref.ID] = true
+
+		d, err := download(ctx, c, ref.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			manifest.Failures = append(manifest.Failures, BundleError{FileID: ref.ID, Error: err.Error()})
+			continue
+		}
+
+		f, err := writeFile(zw, d)
+		d.close()
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, *f)
+	}
+
+	if err := writeJSON(zw, BundleManifestPath, manifest); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// downloadedFile is an attachment downloaded to a temporary file
+type downloadedFile struct {
+	id     int
+	file   *models.File
+	tmp    *os.File
+	size   int64
+	sha256 string
+}
+
+// download fetches an attachment into a temporary file, so that a download
+// that fails part way does not leave a truncated entry in the archive
+func download(ctx context.Context, c *client.Client, fileID int) (*downloadedFile, error) {
+	file, body, err := c.Files.Download(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "desk-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file: %w", err)
+	}
+	d := &downloadedFile{id: fileID, file: file, tmp: tmp}
+
+	hash := sha256.New()
+	d.size, err = io.Copy(io.MultiWriter(tmp, hash), body)
+	if err != nil {
+		d.close()
+		return nil, fmt.Errorf("copy file %d: %w", fileID, err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		d.close()
+		return nil, fmt.Errorf("rewind file %d: %w", fileID, err)
+	}
+	d.sha256 = hex.EncodeToString(hash.Sum(nil))
+
+	return d, nil
+}
+
+// close removes the temporary file
+func (d *downloadedFile) close() {
+	d.tmp.Close()
+	os.Remove(d.tmp.Name())
+}
+
+func writeFile(zw *zip.Writer, d *downloadedFile) (*BundleFile, error) {
+	f := &BundleFile{
+		FileID:   d.id,
+		Filename: deref(d.file.Filename),
+		MIMEType: deref(d.file.MIMEType),
+		Size:     d.size,
+		SHA256:   d.sha256,
+	}
+	f.Path = fmt.Sprintf("%s/%d-%s", bundleFilesDir, f.FileID, safeName(f.Filename))
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.Path, Method: zip.Deflate, Modified: fileTime(d.file)})
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", f.Path, err)
+	}
+	if _, err := io.Copy(fw, d.tmp); err != nil {
+		return nil, fmt.Errorf("write %s: %w", f.Path, err)
+	}
+
+	return f, nil
+}
+
+func fileTime(file *models.File) time.Time {
+	if file.CreatedAt != nil {
+		return *file.CreatedAt
+	}
+	return time.Now()
+}
+
+// safeName strips path separators so a filename cannot escape the
+// attachments directory
+func safeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,160 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTicketBundle(t *testing.T) {
+	name := "../../etc/passwd"
+	url := "https://files.example.com/5"
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{
+			BaseEntity: models.BaseEntity{ID: 42},
+			Files:      []models.EntityRef{{ID: 5}, {ID: 5}, {ID: 6}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42/messages.json", http.StatusOK, models.MessagesResponse{
+		Messages: []models.Message{{BaseEntity: models.BaseEntity{ID: 1}}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/files/5.json", http.StatusOK, models.FileResponse{
+		File: models.File{BaseEntity: models.BaseEntity{ID: 5}, Filename: &name, URL: &url},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/5", http.StatusOK, "attachment body")
+	mockTransport.AddResponse(http.MethodGet, "/files/6.json", http.StatusNotFound, "")
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	var buf bytes.Buffer
+	manifest, err := TicketBundle(context.Background(), c, 42, &buf)
+	if err != nil {
+		t.Fatalf("TicketBundle() returned error: %v", err)
+	}
+
+	if len(manifest.Files) != 1 || len(manifest.Failures) != 1 {
+		t.Fatalf("expected 1 file and 1 failure, got %+v", manifest)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(b)
+	}
+
+	if got := contents["attachments/5-.._.._etc_passwd"]; got != "attachment body" {
+		t.Errorf("expected the attachment under a safe name, got files %v", zr.File)
+	}
+
+	var timeline models.Timeline
+	if err := json.Unmarshal([]byte(contents[BundleTimelinePath]), &timeline); err != nil || len(timeline.Events) != 1 {
+		t.Errorf("expected a timeline with 1 event, got %q", contents[BundleTimelinePath])
+	}
+
+	var written BundleManifest
+	if err := json.Unmarshal([]byte(contents[BundleManifestPath]), &written); err != nil || written.Files[0].SHA256 != manifest.Files[0].SHA256 {
+		t.Errorf("expected the manifest in the archive, got %q", contents[BundleManifestPath])
+	}
+}
+
+// failingBody returns some data and then fails, like a dropped connection
+type failingBody struct {
+	sent bool
+}
+
+func (b *failingBody) Read(p []byte) (int, error) {
+	if b.sent {
+		return 0, errors.New("connection reset")
+	}
+	b.sent = true
+	return copy(p, "partial"), nil
+}
+
+func (b *failingBody) Close() error { return nil }
+
+// truncatingTransport serves the download at truncatePath with a body that
+// fails part way
+type truncatingTransport struct {
+	*client.MockRoundTripper
+	truncatePath string
+}
+
+func (t *truncatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == t.truncatePath {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: &failingBody{}, Request: req}, nil
+	}
+	return t.MockRoundTripper.RoundTrip(req)
+}
+
+func TestTicketBundleMessageFiles(t *testing.T) {
+	good, bad := "notes.txt", "dump.bin"
+	goodURL, badURL := "https://files.example.com/7", "https://files.example.com/8"
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 42}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42/messages.json", http.StatusOK, models.MessagesResponse{
+		Messages: []models.Message{
+			{BaseEntity: models.BaseEntity{ID: 1}, Files: []models.EntityRef{{ID: 7}}},
+			{BaseEntity: models.BaseEntity{ID: 2}, Files: []models.EntityRef{{ID: 7}, {ID: 8}}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/files/7.json", http.StatusOK, models.FileResponse{
+		File: models.File{BaseEntity: models.BaseEntity{ID: 7}, Filename: &good, URL: &goodURL},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/7", http.StatusOK, "meeting notes")
+	mockTransport.AddResponse(http.MethodGet, "/files/8.json", http.StatusOK, models.FileResponse{
+		File: models.File{BaseEntity: models.BaseEntity{ID: 8}, Filename: &bad, URL: &badURL},
+	})
+
+	transport := &truncatingTransport{MockRoundTripper: mockTransport, truncatePath: "/8"}
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: transport}))
+
+	var buf bytes.Buffer
+	manifest, err := TicketBundle(context.Background(), c, 42, &buf)
+	if err != nil {
+		t.Fatalf("TicketBundle() returned error: %v", err)
+	}
+
+	if len(manifest.Files) != 1 || manifest.Files[0].FileID != 7 || manifest.Files[0].Size != int64(len("meeting notes")) {
+		t.Errorf("expected the message attachment in the manifest once, got %+v", manifest.Files)
+	}
+	if len(manifest.Failures) != 1 || manifest.Failures[0].FileID != 8 {
+		t.Errorf("expected the truncated download as a failure, got %+v", manifest.Failures)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	want := []string{BundleTicketPath, BundleTimelinePath, "attachments/7-notes.txt", BundleManifestPath}
+	if !slices.Equal(names, want) {
+		t.Errorf("expected archive entries %v, got %v", want, names)
+	}
+}
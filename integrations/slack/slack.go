@@ -0,0 +1,246 @@
+// Package slack formats Desk tickets and happiness ratings as Slack Block Kit
+// messages and posts them to a Slack incoming webhook.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/desksdkgo/webhooks"
+)
+
+// Text is a Slack text object
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Block is a Slack layout block. Only the fields used by this package are
+// modelled.
+type Block struct {
+	Type     string `json:"type"`
+	Text     *Text  `json:"text,omitempty"`
+	Fields   []Text `json:"fields,omitempty"`
+	Elements []Text `json:"elements,omitempty"`
+}
+
+// Message is the payload accepted by a Slack incoming webhook. Text is used as
+// the notification fallback when blocks cannot be rendered.
+type Message struct {
+	Text   string  `json:"text"`
+	Blocks []Block `json:"blocks,omitempty"`
+}
+
+// Notifier posts messages to a Slack incoming webhook
+type Notifier struct {
+	webhookURL string
+	httpClient *http.Client
+}
+
+// Option configures a Notifier
+type Option func(*Notifier)
+
+// WithHTTPClient sets a custom HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(n *Notifier) {
+		n.httpClient = httpClient
+	}
+}
+
+// New creates a notifier that posts to the given incoming webhook URL
+func New(webhookURL string, opts ...Option) *Notifier {
+	n := &Notifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// Post sends msg to the webhook
+func (n *Notifier) Post(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+// PostTicket formats t with TicketMessage and posts it
+func (n *Notifier) PostTicket(ctx context.Context, title string, t models.Ticket, link string) error {
+	return n.Post(ctx, TicketMessage(title, t, link))
+}
+
+// TicketMessage formats a ticket as a Slack message. title describes what
+// happened (for example "New ticket"), and link, when non-empty, is rendered as
+// a link to the ticket in Desk. Ticket.WebURL builds the link from the
+// client's BaseURL.
+func TicketMessage(title string, t models.Ticket, link string) Message {
+	subject := deref(t.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+
+	heading := fmt.Sprintf("*%s* #%d: %s", escape(title), t.ID, escape(subject))
+	if link != "" {
+		heading = fmt.Sprintf("*%s* <%s|#%d: %s>", escape(title), link, t.ID, escape(subject))
+	}
+
+	blocks := []Block{{
+		Type: "section",
+		Text: &Text{Type: "mrkdwn", Text: heading},
+	}}
+
+	var fields []Text
+	if ref := refLabel(t.Status); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Status*\n" + ref})
+	}
+	if ref := refLabel(t.Priority); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Priority*\n" + ref})
+	}
+	if ref := refLabel(t.Inbox); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Inbox*\n" + ref})
+	}
+	if ref := refLabel(t.Agent); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Agent*\n" + ref})
+	}
+	if len(fields) > 0 {
+		blocks = append(blocks, Block{Type: "section", Fields: fields})
+	}
+
+	if preview := deref(t.PreviewText); preview != "" {
+		blocks = append(blocks, Block{
+			Type:     "context",
+			Elements: []Text{{Type: "plain_text", Text: truncate(preview, 300)}},
+		})
+	}
+
+	return Message{
+		Text:   fmt.Sprintf("%s #%d: %s", title, t.ID, subject),
+		Blocks: blocks,
+	}
+}
+
+// PostHappiness formats p with HappinessMessage and posts it
+func (n *Notifier) PostHappiness(ctx context.Context, p webhooks.HappinessPayload, link string) error {
+	return n.Post(ctx, HappinessMessage(p, link))
+}
+
+// HappinessMessage formats a happiness.rated webhook payload as a Slack
+// message. link, when non-empty, is rendered as a link to the rated ticket in
+// Desk.
+func HappinessMessage(p webhooks.HappinessPayload, link string) Message {
+	rating := string(p.Rating)
+	if rating == "" {
+		rating = "unknown"
+	}
+
+	ticket := fmt.Sprintf("#%d", p.Ticket.ID)
+	if link != "" {
+		ticket = fmt.Sprintf("<%s|#%d>", link, p.Ticket.ID)
+	}
+
+	blocks := []Block{{
+		Type: "section",
+		Text: &Text{Type: "mrkdwn", Text: fmt.Sprintf("%s *%s rating* on ticket %s", ratingEmoji(p.Rating), escape(rating), ticket)},
+	}}
+
+	var fields []Text
+	if ref := refLabel(&p.Customer); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Customer*\n" + ref})
+	}
+	if ref := refLabel(p.Agent); ref != "" {
+		fields = append(fields, Text{Type: "mrkdwn", Text: "*Agent*\n" + ref})
+	}
+	if len(fields) > 0 {
+		blocks = append(blocks, Block{Type: "section", Fields: fields})
+	}
+
+	if p.Comment != "" {
+		blocks = append(blocks, Block{
+			Type: "section",
+			Text: &Text{Type: "mrkdwn", Text: "> " + strings.ReplaceAll(escape(truncate(p.Comment, 300)), "\n", "\n> ")},
+		})
+	}
+
+	return Message{
+		Text:   fmt.Sprintf("%s rating on ticket #%d", rating, p.Ticket.ID),
+		Blocks: blocks,
+	}
+}
+
+// ratingEmoji returns the Slack emoji shown next to a rating
+func ratingEmoji(r models.HappinessRating) string {
+	switch r {
+	case models.HappinessRatingHappy:
+		return ":smiley:"
+	case models.HappinessRatingNeutral:
+		return ":neutral_face:"
+	case models.HappinessRatingUnhappy:
+		return ":disappointed:"
+	default:
+		return ":grey_question:"
+	}
+}
+
+// refLabel renders a reference using its name from meta when the API provided
+// one, falling back to the ID
+func refLabel(ref *models.EntityRef) string {
+	if ref == nil || ref.ID == 0 {
+		return ""
+	}
+	if name, ok := ref.Meta["name"].(string); ok && name != "" {
+		return escape(name)
+	}
+	return fmt.Sprintf("#%d", ref.ID)
+}
+
+// escape escapes the characters Slack treats as control sequences in mrkdwn
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
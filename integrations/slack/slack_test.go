@@ -0,0 +1,111 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/desksdkgo/webhooks"
+)
+
+func TestTicketMessage(t *testing.T) {
+	subject := "Printer <on fire>"
+	msg := TicketMessage("New ticket", models.Ticket{
+		BaseEntity: models.BaseEntity{ID: 7},
+		Subject:    &subject,
+		Status:     &models.EntityRef{ID: 1, Meta: map[string]any{"name": "Active"}},
+		Priority:   &models.EntityRef{ID: 3},
+	}, "https://example.teamwork.com/desk/tickets/7")
+
+	if len(msg.Blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(msg.Blocks))
+	}
+
+	heading := msg.Blocks[0].Text.Text
+	if !strings.Contains(heading, "&lt;on fire&gt;") {
+		t.Errorf("expected subject to be escaped, got %q", heading)
+	}
+	if !strings.Contains(heading, "<https://example.teamwork.com/desk/tickets/7|#7") {
+		t.Errorf("expected heading to link to the ticket, got %q", heading)
+	}
+
+	fields := msg.Blocks[1].Fields
+	if len(fields) != 2 || fields[0].Text != "*Status*\nActive" || fields[1].Text != "*Priority*\n#3" {
+		t.Errorf("unexpected fields: %+v", fields)
+	}
+}
+
+func TestHappinessMessage(t *testing.T) {
+	msg := HappinessMessage(webhooks.HappinessPayload{
+		ID:       4,
+		Rating:   models.HappinessRatingUnhappy,
+		Comment:  "Took <days>\nStill broken",
+		Ticket:   models.EntityRef{ID: 7},
+		Customer: models.EntityRef{ID: 2, Meta: map[string]any{"name": "Ada Lovelace"}},
+		Agent:    &models.EntityRef{ID: 5},
+	}, "https://example.teamwork.com/desk/tickets/7")
+
+	if len(msg.Blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(msg.Blocks))
+	}
+
+	heading := msg.Blocks[0].Text.Text
+	if heading != ":disappointed: *unhappy rating* on ticket <https://example.teamwork.com/desk/tickets/7|#7>" {
+		t.Errorf("unexpected heading %q", heading)
+	}
+
+	fields := msg.Blocks[1].Fields
+	if len(fields) != 2 || fields[0].Text != "*Customer*\nAda Lovelace" || fields[1].Text != "*Agent*\n#5" {
+		t.Errorf("unexpected fields: %+v", fields)
+	}
+
+	if comment := msg.Blocks[2].Text.Text; comment != "> Took &lt;days&gt;\n> Still broken" {
+		t.Errorf("expected the comment to be quoted and escaped, got %q", comment)
+	}
+	if msg.Text != "unhappy rating on ticket #7" {
+		t.Errorf("unexpected fallback text %q", msg.Text)
+	}
+}
+
+func TestHappinessMessageMinimal(t *testing.T) {
+	msg := HappinessMessage(webhooks.HappinessPayload{Rating: models.HappinessRatingHappy, Ticket: models.EntityRef{ID: 9}}, "")
+
+	if len(msg.Blocks) != 1 {
+		t.Fatalf("expected only the heading without customer, agent or comment, got %+v", msg.Blocks)
+	}
+	if heading := msg.Blocks[0].Text.Text; heading != ":smiley: *happy rating* on ticket #9" {
+		t.Errorf("unexpected heading %q", heading)
+	}
+}
+
+func TestNotifierPost(t *testing.T) {
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodPost, "/services/hook", http.StatusOK, "ok")
+
+	n := New("https://hooks.slack.com/services/hook", WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	subject := gofakeit.Sentence(4)
+	if err := n.PostTicket(context.Background(), "New ticket", models.Ticket{Subject: &subject}, ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	b, _ := io.ReadAll(requests[0].Body)
+	var sent Message
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if !strings.Contains(sent.Text, subject) {
+		t.Errorf("expected fallback text to contain subject, got %q", sent.Text)
+	}
+}
@@ -4,21 +4,68 @@ import (
 	"context"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
 	"log/slog"
+	"net/url"
 	"os"
-	"strconv"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/teamwork/desksdkgo/api"
+	"github.com/teamwork/desksdkgo/backup"
 	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/config"
+	"github.com/teamwork/desksdkgo/migrate"
 	"github.com/teamwork/desksdkgo/models"
 	"github.com/teamwork/desksdkgo/util"
 )
 
 func ptr[T any](v T) *T { return &v }
 
+// applyData merges the --data JSON into a resource
+func applyData(target any, data map[string]any) {
+	if err := models.ApplyPatch(target, data); err != nil {
+		fatalf("Invalid --data: %v", err)
+	}
+}
+
+// exitHooks run when the CLI exits, whether main returns or a fatal error
+// stops it, so reports such as the journal are written for failed runs too
+var exitHooks []func()
+
+// atExit registers fn to run when the CLI exits, latest first
+func atExit(fn func()) {
+	exitHooks = append(exitHooks, fn)
+}
+
+func runExitHooks() {
+	for i := len(exitHooks) - 1; i >= 0; i-- {
+		exitHooks[i]()
+	}
+	exitHooks = nil
+}
+
+// fatal is log.Fatal that runs the exit hooks first
+func fatal(v ...any) {
+	log.Print(v...)
+	runExitHooks()
+	os.Exit(1)
+}
+
+// fatalf is log.Fatalf that runs the exit hooks first
+func fatalf(format string, v ...any) {
+	log.Printf(format, v...)
+	runExitHooks()
+	os.Exit(1)
+}
+
 func deref[T any](v *T) T {
 	if v == nil {
 		var zero T
@@ -31,53 +78,154 @@ func main() {
 	// Load environment variables from .env file
 	util.LoadEnv()
 
-	// Define flags with environment variable fallbacks
-	apiKey := flag.String("api-key", util.GetEnv("DESK_API_KEY", ""), "Desk API key (can also be set via DESK_API_KEY env var)")
-	baseURL := flag.String("base-url", util.GetEnv("DESK_BASE_URL", "https://mycompany.teamwork.com/desk/api/v2"), "Desk API base URL (can also be set via DESK_BASE_URL env var)")
-	resource := flag.String("resource", util.GetEnv("DESK_RESOURCE", "tickets"), "Resource to interact with (tickets, messages, customers, companies, users) (can also be set via DESK_RESOURCE env var)")
-	action := flag.String("action", util.GetEnv("DESK_ACTION", "list"), "Action to perform (get, list, create, update) (can also be set via DESK_ACTION env var)")
-	envCount, _ := strconv.ParseInt(util.GetEnv("DESK_COUNT", "1"), 10, 64)
-	count := flag.Int("count", int(envCount), "Number of resources to create (default: 1)")
+	// Settings that can also come from the environment or a profile file
+	// are declared here for --help and resolved through config below
+	flag.String("api-key", "", "Desk API key (can also be set via DESK_API_KEY env var)")
+	flag.String("base-url", "https://mycompany.teamwork.com/desk/api/v2", "Desk API base URL (can also be set via DESK_BASE_URL env var)")
+	flag.String("resource", "tickets", "Resource to interact with (tickets, messages, customers, companies, users) (can also be set via DESK_RESOURCE env var)")
+	flag.String("action", "list", "Action to perform (get, list, create, update, replay, seed, tail, stats, open, backup, restore) (can also be set via DESK_ACTION env var)")
+	flag.Int("count", 1, "Number of resources to create (can also be set via DESK_COUNT env var)")
+	flag.Bool("debug", false, "Enable debug logging (can also be set via DESK_DEBUG env var)")
+	flag.String("ca-bundle", "", "PEM file of extra certificate authorities to trust, added to the system pool (can also be set via DESK_CA_BUNDLE env var)")
+	flag.Bool("beta", false, "Allow calls to beta endpoints, such as reports (can also be set via DESK_BETA env var)")
+	profile := flag.String("profile", os.Getenv("DESK_PROFILE"), "File of DESK_* settings used when neither a flag nor an env var is set (can also be set via DESK_PROFILE env var)")
 	id := flag.Int("id", 0, "Resource ID for get/update actions")
-	debug := flag.Bool("debug", false, "Enable debug logging")
 	data := flag.String("data", "", "JSON data to merge with default values for create/update actions")
+	harFile := flag.String("har", "", "Record all API traffic to this HAR file, with credentials redacted")
+	usage := flag.Bool("usage", false, "Print a summary of API requests per endpoint when finished")
+	journalFile := flag.String("journal", "", "Write every create, update and delete made to this file as JSON lines when finished")
+	replayFile := flag.String("file", "", "HAR file to send for the replay action")
+	browser := flag.Bool("browser", false, "Open the page in the default browser for the open action, instead of printing its URL")
+	inbox := flag.Int("inbox", 0, "Only show tickets in this inbox for the tail action")
+	dir := flag.String("dir", "", "Backup directory for the backup and restore actions")
+	attachments := flag.Bool("attachments", false, "Include ticket attachments in the backup action")
+	only := flag.String("resources", "", "Comma-separated resources for the backup and restore actions (default: all)")
+	onConflict := flag.String("on-conflict", "", "Conflict strategies for the restore action, e.g. skip,customers=external-id (skip, overwrite, duplicate, external-id)")
+	replayFrom := flag.String("replay-from", "", "Base URL the replayed HAR was captured against (default: same path on the recorded host)")
 	flag.Parse()
 
-	if action == nil || *action == "" {
-		log.Fatal("Action is required. Set it via --action flag or DESK_ACTION environment variable")
+	cfg, err := config.New(flag.CommandLine, config.WithProfile(*profile))
+	if err != nil {
+		fatal(err)
 	}
 
-	if *action != "create" {
-		*count = 1 // For get/list/update actions, count should be 1
+	apiKey := cfg.String("api-key", "")
+	baseURL := cfg.String("base-url", "https://mycompany.teamwork.com/desk/api/v2")
+	resource := cfg.String("resource", "tickets")
+	action := cfg.String("action", "list")
+	count := cfg.Int("count", 1)
+	debug := cfg.Bool("debug", false)
+	caBundle := cfg.String("ca-bundle", "")
+	beta := cfg.Bool("beta", false)
+	cfg.Require("api-key", "action")
+	if err := cfg.Err(); err != nil {
+		fatalf("Invalid configuration:\n%v", err)
 	}
 
-	// Validate required flags
-	if *apiKey == "" {
-		log.Fatal("API key is required. Set it via --api-key flag or DESK_API_KEY environment variable")
+	if action != "create" {
+		count = 1 // For get/list/update actions, count should be 1
 	}
 
 	// Create client
 	opts := []client.Option{}
-	if *debug {
+	if debug {
 		opts = append(opts, client.WithLogLevel(slog.LevelDebug))
 	}
-	opts = append(opts, client.WithAPIKey(*apiKey))
+	opts = append(opts, client.WithAPIKey(apiKey), client.WithRetryPolicy(client.DefaultRetryPolicy()))
+
+	if caBundle != "" {
+		pool, err := client.LoadCABundle(caBundle)
+		if err != nil {
+			fatalf("Failed to load CA bundle: %v", err)
+		}
+		opts = append(opts, client.WithRootCAs(pool))
+	}
 
-	c := client.NewClient(*baseURL, opts...)
+	if beta {
+		opts = append(opts, client.WithBetaEndpoints())
+	}
+
+	if *usage {
+		opts = append(opts, client.WithUsageTracking())
+	}
+
+	if *journalFile != "" {
+		opts = append(opts, client.WithJournal())
+	}
+
+	var recorder *client.HARRecorder
+	if *harFile != "" {
+		recorder = client.NewHARRecorder()
+		opts = append(opts, client.WithHARRecorder(recorder))
+	}
+
+	c := client.NewClient(baseURL, opts...)
 
 	// Create context
 	ctx := context.Background()
 
+	defer runExitHooks()
+
+	if *usage {
+		atExit(func() { printUsage(c.Usage()) })
+	}
+
+	if *journalFile != "" {
+		atExit(func() { writeJournal(c.Journal(), *journalFile) })
+	}
+
+	if recorder != nil {
+		atExit(func() {
+			if err := recorder.WriteFile(*harFile); err != nil {
+				log.Printf("Failed to write HAR file: %v", err)
+			}
+		})
+	}
+
+	if action == "replay" {
+		replay(ctx, c, *replayFile, *replayFrom)
+		return
+	}
+
+	if action == "tail" {
+		tail(ctx, c, resource, *inbox)
+		return
+	}
+
+	if action == "open" {
+		open(c, resource, *id, *browser)
+		return
+	}
+
+	if action == "stats" {
+		stats(ctx, c)
+		return
+	}
+
+	if action == "seed" {
+		seed(ctx, c)
+		return
+	}
+
+	if action == "backup" || action == "restore" {
+		var resources []string
+		if *only != "" {
+			resources = strings.Split(*only, ",")
+		}
+		runBackup(ctx, c, action, *dir, resources, *attachments, *onConflict)
+		return
+	}
+
 	// Parse JSON data if provided
 	var jsonData map[string]interface{}
 	if *data != "" {
 		if err := json.Unmarshal([]byte(*data), &jsonData); err != nil {
-			log.Fatalf("Failed to parse JSON data: %v", err)
+			fatalf("Failed to parse JSON data: %v", err)
 		}
 	}
 
-	resources := []string{*resource}
-	if *resource == "all" {
+	resources := []string{resource}
+	if resource == "all" {
 		resources = []string{
 			"businesshours",
 			"companies",
@@ -95,7 +243,330 @@ func main() {
 	}
 
 	for _, resource := range resources {
-		generateData(ctx, c, resource, *action, *count, *id, jsonData)
+		generateData(ctx, c, resource, action, count, *id, jsonData)
+	}
+}
+
+func printUsage(usage *client.Usage) {
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tENDPOINT\tREQUESTS\tERRORS\tRATE LIMITED")
+	for _, e := range usage.Endpoints {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", e.Method, e.Endpoint, e.Requests, e.Errors, e.RateLimited)
+	}
+	fmt.Fprintf(w, "\tTOTAL\t%d\t\t\n", usage.Requests)
+	w.Flush()
+
+	if usage.RateLimit > 0 {
+		fmt.Fprintf(os.Stderr, "Rate limit: %d of %d remaining\n", usage.RateLimitRemaining, usage.RateLimit)
+	}
+}
+
+func writeJournal(journal client.Journal, file string) {
+	f, err := os.Create(file)
+	if err != nil {
+		log.Printf("Failed to write journal: %v", err)
+		return
+	}
+	defer f.Close()
+
+	if err := journal.WriteJSON(f); err != nil {
+		log.Printf("Failed to write journal: %v", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Journal: %d changes written to %s\n", len(journal), file)
+}
+
+func replay(ctx context.Context, c *client.Client, file, from string) {
+	if file == "" {
+		fatal("A HAR file is required for replay. Set it via --file flag")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		fatalf("Failed to open HAR file: %v", err)
+	}
+	defer f.Close()
+
+	har, err := client.ReadHAR(f)
+	if err != nil {
+		fatalf("Failed to read HAR file: %v", err)
+	}
+
+	results, err := c.Replay(ctx, har, from)
+	if err != nil {
+		fatalf("Failed to replay HAR file: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(results)
+}
+
+const (
+	colorReset  = "\033[0m"
+	colorDim    = "\033[2m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+func tail(ctx context.Context, c *client.Client, resource string, inbox int) {
+	if resource != "tickets" {
+		fatalf("The tail action only supports tickets, got %q", resource)
+	}
+
+	filter := &models.SearchTicketsFilter{}
+	if inbox > 0 {
+		filter.Inboxes = []int64{int64(inbox)}
+	}
+
+	rt, err := client.NewRealtime(c, client.RealtimeOptions{Filter: filter, Interval: 5 * time.Second})
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	go rt.Run(ctx)
+
+	color := os.Getenv("NO_COLOR") == ""
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+
+	errs := rt.Errors()
+	for {
+		select {
+		case e, ok := <-rt.Tickets():
+			if !ok {
+				return
+			}
+
+			kind := paint(colorYellow, "updated")
+			if e.Type == client.TicketEventCreated {
+				kind = paint(colorGreen, "created")
+			}
+
+			t := e.Ticket
+			at := time.Now()
+			if t.UpdatedAt != nil {
+				at = t.UpdatedAt.Local()
+			}
+
+			var status, agent string
+			if t.Status != nil {
+				status = fmt.Sprintf(" status=%d", t.Status.ID)
+			}
+			if t.Agent != nil {
+				agent = fmt.Sprintf(" agent=%d", t.Agent.ID)
+			}
+
+			fmt.Printf("%s %s #%d %s%s\n",
+				paint(colorDim, at.Format(time.TimeOnly)),
+				kind,
+				t.ID,
+				deref(t.Subject),
+				paint(colorDim, status+agent),
+			)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "tail: %v\n", err)
+		}
+	}
+}
+
+func open(c *client.Client, resource string, id int, browser bool) {
+	if id == 0 {
+		fatal("ID is required for open action")
+	}
+
+	link, err := c.WebURL(resource, id)
+	if err != nil {
+		fatal(err)
+	}
+
+	if !browser {
+		fmt.Println(link)
+		return
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", link)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		cmd = exec.Command("xdg-open", link)
+	}
+	if err := cmd.Start(); err != nil {
+		fatalf("Failed to open browser: %v", err)
+	}
+}
+
+// statGroup is a set of ticket counts broken down by one dimension
+type statGroup struct {
+	name string
+	rows []statRow
+}
+
+type statRow struct {
+	label string
+	count int
+}
+
+func stats(ctx context.Context, c *client.Client) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	total, err := c.Tickets.Count(ctx, nil)
+	if err != nil {
+		fatalf("Failed to count tickets: %v", err)
+	}
+
+	since := time.Now().AddDate(0, 0, -7)
+	recent, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{StartDate: &since})
+	if err != nil {
+		fatalf("Failed to count recent tickets: %v", err)
+	}
+
+	fmt.Fprintf(w, "Tickets\t%d\n", total)
+	fmt.Fprintf(w, "Created in the last 7 days\t%d\n", recent)
+
+	var groups []statGroup
+
+	group := statGroup{name: "By status"}
+	err = c.TicketStatuses.ListAllFunc(ctx, nil, func(page *models.TicketStatusesResponse) error {
+		for _, st := range page.TicketStatuses {
+			n, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{Statuses: []int64{int64(st.ID)}})
+			if err != nil {
+				return fmt.Errorf("count tickets for status %d: %w", st.ID, err)
+			}
+			group.rows = append(group.rows, statRow{label: deref(st.Name), count: n})
+		}
+		return nil
+	})
+	if err != nil {
+		fatalf("Failed to list ticket statuses: %v", err)
+	}
+	groups = append(groups, group)
+
+	sources, err := c.TicketSources.TicketCounts(ctx, nil)
+	if err != nil {
+		fatalf("Failed to count tickets by source: %v", err)
+	}
+	group = statGroup{name: "By source"}
+	for _, sc := range sources {
+		group.rows = append(group.rows, statRow{label: deref(sc.Source.Name), count: sc.Count})
+	}
+	groups = append(groups, group)
+
+	group = statGroup{name: "By inbox"}
+	err = c.Inboxes.ListAllFunc(ctx, nil, func(page *models.InboxesResponse) error {
+		for _, in := range page.Inboxes {
+			n, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{Inboxes: []int64{int64(in.ID)}})
+			if err != nil {
+				return fmt.Errorf("count tickets for inbox %d: %w", in.ID, err)
+			}
+			group.rows = append(group.rows, statRow{label: deref(in.Name), count: n})
+		}
+		return nil
+	})
+	if err != nil {
+		fatalf("Failed to list inboxes: %v", err)
+	}
+	groups = append(groups, group)
+
+	group = statGroup{name: "By agent"}
+	err = c.Users.ListAllFunc(ctx, nil, func(page *models.UsersResponse) error {
+		for _, u := range page.Users {
+			n, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{Agents: []int64{int64(u.ID)}})
+			if err != nil {
+				return fmt.Errorf("count tickets for agent %d: %w", u.ID, err)
+			}
+			group.rows = append(group.rows, statRow{label: strings.TrimSpace(deref(u.FirstName) + " " + deref(u.LastName)), count: n})
+		}
+		return nil
+	})
+	if err != nil {
+		fatalf("Failed to list users: %v", err)
+	}
+	unassigned, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{Unassigned: true})
+	if err != nil {
+		fatalf("Failed to count unassigned tickets: %v", err)
+	}
+	group.rows = append(group.rows, statRow{label: "Unassigned", count: unassigned})
+	groups = append(groups, group)
+
+	for _, g := range groups {
+		fmt.Fprintf(w, "\n%s\t\n", g.name)
+		for _, r := range g.rows {
+			fmt.Fprintf(w, "  %s\t%d\n", r.label, r.count)
+		}
+	}
+}
+
+func seed(ctx context.Context, c *client.Client) {
+	statuses, err := c.TicketStatuses.EnsureDefaults(ctx)
+	if err != nil {
+		fatalf("Failed to seed ticket statuses: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(statuses)
+}
+
+func runBackup(ctx context.Context, c *client.Client, action, dir string, resources []string, attachments bool, onConflict string) {
+	if dir == "" {
+		fatalf("--dir is required for the %s action", action)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if action == "backup" {
+		manifest, err := backup.Backup(ctx, c, dir, backup.Options{Resources: resources, Attachments: attachments})
+		if err != nil {
+			fatalf("Backup failed: %v", err)
+		}
+		enc.Encode(manifest)
+		return
+	}
+
+	conflict, conflicts, err := backup.ParseStrategies(onConflict)
+	if err != nil {
+		fatalf("Invalid --on-conflict: %v", err)
+	}
+
+	// Restored IDs are kept per target installation so an interrupted
+	// restore can be rerun without creating duplicates
+	target, err := url.Parse(c.BaseURL())
+	if err != nil {
+		fatalf("Invalid base URL: %v", err)
+	}
+	ids, err := migrate.OpenFile(filepath.Join(dir, "restore-"+target.Hostname()+".json"))
+	if err != nil {
+		fatalf("Failed to open restore ID map: %v", err)
+	}
+
+	report, err := backup.Restore(ctx, c, dir, backup.RestoreOptions{
+		Resources: resources,
+		IDs:       ids,
+		Conflict:  conflict,
+		Conflicts: conflicts,
+	})
+	if report != nil {
+		enc.Encode(report)
+	}
+	if err != nil {
+		fatalf("Restore failed: %v", err)
 	}
 }
 
@@ -109,490 +580,416 @@ func generateData(
 	jsonData map[string]any,
 ) {
 	// Execute action based on resource and action
-	for range count {
-		switch strings.ToLower(resource) {
-		case "tickets":
-			if strings.EqualFold(action, "search") {
-				filter := &models.SearchTicketsFilter{
-					Search: "Test",
-				}
-				resp, err := c.Tickets.Search(ctx, filter)
-				if err != nil {
-					log.Fatalf("Failed to search tickets: %v", err)
-				}
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				enc.Encode(resp)
-				return
+	switch strings.ToLower(resource) {
+	case "tickets":
+		if strings.EqualFold(action, "search") {
+			filter := &models.SearchTicketsFilter{
+				Search: "Test",
+			}
+			resp, err := c.Tickets.Search(ctx, filter)
+			if err != nil {
+				fatalf("Failed to search tickets: %v", err)
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(resp)
+			return
+		}
+		api.Call(ctx, c.Tickets, action, id, count, func() *models.TicketResponse {
+			inboxes, err := c.Inboxes.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list inboxes: %v", err)
 			}
-			api.Call(ctx, c.Tickets, action, id, func() *models.TicketResponse {
-				inboxes, err := c.Inboxes.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list inboxes: %v", err)
-				}
 
-				if len(inboxes.Inboxes) == 0 {
-					log.Fatal("No inboxes found. Please create an inbox first.")
-				}
+			if len(inboxes.Inboxes) == 0 {
+				fatal("No inboxes found. Please create an inbox first.")
+			}
 
-				customers, err := c.Customers.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list customers: %v", err)
-				}
+			customers, err := c.Customers.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list customers: %v", err)
+			}
 
-				if len(customers.Customers) == 0 {
-					log.Fatal("No customers found. Please create a customer first.")
-				}
+			if len(customers.Customers) == 0 {
+				fatal("No customers found. Please create a customer first.")
+			}
 
-				types, err := c.TicketTypes.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list ticket types: %v", err)
-				}
+			types, err := c.TicketTypes.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list ticket types: %v", err)
+			}
 
-				var t models.TicketType
-				for _, tt := range types.TicketTypes {
-					for _, ibx := range inboxes.Inboxes {
-						for _, ttibx := range tt.Inboxes {
-							if ttibx.ID == ibx.ID {
-								t = tt
-								break
-							}
+			var t models.TicketType
+			for _, tt := range types.TicketTypes {
+				for _, ibx := range inboxes.Inboxes {
+					for _, ttibx := range tt.Inboxes {
+						if ttibx.ID == ibx.ID {
+							t = tt
+							break
 						}
 					}
 				}
+			}
 
-				if t.ID == 0 {
-					log.Fatal("No ticket types associated with the available inboxes.")
-				}
+			if t.ID == 0 {
+				fatal("No ticket types associated with the available inboxes.")
+			}
 
-				if len(types.TicketTypes) == 0 {
-					log.Fatal("No ticket types found. Please create a ticket type first.")
-				}
+			if len(types.TicketTypes) == 0 {
+				fatal("No ticket types found. Please create a ticket type first.")
+			}
 
-				sources, err := c.TicketSources.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list ticket sources: %v", err)
-				}
+			sources, err := c.TicketSources.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list ticket sources: %v", err)
+			}
 
-				if len(sources.TicketSources) == 0 {
-					log.Fatal("No ticket sources found. Please create a ticket source first.")
-				}
+			if len(sources.TicketSources) == 0 {
+				fatal("No ticket sources found. Please create a ticket source first.")
+			}
 
-				statuses, err := c.TicketStatuses.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list ticket statuses: %v", err)
-				}
+			statuses, err := c.TicketStatuses.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list ticket statuses: %v", err)
+			}
 
-				if len(statuses.TicketStatuses) == 0 {
-					log.Fatal("No ticket statuses found. Please create a ticket status first.")
-				}
+			if len(statuses.TicketStatuses) == 0 {
+				fatal("No ticket statuses found. Please create a ticket status first.")
+			}
 
-				agents, err := c.Users.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list users: %v", err)
-				}
+			agents, err := c.Users.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list users: %v", err)
+			}
 
-				if len(agents.Users) == 0 {
-					log.Fatal("No users found. Please create a user first.")
-				}
+			if len(agents.Users) == 0 {
+				fatal("No users found. Please create a user first.")
+			}
 
-				resp := &models.TicketResponse{Ticket: models.Ticket{
-					Subject:           ptr(gofakeit.Sentence(1)),
-					PreviewText:       ptr(gofakeit.Paragraph(1, 2, 3, " ")),
-					OriginalRecipient: ptr(gofakeit.Email()),
-					Inbox: &models.EntityRef{
-						ID: inboxes.Inboxes[0].ID,
-					},
-					Customer: &models.EntityRef{
-						ID: customers.Customers[0].ID,
-					},
-					Body: ptr(gofakeit.Paragraph(3, 5, 10, "\n")),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Ticket, jsonData)
-				}
-				return resp
-			})
-		case "customers":
-			api.Call(ctx, c.Customers, action, id, func() *models.CustomerResponse {
-				email := gofakeit.Email()
-				resp := &models.CustomerResponse{
-					Customer: models.Customer{
-						FirstName: ptr(gofakeit.FirstName()),
-						LastName:  ptr(gofakeit.LastName()),
-						Email:     ptr(email),
-					},
-					Included: models.IncludedData{
-						Contacts: []models.Contact{
-							{
-								BaseEntity: models.BaseEntity{
-									Type: "email",
-								},
-								Value:  ptr(email),
-								IsMain: ptr(true),
-							},
-						},
-					},
-				}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Customer, jsonData)
-				}
-				return resp
-			})
-		case "companies":
-			api.Call(ctx, c.Companies, action, id, func() *models.CompanyResponse {
-				resp := &models.CompanyResponse{
-					Company: models.Company{
-						Name:        ptr(gofakeit.Company()),
-						Description: ptr(gofakeit.Paragraph(1, 2, 3, " ")),
-					},
-					Included: models.IncludedData{
-						Domains: []models.Domain{
-							{
-								Name: ptr(gofakeit.DomainName()),
-							},
-						},
-					},
-				}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Company, jsonData)
-				}
-				return resp
-			})
-		case "users":
-			api.Call(ctx, c.Users, action, id, func() *models.UserResponse {
-				resp := &models.UserResponse{User: models.User{
+			resp := &models.TicketResponse{Ticket: models.Ticket{
+				Subject:           ptr(gofakeit.Sentence(1)),
+				PreviewText:       ptr(gofakeit.Paragraph(1, 2, 3, " ")),
+				OriginalRecipient: ptr(gofakeit.Email()),
+				Inbox: &models.EntityRef{
+					ID: inboxes.Inboxes[0].ID,
+				},
+				Customer: &models.EntityRef{
+					ID: customers.Customers[0].ID,
+				},
+				Body: ptr(gofakeit.Paragraph(3, 5, 10, "\n")),
+			}}
+			if jsonData != nil {
+				applyData(&resp.Ticket, jsonData)
+			}
+			return resp
+		})
+	case "customers":
+		api.Call(ctx, c.Customers, action, id, count, func() *models.CustomerResponse {
+			email := gofakeit.Email()
+			resp := &models.CustomerResponse{
+				Customer: models.Customer{
 					FirstName: ptr(gofakeit.FirstName()),
 					LastName:  ptr(gofakeit.LastName()),
-					Email:     ptr(gofakeit.Email()),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.User, jsonData)
-				}
-				return resp
-			})
-		case "tags":
-			api.Call(ctx, c.Tags, action, id, func() *models.TagResponse {
-				resp := &models.TagResponse{Tag: models.Tag{
-					Name: ptr(gofakeit.Word()),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Tag, jsonData)
-				}
-				return resp
-			})
-		case "messages":
-			api.Call(ctx, c.Messages, action, id, func() *models.MessageResponse {
-				tickets, err := c.Tickets.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list tickets: %v", err)
-				}
-
-				if len(tickets.Tickets) == 0 {
-					log.Fatal("No tickets found. Please create a ticket first.")
-				}
-
-				resp := &models.MessageResponse{Message: models.Message{
-					Message: ptr(gofakeit.Paragraph(1, 2, 5, " ")),
-					Ticket: models.EntityRef{
-						ID: tickets.Tickets[0].ID,
-					},
-				}}
-
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Message, jsonData)
-				}
-
-				return resp
-			})
-		case "files":
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			f := &models.FileResponse{File: models.File{
-				Filename:    ptr(gofakeit.LoremIpsumWord() + "." + gofakeit.FileExtension()),
-				MIMEType:    ptr("image/jpeg"),
-				Type:        ptr(models.FileTypeAttachment),
-				Disposition: ptr(models.DispositionAttachment),
+					Email:     ptr(email),
+				},
+				Included: models.Include(models.WithRelatedContacts(models.EmailContact(email, true))),
+			}
+			if jsonData != nil {
+				applyData(&resp.Customer, jsonData)
+			}
+			return resp
+		})
+	case "companies":
+		api.Call(ctx, c.Companies, action, id, count, func() *models.CompanyResponse {
+			resp := &models.CompanyResponse{
+				Company: models.Company{
+					Name:        ptr(gofakeit.Company()),
+					Description: ptr(gofakeit.Paragraph(1, 2, 3, " ")),
+				},
+				Included: models.Include(models.WithDomains(gofakeit.DomainName())),
+			}
+			if jsonData != nil {
+				applyData(&resp.Company, jsonData)
+			}
+			return resp
+		})
+	case "users":
+		api.Call(ctx, c.Users, action, id, count, func() *models.UserResponse {
+			resp := &models.UserResponse{User: models.User{
+				FirstName: ptr(gofakeit.FirstName()),
+				LastName:  ptr(gofakeit.LastName()),
+				Email:     ptr(gofakeit.Email()),
 			}}
 			if jsonData != nil {
-				util.MergeJSONData(&f.File, jsonData)
+				applyData(&resp.User, jsonData)
 			}
-
-			resp, err := c.Files.Create(ctx, f)
-			if err != nil {
-				log.Fatalf("Failed to create file reference: %v", err)
+			return resp
+		})
+	case "tags":
+		api.Call(ctx, c.Tags, action, id, count, func() *models.TagResponse {
+			resp := &models.TagResponse{Tag: models.Tag{
+				Name: ptr(gofakeit.Word()),
+			}}
+			if jsonData != nil {
+				applyData(&resp.Tag, jsonData)
 			}
-
-			err = c.Files.Upload(ctx, resp, []byte(gofakeit.ImageJpeg(800, 600)))
+			return resp
+		})
+	case "messages":
+		api.Call(ctx, c.Messages, action, id, count, func() *models.MessageResponse {
+			tickets, err := c.Tickets.List(ctx, nil)
 			if err != nil {
-				log.Fatalf("Failed to upload file: %v", err)
+				fatalf("Failed to list tickets: %v", err)
 			}
 
-			enc.Encode(resp)
-		case "spamlists":
-			api.Call(ctx, c.Spamlists, action, id, func() *models.SpamlistResponse {
-				resp := &models.SpamlistResponse{Spamlist: models.Spamlist{
-					Term: ptr(gofakeit.Email()),
-					Type: ptr("blacklist"),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Spamlist, jsonData)
-				}
-				return resp
-			})
-		case "statuses":
-			api.Call(ctx, c.TicketStatuses, action, id, func() *models.TicketStatusResponse {
-				resp := &models.TicketStatusResponse{TicketStatus: models.TicketStatus{
-					Name: ptr(gofakeit.Word()),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.TicketStatus, jsonData)
-				}
-				return resp
-			})
-		case "types":
-			api.Call(ctx, c.TicketTypes, action, id, func() *models.TicketTypeResponse {
-				resp := &models.TicketTypeResponse{TicketType: models.TicketType{
-					Name: ptr(gofakeit.Word()),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.TicketType, jsonData)
-				}
-				return resp
-			})
-		case "priorities":
-			api.Call(ctx, c.TicketPriorities, action, id, func() *models.TicketPriorityResponse {
-				resp := &models.TicketPriorityResponse{TicketPriority: models.TicketPriority{
-					Name:  ptr(gofakeit.Word()),
-					Color: ptr(gofakeit.SafeColor()),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.TicketPriority, jsonData)
-				}
-				return resp
-			})
-		case "helpdocsites":
-			api.Call(ctx, c.HelpDocSites, action, id, func() *models.HelpDocSiteResponse {
-				resp := &models.HelpDocSiteResponse{HelpDocSite: models.HelpDocSite{
-					Name: ptr(gofakeit.Company() + " Help Center"),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.HelpDocSite, jsonData)
-				}
-				return resp
-			})
-		case "helpdocarticles":
-			api.Call(ctx, c.HelpDocArticles, action, id, func() *models.HelpDocArticleResponse {
-				resp := &models.HelpDocArticleResponse{HelpDocArticle: models.HelpDocArticle{
-					Title:    ptr(gofakeit.Sentence(5)),
-					Contents: ptr(gofakeit.Paragraph(3, 5, 10, "\n")),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.HelpDocArticle, jsonData)
-				}
-				return resp
-			})
-		case "businesshours":
-			api.Call(ctx, c.BusinessHours, action, id, func() *models.BusinessHourResponse {
-				resp := &models.BusinessHourResponse{BusinessHour: models.BusinessHour{
-					Name:      ptr(gofakeit.Company() + " Business Hours"),
-					IsDefault: ptr(true),
-				}}
-				if jsonData != nil {
-					util.MergeJSONData(&resp.BusinessHour, jsonData)
-				}
-				return resp
-			})
-
-		case "inboxes":
-			api.Call(ctx, c.Inboxes, action, id, func() *models.InboxResponse {
-				users, err := c.Users.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list users: %v", err)
-				}
-				if len(users.Users) == 0 {
-					log.Fatal("No users found. Please create a user first.")
-				}
+			if len(tickets.Tickets) == 0 {
+				fatal("No tickets found. Please create a ticket first.")
+			}
 
-				resp := &models.InboxResponse{Inbox: models.Inbox{
-					Name:      ptr(gofakeit.Company() + " Inbox"),
-					Email:     ptr(gofakeit.Email()),
-					LocalPart: ptr(strings.SplitN(gofakeit.Email(), "@", 2)[0]),
-				}}
-
-				for _, user := range users.Users {
-					resp.Inbox.Users = append(resp.Inbox.Users, models.InboxUser{
-						EntityRef: models.EntityRef{
-							ID: user.ID,
-						},
-						Meta: models.InboxMeta{
-							Access: ptr("write"),
-						},
-					})
-				}
+			resp := &models.MessageResponse{Message: models.Message{
+				Message: ptr(gofakeit.Paragraph(1, 2, 5, " ")),
+				Ticket: models.EntityRef{
+					ID: tickets.Tickets[0].ID,
+				},
+			}}
 
-				if jsonData != nil {
-					util.MergeJSONData(&resp.Inbox, jsonData)
-				}
-				return resp
-			})
-		case "slas":
-			api.Call(ctx, c.SLAs, action, id, func() *models.SLAResponse {
-				priorities, err := c.TicketPriorities.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list ticketpriorities: %v", err)
-				}
+			if jsonData != nil {
+				applyData(&resp.Message, jsonData)
+			}
 
-				if len(priorities.TicketPriorities) == 0 {
-					log.Fatal("No ticketpriorities found. Please create a ticketpriority first.")
-				}
+			return resp
+		})
+	case "files":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		f := &models.FileResponse{File: models.File{
+			Filename:    ptr(gofakeit.LoremIpsumWord() + "." + gofakeit.FileExtension()),
+			MIMEType:    ptr("image/jpeg"),
+			Type:        ptr(models.FileTypeAttachment),
+			Disposition: ptr(models.DispositionAttachment),
+		}}
+		if jsonData != nil {
+			util.MergeJSONData(&f.File, jsonData)
+		}
 
-				tags, err := c.Tags.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list tags: %v", err)
-				}
+		resp, err := c.Files.Create(ctx, f)
+		if err != nil {
+			fatalf("Failed to create file reference: %v", err)
+		}
 
-				if len(tags.Tags) == 0 {
-					log.Fatal("No tags found. Please create a tag first.")
-				}
+		err = c.Files.Upload(ctx, resp, []byte(gofakeit.ImageJpeg(800, 600)))
+		if err != nil {
+			fatalf("Failed to upload file: %v", err)
+		}
 
-				companies, err := c.Companies.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list companies: %v", err)
-				}
+		enc.Encode(resp)
+	case "spamlists":
+		api.Call(ctx, c.Spamlists, action, id, count, func() *models.SpamlistResponse {
+			resp := &models.SpamlistResponse{Spamlist: models.Spamlist{
+				Term: ptr(gofakeit.Email()),
+				Type: ptr(models.SpamlistTypeBlacklist),
+			}}
+			if jsonData != nil {
+				applyData(&resp.Spamlist, jsonData)
+			}
+			return resp
+		})
+	case "statuses":
+		api.Call(ctx, c.TicketStatuses, action, id, count, func() *models.TicketStatusResponse {
+			resp := &models.TicketStatusResponse{TicketStatus: models.TicketStatus{
+				Name: ptr(gofakeit.Word()),
+			}}
+			if jsonData != nil {
+				applyData(&resp.TicketStatus, jsonData)
+			}
+			return resp
+		})
+	case "types":
+		api.Call(ctx, c.TicketTypes, action, id, count, func() *models.TicketTypeResponse {
+			resp := &models.TicketTypeResponse{TicketType: models.TicketType{
+				Name: ptr(gofakeit.Word()),
+			}}
+			if jsonData != nil {
+				applyData(&resp.TicketType, jsonData)
+			}
+			return resp
+		})
+	case "priorities":
+		api.Call(ctx, c.TicketPriorities, action, id, count, func() *models.TicketPriorityResponse {
+			resp := &models.TicketPriorityResponse{TicketPriority: models.TicketPriority{
+				Name:  ptr(gofakeit.Word()),
+				Color: ptr(gofakeit.SafeColor()),
+			}}
+			if jsonData != nil {
+				applyData(&resp.TicketPriority, jsonData)
+			}
+			return resp
+		})
+	case "helpdocsites":
+		api.Call(ctx, c.HelpDocSites, action, id, count, func() *models.HelpDocSiteResponse {
+			resp := &models.HelpDocSiteResponse{HelpDocSite: models.HelpDocSite{
+				Name: ptr(gofakeit.Company() + " Help Center"),
+			}}
+			if jsonData != nil {
+				applyData(&resp.HelpDocSite, jsonData)
+			}
+			return resp
+		})
+	case "helpdocarticles":
+		api.Call(ctx, c.HelpDocArticles, action, id, count, func() *models.HelpDocArticleResponse {
+			resp := &models.HelpDocArticleResponse{HelpDocArticle: models.HelpDocArticle{
+				Title:    ptr(gofakeit.Sentence(5)),
+				Contents: ptr(gofakeit.Paragraph(3, 5, 10, "\n")),
+			}}
+			if jsonData != nil {
+				applyData(&resp.HelpDocArticle, jsonData)
+			}
+			return resp
+		})
+	case "businesshours":
+		api.Call(ctx, c.BusinessHours, action, id, count, func() *models.BusinessHourResponse {
+			resp := &models.BusinessHourResponse{BusinessHour: models.BusinessHour{
+				Name:      ptr(gofakeit.Company() + " Business Hours"),
+				IsDefault: ptr(true),
+			}}
+			if jsonData != nil {
+				applyData(&resp.BusinessHour, jsonData)
+			}
+			return resp
+		})
 
-				if len(companies.Companies) == 0 {
-					log.Fatal("No companies found. Please create a company first.")
-				}
+	case "inboxes":
+		api.Call(ctx, c.Inboxes, action, id, count, func() *models.InboxResponse {
+			users, err := c.Users.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list users: %v", err)
+			}
+			if len(users.Users) == 0 {
+				fatal("No users found. Please create a user first.")
+			}
 
-				customers, err := c.Customers.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list customers: %v", err)
-				}
+			resp := &models.InboxResponse{Inbox: models.Inbox{
+				Name:      ptr(gofakeit.Company() + " Inbox"),
+				Email:     ptr(gofakeit.Email()),
+				LocalPart: ptr(strings.SplitN(gofakeit.Email(), "@", 2)[0]),
+			}}
 
-				if len(customers.Customers) == 0 {
-					log.Fatal("No customers found. Please create a customer first.")
-				}
+			for _, user := range users.Users {
+				resp.Inbox.Users = append(resp.Inbox.Users, models.InboxUser{
+					EntityRef: models.EntityRef{
+						ID: user.ID,
+					},
+					Meta: models.InboxMeta{
+						Access: ptr(models.InboxAccessWrite),
+					},
+				})
+			}
 
-				inboxes, err := c.Inboxes.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list inboxes: %v", err)
-				}
+			if jsonData != nil {
+				applyData(&resp.Inbox, jsonData)
+			}
+			return resp
+		})
+	case "slas":
+		api.Call(ctx, c.SLAs, action, id, count, func() *models.SLAResponse {
+			priorities, err := c.TicketPriorities.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list ticketpriorities: %v", err)
+			}
 
-				if len(inboxes.Inboxes) == 0 {
-					log.Fatal("No inboxes found. Please create an inbox first.")
-				}
+			if len(priorities.TicketPriorities) == 0 {
+				fatal("No ticketpriorities found. Please create a ticketpriority first.")
+			}
 
-				businesshours, err := c.BusinessHours.List(ctx, nil)
-				if err != nil {
-					log.Fatalf("Failed to list businesshours: %v", err)
-				}
+			tags, err := c.Tags.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list tags: %v", err)
+			}
 
-				if len(businesshours.BusinessHours) == 0 {
-					log.Fatal("No businesshours found. Please create a businesshour first.")
-				}
+			if len(tags.Tags) == 0 {
+				fatal("No tags found. Please create a tag first.")
+			}
 
-				resp := &models.SLAResponse{
-					SLA: models.SLA{
-						Name: ptr(gofakeit.Company() + " SLA Policy"),
-						BusinessHour: &models.EntityRef{
-							ID: businesshours.BusinessHours[0].ID,
-						},
-					},
-					Included: models.IncludedData{
-						SLANotifications: []models.SLANotification{
-							{
-								Condition:          ptr(models.SLANotificationConditionTypeWarning),
-								Type:               ptr(models.SLANotificationTypeFirstResponse),
-								Duration:           ptr(gofakeit.Number(1, 10)),
-								NotifyAssignedUser: ptr(true),
-							},
-							{
-								Condition:          ptr(models.SLANotificationConditionTypeBreach),
-								Type:               ptr(models.SLANotificationTypeFirstResponse),
-								Duration:           ptr(0),
-								NotifyAssignedUser: ptr(true),
-							},
-						},
-					},
-				}
+			companies, err := c.Companies.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list companies: %v", err)
+			}
 
-				for _, priority := range priorities.TicketPriorities {
-					resp.Included.SLAPriorities = append(resp.Included.SLAPriorities, models.SLATicketPriority{
-						Hours:       ptr(gofakeit.Number(1, 10)),
-						Minutes:     ptr(gofakeit.Number(1, 59)),
-						Description: ptr("SLA for " + deref(priority.Name)),
-						TicketPriority: &models.EntityRef{
-							ID: priority.ID,
-						},
-					})
-				}
+			if len(companies.Companies) == 0 {
+				fatal("No companies found. Please create a company first.")
+			}
 
-				resp.Included.SLAPriorities = append(resp.Included.SLAPriorities, models.SLATicketPriority{
-					Hours:       ptr(gofakeit.Number(1, 10)),
-					Minutes:     ptr(gofakeit.Number(1, 59)),
-					Description: ptr("SLA for None"),
-				})
+			customers, err := c.Customers.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list customers: %v", err)
+			}
 
-				for _, inbox := range inboxes.Inboxes {
-					resp.Included.SLAInboxes = append(resp.Included.SLAInboxes, models.SLAInbox{
-						Inbox: &models.EntityRef{
-							ID: inbox.ID,
-						},
-						Condition: ptr(models.SLAConditionOptionEqual),
-					})
+			if len(customers.Customers) == 0 {
+				fatal("No customers found. Please create a customer first.")
+			}
 
-					if len(resp.Included.SLAInboxes) > 4 {
-						break
-					}
-				}
+			inboxes, err := c.Inboxes.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list inboxes: %v", err)
+			}
 
-				for _, company := range companies.Companies {
-					resp.Included.SLACompanies = append(resp.Included.SLACompanies, models.SLACompany{
-						Company: &models.EntityRef{
-							ID: company.ID,
-						},
-						Condition: ptr(models.SLAConditionOptionEqual),
-					})
+			if len(inboxes.Inboxes) == 0 {
+				fatal("No inboxes found. Please create an inbox first.")
+			}
 
-					if len(resp.Included.SLACompanies) > 4 {
-						break
-					}
-				}
+			businesshours, err := c.BusinessHours.List(ctx, nil)
+			if err != nil {
+				fatalf("Failed to list businesshours: %v", err)
+			}
 
-				for _, customer := range customers.Customers {
-					resp.Included.SLACustomers = append(resp.Included.SLACustomers, models.SLACustomer{
-						Customer: &models.EntityRef{
-							ID: customer.ID,
-						},
-						Condition: ptr(models.SLAConditionOptionEqual),
-					})
+			if len(businesshours.BusinessHours) == 0 {
+				fatal("No businesshours found. Please create a businesshour first.")
+			}
 
-					if len(resp.Included.SLACustomers) > 3 {
-						break
-					}
-				}
+			resp := &models.SLAResponse{
+				SLA: models.SLA{
+					Name: ptr(gofakeit.Company() + " SLA Policy"),
+					BusinessHour: &models.EntityRef{
+						ID: businesshours.BusinessHours[0].ID,
+					},
+				},
+				Included: models.Include(
+					models.WithSLANotification(models.SLANotificationConditionTypeWarning, models.SLANotificationTypeFirstResponse, gofakeit.Number(1, 10)),
+					models.WithSLANotification(models.SLANotificationConditionTypeBreach, models.SLANotificationTypeFirstResponse, 0),
+				),
+			}
 
-				for _, tag := range tags.Tags {
-					resp.Included.SLATags = append(resp.Included.SLATags, models.SLATag{
-						Tag: &models.EntityRef{
-							ID: tag.ID,
-						},
-						Condition: ptr(models.SLAConditionOptionEqual),
-					})
+			for _, priority := range priorities.TicketPriorities {
+				resp.Included.Add(models.WithSLAPriority(priority.ID, gofakeit.Number(1, 10), gofakeit.Number(1, 59), "SLA for "+deref(priority.Name)))
+			}
+			resp.Included.Add(models.WithSLAPriority(0, gofakeit.Number(1, 10), gofakeit.Number(1, 59), "SLA for None"))
 
-					if len(resp.Included.SLATags) > 6 {
-						break
-					}
-				}
+			var inboxIDs, companyIDs, customerIDs, tagIDs []int
+			for _, inbox := range inboxes.Inboxes[:min(len(inboxes.Inboxes), 5)] {
+				inboxIDs = append(inboxIDs, inbox.ID)
+			}
+			for _, company := range companies.Companies[:min(len(companies.Companies), 5)] {
+				companyIDs = append(companyIDs, company.ID)
+			}
+			for _, customer := range customers.Customers[:min(len(customers.Customers), 4)] {
+				customerIDs = append(customerIDs, customer.ID)
+			}
+			for _, tag := range tags.Tags[:min(len(tags.Tags), 7)] {
+				tagIDs = append(tagIDs, tag.ID)
+			}
+			resp.Included.Add(
+				models.WithSLAInboxes(models.SLAConditionOptionEqual, inboxIDs...),
+				models.WithSLACompanies(models.SLAConditionOptionEqual, companyIDs...),
+				models.WithSLACustomers(models.SLAConditionOptionEqual, customerIDs...),
+				models.WithSLATags(models.SLAConditionOptionEqual, tagIDs...),
+			)
 
-				if jsonData != nil {
-					util.MergeJSONData(&resp.SLA, jsonData)
-				}
-				return resp
-			})
-		default:
-			log.Fatalf("Unsupported resource: %s", resource)
-		}
+			if jsonData != nil {
+				applyData(&resp.SLA, jsonData)
+			}
+			return resp
+		})
+	default:
+		fatalf("Unsupported resource: %s", resource)
 	}
 }
@@ -0,0 +1,167 @@
+// Package migrate helps import data from other help desks into Desk. An IDMap
+// records which Desk resource each source-system record became, so imports
+// can be rerun safely and relationships between records can be rebuilt.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Kind is the type of resource a mapping belongs to. Source IDs only need to
+// be unique within a kind.
+type Kind string
+
+const (
+	KindCompany  Kind = "company"
+	KindCustomer Kind = "customer"
+	KindTicket   Kind = "ticket"
+	KindMessage  Kind = "message"
+	KindUser     Kind = "user"
+)
+
+// IDMap stores source-system ID to Desk ID mappings. Implementations must be
+// safe for concurrent use.
+type IDMap interface {
+	// Get returns the Desk ID recorded for sourceID. ok is false when no
+	// mapping exists.
+	Get(ctx context.Context, kind Kind, sourceID string) (deskID int, ok bool, err error)
+	// Put records a mapping, replacing any existing one
+	Put(ctx context.Context, kind Kind, sourceID string, deskID int) error
+}
+
+// FileIDMap is an IDMap persisted as a JSON file. The whole file is rewritten
+// on every Put, which is fine for the tens of thousands of records a typical
+// migration handles; use SQLIDMap beyond that.
+type FileIDMap struct {
+	path string
+
+	mu  sync.Mutex
+	ids map[Kind]map[string]int
+}
+
+// OpenFile loads the mappings stored at path. A missing file is treated as an
+// empty map and is created on the first Put.
+func OpenFile(path string) (*FileIDMap, error) {
+	m := &FileIDMap{path: path, ids: make(map[Kind]map[string]int)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &m.ids); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// Get implements IDMap
+func (m *FileIDMap) Get(_ context.Context, kind Kind, sourceID string) (int, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, ok := m.ids[kind][sourceID]
+	return id, ok, nil
+}
+
+// Put implements IDMap. The file is replaced atomically so an interrupted
+// import never leaves a truncated map behind.
+func (m *FileIDMap) Put(_ context.Context, kind Kind, sourceID string, deskID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ids[kind] == nil {
+		m.ids[kind] = make(map[string]int)
+	}
+	m.ids[kind][sourceID] = deskID
+
+	b, err := json.MarshalIndent(m.ids, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), filepath.Base(m.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), m.path)
+}
+
+// SQLIDMap is an IDMap stored in a database table. It uses "?" placeholders
+// and an upsert supported by SQLite and MySQL; the SDK does not import a
+// driver, so register one (for example modernc.org/sqlite) in your program.
+type SQLIDMap struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLIDMap returns an IDMap backed by table, creating the table if it does
+// not exist
+func NewSQLIDMap(ctx context.Context, db *sql.DB, table string) (*SQLIDMap, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db is required")
+	}
+	if !regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`).MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	kind VARCHAR(32) NOT NULL,
+	source_id VARCHAR(255) NOT NULL,
+	desk_id INTEGER NOT NULL,
+	PRIMARY KEY (kind, source_id)
+)`, table))
+	if err != nil {
+		return nil, fmt.Errorf("create table %s: %w", table, err)
+	}
+
+	return &SQLIDMap{db: db, table: table}, nil
+}
+
+// Get implements IDMap
+func (m *SQLIDMap) Get(ctx context.Context, kind Kind, sourceID string) (int, bool, error) {
+	var id int
+	err := m.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT desk_id FROM %s WHERE kind = ? AND source_id = ?", m.table),
+		string(kind), sourceID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+// Put implements IDMap
+func (m *SQLIDMap) Put(ctx context.Context, kind Kind, sourceID string, deskID int) error {
+	_, err := m.db.ExecContext(ctx,
+		fmt.Sprintf("REPLACE INTO %s (kind, source_id, desk_id) VALUES (?, ?, ?)", m.table),
+		string(kind), sourceID, deskID,
+	)
+	return err
+}
@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestFileIDMap(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ids.json")
+
+	m, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := m.Put(ctx, KindCustomer, "zd-1", 42); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if id, ok, _ := reopened.Get(ctx, KindCustomer, "zd-1"); !ok || id != 42 {
+		t.Errorf("expected mapping to 42, got %d (ok=%v)", id, ok)
+	}
+	if _, ok, _ := reopened.Get(ctx, KindTicket, "zd-1"); ok {
+		t.Errorf("expected mappings to be scoped by kind")
+	}
+}
+
+func TestImportTicket(t *testing.T) {
+	ctx := context.Background()
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, models.TicketsResponse{})
+	mockTransport.AddResponse(http.MethodPost, "/tickets.json", http.StatusCreated, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 900}},
+	})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	ids, err := OpenFile(filepath.Join(t.TempDir(), "ids.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ImportTicket(ctx, c, ids, "zd-7", "zd-1", models.Ticket{}); err == nil {
+		t.Fatalf("expected an error for a customer that has not been imported")
+	}
+
+	if err := ids.Put(ctx, KindCustomer, "zd-1", 42); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := ImportTicket(ctx, c, ids, "zd-7", "zd-1", models.Ticket{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != 900 {
+		t.Errorf("expected ticket 900, got %d", id)
+	}
+
+	// A rerun must not create the ticket again
+	if _, err := ImportTicket(ctx, c, ids, "zd-7", "zd-1", models.Ticket{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n := len(mockTransport.GetRequests()); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestSQLIDMap(t *testing.T) {
+	ctx := context.Background()
+	db := sql.OpenDB(&fakeIDMapDB{rows: map[string]int64{}})
+	defer db.Close()
+
+	if _, err := NewSQLIDMap(ctx, db, "ids; DROP TABLE tickets"); err == nil {
+		t.Fatal("expected an error for an invalid table name")
+	}
+
+	m, err := NewSQLIDMap(ctx, db, "desk_ids")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok, err := m.Get(ctx, KindCustomer, "zd-1"); err != nil || ok {
+		t.Fatalf("expected no mapping yet, got ok=%v, err=%v", ok, err)
+	}
+
+	if err := m.Put(ctx, KindCustomer, "zd-1", 42); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := m.Put(ctx, KindCustomer, "zd-1", 43); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if id, ok, err := m.Get(ctx, KindCustomer, "zd-1"); err != nil || !ok || id != 43 {
+		t.Errorf("expected the mapping to be replaced with 43, got %d (ok=%v, err=%v)", id, ok, err)
+	}
+	if _, ok, _ := m.Get(ctx, KindTicket, "zd-1"); ok {
+		t.Errorf("expected mappings to be scoped by kind")
+	}
+}
+
+// fakeIDMapDB is a database/sql driver that understands just the statements
+// SQLIDMap sends, since the SDK does not depend on a real driver
+type fakeIDMapDB struct {
+	mu   sync.Mutex
+	rows map[string]int64
+}
+
+func (d *fakeIDMapDB) Connect(context.Context) (driver.Conn, error) { return fakeIDMapConn{d}, nil }
+func (d *fakeIDMapDB) Driver() driver.Driver                        { return nil }
+
+type fakeIDMapConn struct{ db *fakeIDMapDB }
+
+func (c fakeIDMapConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeIDMapStmt{db: c.db, query: query}, nil
+}
+func (c fakeIDMapConn) Close() error { return nil }
+func (c fakeIDMapConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+type fakeIDMapStmt struct {
+	db    *fakeIDMapDB
+	query string
+}
+
+func (s fakeIDMapStmt) Close() error { return nil }
+
+func (s fakeIDMapStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s fakeIDMapStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS desk_ids "):
+	case strings.HasPrefix(s.query, "REPLACE INTO desk_ids "):
+		s.db.rows[fmt.Sprint(args[0], "/", args[1])] = args[2].(int64)
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeIDMapStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if !strings.HasPrefix(s.query, "SELECT desk_id FROM desk_ids WHERE kind = ? AND source_id = ?") {
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+
+	rows := &fakeIDMapRows{}
+	if id, ok := s.db.rows[fmt.Sprint(args[0], "/", args[1])]; ok {
+		rows.ids = []int64{id}
+	}
+	return rows, nil
+}
+
+type fakeIDMapRows struct{ ids []int64 }
+
+func (r *fakeIDMapRows) Columns() []string { return []string{"desk_id"} }
+func (r *fakeIDMapRows) Close() error      { return nil }
+
+func (r *fakeIDMapRows) Next(dest []driver.Value) error {
+	if len(r.ids) == 0 {
+		return io.EOF
+	}
+	dest[0], r.ids = r.ids[0], r.ids[1:]
+	return nil
+}
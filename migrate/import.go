@@ -0,0 +1,80 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// ImportCustomer creates customer in Desk unless sourceID has already been
+// imported, and returns its Desk ID. The source ID is also stored as the
+// customer's external ID so a run interrupted between creating the customer
+// and recording the mapping recovers without creating a duplicate.
+func ImportCustomer(ctx context.Context, c *client.Client, ids IDMap, sourceID string, customer models.Customer) (int, error) {
+	if sourceID == "" {
+		return 0, fmt.Errorf("sourceID is required")
+	}
+
+	if id, ok, err := ids.Get(ctx, KindCustomer, sourceID); err != nil || ok {
+		return id, err
+	}
+
+	existing, err := c.Customers.FindByExternalID(ctx, sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("find customer %s: %w", sourceID, err)
+	}
+	if existing != nil {
+		return existing.ID, ids.Put(ctx, KindCustomer, sourceID, existing.ID)
+	}
+
+	customer.ExternalID = &sourceID
+	resp, err := c.Customers.Create(ctx, &models.CustomerResponse{Customer: customer})
+	if err != nil {
+		return 0, fmt.Errorf("create customer %s: %w", sourceID, err)
+	}
+
+	return resp.Customer.ID, ids.Put(ctx, KindCustomer, sourceID, resp.Customer.ID)
+}
+
+// ImportTicket creates ticket in Desk unless sourceID has already been
+// imported, and returns its Desk ID. customerSourceID, when set, must refer
+// to a customer imported earlier with ImportCustomer; the ticket is linked to
+// that customer's Desk ID.
+func ImportTicket(ctx context.Context, c *client.Client, ids IDMap, sourceID, customerSourceID string, ticket models.Ticket) (int, error) {
+	if sourceID == "" {
+		return 0, fmt.Errorf("sourceID is required")
+	}
+
+	if id, ok, err := ids.Get(ctx, KindTicket, sourceID); err != nil || ok {
+		return id, err
+	}
+
+	if customerSourceID != "" {
+		customerID, ok, err := ids.Get(ctx, KindCustomer, customerSourceID)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, fmt.Errorf("customer %s has not been imported", customerSourceID)
+		}
+		ticket.Customer = &models.EntityRef{ID: customerID}
+	}
+
+	existing, err := c.Tickets.FindByExternalID(ctx, sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("find ticket %s: %w", sourceID, err)
+	}
+	if existing != nil {
+		return existing.ID, ids.Put(ctx, KindTicket, sourceID, existing.ID)
+	}
+
+	ticket.ExternalID = &sourceID
+	resp, err := c.Tickets.Create(ctx, &models.TicketResponse{Ticket: ticket})
+	if err != nil {
+		return 0, fmt.Errorf("create ticket %s: %w", sourceID, err)
+	}
+
+	return resp.Ticket.ID, ids.Put(ctx, KindTicket, sourceID, resp.Ticket.ID)
+}
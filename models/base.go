@@ -9,6 +9,8 @@ const (
 	StateActive State = "active"
 	// StateDeleted represents a deleted state
 	StateDeleted State = "deleted"
+	// StateInactive represents a deactivated user who can no longer log in
+	StateInactive State = "inactive"
 )
 
 // Base types for common fields
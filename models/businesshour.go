@@ -23,3 +23,6 @@ type BusinessHourResponse struct {
 	BusinessHour BusinessHour `json:"businesshour"`
 	Included     IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (BusinessHour) EnvelopeKeys() (string, string) { return "businesshour", "businesshours" }
@@ -0,0 +1,34 @@
+package models
+
+// CannedResponse is a saved reply that agents can insert into a message
+type CannedResponse struct {
+	BaseEntity
+	Name *string `json:"name,omitempty"`
+	// Body is the reply as HTML
+	Body    *string     `json:"body,omitempty"`
+	Folder  *EntityRef  `json:"folder,omitempty"`
+	Inboxes []EntityRef `json:"inboxes,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (CannedResponse) EnvelopeKeys() (string, string) { return "cannedResponse", "cannedResponses" }
+
+type CannedResponseResponse = Single[CannedResponse]
+
+type CannedResponsesResponse = List[CannedResponse]
+
+// CannedResponseFolder groups canned responses. Folders can be nested.
+type CannedResponseFolder struct {
+	BaseEntity
+	Name   *string    `json:"name,omitempty"`
+	Parent *EntityRef `json:"parent,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (CannedResponseFolder) EnvelopeKeys() (string, string) {
+	return "cannedResponseFolder", "cannedResponseFolders"
+}
+
+type CannedResponseFolderResponse = Single[CannedResponseFolder]
+
+type CannedResponseFoldersResponse = List[CannedResponseFolder]
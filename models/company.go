@@ -30,8 +30,39 @@ type Company struct {
 	Kind        *string     `json:"kind,omitempty"`
 	Domains     []EntityRef `json:"domains,omitempty"`
 	Note        *string     `json:"note,omitempty"`
+	Notes       []EntityRef `json:"notes,omitempty"`
+	Tags        []EntityRef `json:"tags,omitempty"`
+	// Inboxes limits the inboxes the company's customers can see and raise
+	// tickets in through the portal. Empty means every inbox.
+	Inboxes []EntityRef `json:"inboxes,omitempty"`
 }
 
+// CompanyNote is a dated note on a company, such as context recorded by a CRM
+// sync. Unlike Company.Note, a company can have any number of them.
+type CompanyNote struct {
+	BaseEntity
+	Body    *string    `json:"body,omitempty"`
+	Company *EntityRef `json:"company,omitempty"`
+}
+
+// CompanyNotesResponse represents the response for a list of company notes
+type CompanyNotesResponse struct {
+	Notes      []CompanyNote `json:"notes"`
+	Included   IncludedData  `json:"included"`
+	Pagination Pagination    `json:"pagination"`
+	Meta       Meta          `json:"meta"`
+}
+
+// CompanyNoteResponse represents the response for a single company note
+type CompanyNoteResponse struct {
+	Note     CompanyNote  `json:"note"`
+	Included IncludedData `json:"included"`
+}
+
+type CompanyDomainsResponse = List[Domain]
+
+type CompanyDomainResponse = Single[Domain]
+
 // CompaniesResponse represents the response for a list of companies
 type CompaniesResponse struct {
 	Companies  []Company    `json:"companies"`
@@ -45,3 +76,12 @@ type CompanyResponse struct {
 	Company  Company      `json:"company"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (Company) EnvelopeKeys() (string, string) { return "company", "companies" }
+
+// EnvelopeKeys implements Entity
+func (CompanyNote) EnvelopeKeys() (string, string) { return "note", "notes" }
+
+// EnvelopeKeys implements Entity
+func (Domain) EnvelopeKeys() (string, string) { return "domain", "domains" }
@@ -1,8 +1,87 @@
 package models
 
-// Contact related types
+// Contact is one way of reaching a customer, such as an email address or
+// phone number. A customer can have several contacts; the one with IsMain set
+// is used for outgoing email. Contacts cannot log in to anything: portal
+// access belongs to the customer.
 type Contact struct {
 	BaseEntity
 	Value  *string `json:"value,omitempty"`
 	IsMain *bool   `json:"isMain,omitempty"`
+	// Customer is the customer the contact belongs to
+	Customer *EntityRef `json:"customer,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (Contact) EnvelopeKeys() (string, string) { return "contact", "contacts" }
+
+type ContactResponse = Single[Contact]
+
+type ContactsResponse = List[Contact]
+
+// Kind returns whether the contact is an email address or a phone number, or
+// "" when the API did not say
+func (c Contact) Kind() ContactType {
+	switch t := c.Type.(type) {
+	case string:
+		return ContactType(t)
+	case ContactType:
+		return t
+	default:
+		return ""
+	}
+}
+
+// Contacts returns the customer's contacts from the included data, in the
+// order the customer lists them. Request the customer with the contacts
+// included for them to be there.
+func (r CustomerResponse) Contacts() []Contact {
+	return resolveContacts(r.Customer, r.Included)
+}
+
+// Emails returns the values of the customer's email contacts, the main one
+// first
+func (r CustomerResponse) Emails() []string {
+	return contactValues(r.Contacts(), ContactTypeEmail)
+}
+
+// Phones returns the values of the customer's phone contacts
+func (r CustomerResponse) Phones() []string {
+	return contactValues(r.Contacts(), ContactTypePhone)
+}
+
+// ContactsFor returns the contacts of one of the listed customers from the
+// included data
+func (r CustomersResponse) ContactsFor(customer Customer) []Contact {
+	return resolveContacts(customer, r.Included)
+}
+
+func resolveContacts(customer Customer, included IncludedData) []Contact {
+	byID := make(map[int]Contact, len(included.Contacts))
+	for _, c := range included.Contacts {
+		byID[c.ID] = c
+	}
+
+	var contacts []Contact
+	for _, ref := range customer.Contacts {
+		if c, ok := byID[ref.ID]; ok {
+			contacts = append(contacts, c)
+		}
+	}
+	return contacts
+}
+
+func contactValues(contacts []Contact, kind ContactType) []string {
+	var main, rest []string
+	for _, c := range contacts {
+		if c.Kind() != kind || c.Value == nil {
+			continue
+		}
+		if c.IsMain != nil && *c.IsMain {
+			main = append(main, *c.Value)
+		} else {
+			rest = append(rest, *c.Value)
+		}
+	}
+	return append(main, rest...)
 }
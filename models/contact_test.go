@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestCustomerResponseContacts(t *testing.T) {
+	var resp CustomerResponse
+	err := json.Unmarshal([]byte(`{
+		"customer": {"id": 1, "contacts": [{"id": 11, "type": "contacts"}, {"id": 12, "type": "contacts"}, {"id": 13, "type": "contacts"}]},
+		"included": {"contacts": [
+			{"id": 13, "type": "email", "value": "main@example.com", "isMain": true},
+			{"id": 11, "type": "email", "value": "alt@example.com", "isMain": false},
+			{"id": 12, "type": "phone", "value": "+353 1 555 0100"},
+			{"id": 99, "type": "email", "value": "someone-else@example.com"}
+		]}
+	}`), &resp)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var ids []int
+	for _, c := range resp.Contacts() {
+		ids = append(ids, c.ID)
+	}
+	if !slices.Equal(ids, []int{11, 12, 13}) {
+		t.Errorf("expected the customer's contacts in order, got %v", ids)
+	}
+	if got := resp.Emails(); !slices.Equal(got, []string{"main@example.com", "alt@example.com"}) {
+		t.Errorf("expected the main email first, got %v", got)
+	}
+	if got := resp.Phones(); !slices.Equal(got, []string{"+353 1 555 0100"}) {
+		t.Errorf("unexpected phones %v", got)
+	}
+}
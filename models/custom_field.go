@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CustomFieldType is the kind of value a custom field holds
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText        CustomFieldType = "text"
+	CustomFieldTypeTextarea    CustomFieldType = "textarea"
+	CustomFieldTypeNumber      CustomFieldType = "number"
+	CustomFieldTypeCheckbox    CustomFieldType = "checkbox"
+	CustomFieldTypeDate        CustomFieldType = "date"
+	CustomFieldTypeDropdown    CustomFieldType = "dropdown"
+	CustomFieldTypeMultiselect CustomFieldType = "multiselect"
+)
+
+// CustomFieldEntity is the kind of record a custom field is defined on
+type CustomFieldEntity string
+
+const (
+	CustomFieldEntityTicket   CustomFieldEntity = "ticket"
+	CustomFieldEntityCustomer CustomFieldEntity = "customer"
+)
+
+// CustomField is the definition of a custom field on tickets or customers
+type CustomField struct {
+	BaseEntity
+	Name *string `json:"name,omitempty"`
+	// Handle is the stable key the field's values are stored under in
+	// CustomFieldValues
+	Handle      *string             `json:"handle,omitempty"`
+	Type        *CustomFieldType    `json:"type,omitempty"`
+	Entity      *CustomFieldEntity  `json:"entity,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Required    *bool               `json:"required,omitempty"`
+	Options     []CustomFieldOption `json:"options,omitempty"`
+	Inboxes     []EntityRef         `json:"inboxes,omitempty"`
+}
+
+// CustomFieldOption is one of the choices of a dropdown or multiselect field
+type CustomFieldOption struct {
+	ID    int    `json:"id,omitempty"`
+	Value string `json:"value"`
+	// Position orders the options in the UI, lowest first
+	Position int `json:"position,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (CustomField) EnvelopeKeys() (string, string) { return "customField", "customFields" }
+
+type CustomFieldResponse = Single[CustomField]
+
+type CustomFieldsResponse = List[CustomField]
+
+// CustomFieldValues holds the custom field values of a ticket or customer,
+// keyed by field handle. Values are kept as raw JSON since their type depends
+// on the field definition; use CustomFieldValue and SetCustomFieldValue to
+// read and write them as Go types.
+type CustomFieldValues map[string]json.RawMessage
+
+// Has reports whether a value is set for handle
+func (v CustomFieldValues) Has(handle string) bool {
+	raw, ok := v[handle]
+	return ok && string(raw) != "null"
+}
+
+// Delete clears the value for handle. The cleared value is sent as null so
+// that an update removes it on the server as well.
+func (v CustomFieldValues) Delete(handle string) {
+	v[handle] = json.RawMessage("null")
+}
+
+// CustomFieldValue decodes the value for handle into a T, for example a
+// string for text fields, a float64 for number fields, a bool for checkboxes,
+// a time.Time for dates and a []string for multiselects. The bool result is
+// false when no value is set; an error is only returned when the value does
+// not fit in a T.
+func CustomFieldValue[T any](values CustomFieldValues, handle string) (T, bool, error) {
+	var out T
+	if !values.Has(handle) {
+		return out, false, nil
+	}
+	if err := json.Unmarshal(values[handle], &out); err != nil {
+		return out, true, fmt.Errorf("custom field %q: %w", handle, err)
+	}
+	return out, true, nil
+}
+
+// SetCustomFieldValue sets the value for handle, creating the map when it is
+// nil
+func SetCustomFieldValue[T any](values *CustomFieldValues, handle string, value T) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("custom field %q: %w", handle, err)
+	}
+	if *values == nil {
+		*values = CustomFieldValues{}
+	}
+	(*values)[handle] = raw
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCustomFieldValues(t *testing.T) {
+	var ticket Ticket
+	if err := json.Unmarshal([]byte(`{"id":1,"customFieldValues":{"plan":"pro","seats":12,"vip":true,"renewsAt":"2026-01-02T00:00:00Z","cleared":null}}`), &ticket); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	plan, ok, err := CustomFieldValue[string](ticket.CustomFieldValues, "plan")
+	if err != nil || !ok || plan != "pro" {
+		t.Errorf("expected plan pro, got %q %v %v", plan, ok, err)
+	}
+	seats, _, err := CustomFieldValue[int](ticket.CustomFieldValues, "seats")
+	if err != nil || seats != 12 {
+		t.Errorf("expected 12 seats, got %d %v", seats, err)
+	}
+	renews, _, err := CustomFieldValue[time.Time](ticket.CustomFieldValues, "renewsAt")
+	if err != nil || !renews.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected renewal date, got %v %v", renews, err)
+	}
+	if _, ok, _ := CustomFieldValue[string](ticket.CustomFieldValues, "cleared"); ok {
+		t.Error("expected a null value to be reported as unset")
+	}
+	if _, _, err := CustomFieldValue[bool](ticket.CustomFieldValues, "plan"); err == nil {
+		t.Error("expected an error reading a text value as a bool")
+	}
+
+	var update Ticket
+	if err := SetCustomFieldValue(&update.CustomFieldValues, "tier", []string{"gold"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	update.CustomFieldValues.Delete("plan")
+	b, err := json.Marshal(update.CustomFieldValues)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(b) != `{"plan":null,"tier":["gold"]}` {
+		t.Errorf("unexpected values %s", b)
+	}
+}
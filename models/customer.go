@@ -1,6 +1,11 @@
 package models
 
-// Customer related types
+import "time"
+
+// Customer is a person who raises tickets. Customers reach Desk through their
+// Contacts (email addresses, phone numbers, ...). A customer becomes a portal
+// user when PortalAccess is enabled, which lets them log in to the customer
+// portal with their main email address to see and reply to their tickets.
 type Customer struct {
 	BaseEntity
 	FirstName             *string     `json:"firstName,omitempty"`
@@ -24,6 +29,14 @@ type Customer struct {
 	Customerwelcomeemails any         `json:"customerwelcomeemails"`
 	Trusted               *bool       `json:"trusted,omitempty"`
 	WelcomeEmailSent      *bool       `json:"welcomeEmailSent,omitempty"`
+	PortalAccess          *bool       `json:"portalAccess,omitempty"`
+	PortalLastLoginAt     *time.Time  `json:"portalLastLoginAt,omitempty"`
+	// Company is the company the customer belongs to, if any. Change it with
+	// CustomerService.SetCompany rather than a full update.
+	Company *EntityRef `json:"company,omitempty"`
+	// CustomFieldValues holds the customer's custom field values by field
+	// handle
+	CustomFieldValues CustomFieldValues `json:"customFieldValues,omitempty"`
 }
 
 // Response types for customers
@@ -38,3 +51,26 @@ type CustomerResponse struct {
 	Customer Customer     `json:"customer"`
 	Included IncludedData `json:"included"`
 }
+
+// CustomerActivityType identifies what a customer activity entry represents
+type CustomerActivityType string
+
+const (
+	CustomerActivityTypeTicket    CustomerActivityType = "ticket"
+	CustomerActivityTypeMessage   CustomerActivityType = "message"
+	CustomerActivityTypeHappiness CustomerActivityType = "happiness"
+)
+
+// CustomerActivity is a single entry in a customer's history. Ticket is
+// always set; Message is set for message entries and Happiness for
+// happiness rating entries.
+type CustomerActivity struct {
+	Type      CustomerActivityType `json:"type"`
+	At        time.Time            `json:"at"`
+	Ticket    *Ticket              `json:"ticket"`
+	Message   *Message             `json:"message,omitempty"`
+	Happiness *HappinessSurvey     `json:"happiness,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (Customer) EnvelopeKeys() (string, string) { return "customer", "customers" }
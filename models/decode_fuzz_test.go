@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func FuzzDecodeResponses(f *testing.F) {
+	f.Add([]byte(`{"ticket":{"id":1,"subject":"Hi","tags":[{"id":2,"type":"tags","meta":{}}]},"included":{}}`))
+	f.Add([]byte(`{"tickets":[{"id":1,"type":{"name":"x"},"suggestions":{}}],"pagination":{"records":1}}`))
+	f.Add([]byte(`{"message":{"id":1,"htmlBody":"<p>hi</p>","threadType":"note","ticket":{"id":3}}}`))
+	f.Add([]byte(`{"messages":[{"htmlBody":null,"cc":null}],"included":{"ticketactivities":[{"inbox":[1]}]}}`))
+	f.Add([]byte(`{"customer":{"id":"not a number","contacts":{}}}`))
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		targets := []any{
+			&TicketResponse{}, &TicketsResponse{},
+			&MessageResponse{}, &MessagesResponse{},
+			&CustomerResponse{}, &CustomersResponse{},
+			&CompanyResponse{}, &CompaniesResponse{},
+			&InboxResponse{}, &InboxesResponse{},
+			&UserResponse{}, &UsersResponse{},
+			&SLAResponse{}, &SLAsResponse{},
+			&Timeline{},
+		}
+
+		for _, target := range targets {
+			if err := json.Unmarshal(data, target); err != nil {
+				continue
+			}
+
+			// Anything that decodes must encode again
+			if _, err := json.Marshal(target); err != nil {
+				t.Fatalf("%T decoded but failed to encode: %v", target, err)
+			}
+		}
+	})
+}
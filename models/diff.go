@@ -0,0 +1,179 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FieldChange is a single field that differs between two model instances
+type FieldChange struct {
+	// Field is the dotted JSON path of the field, for example "priority" or
+	// "ticket.subject"
+	Field string
+	// From and To are the old and new values. Pointers are dereferenced, and
+	// an unset field is nil. References are EntityRef values (or slices of
+	// them), so callers can resolve names from included data.
+	From any
+	To   any
+}
+
+// String describes the change, for example "priority changed from #1 to #3"
+func (c FieldChange) String() string {
+	switch {
+	case c.From == nil:
+		return fmt.Sprintf("%s set to %s", c.Field, formatDiffValue(c.To))
+	case c.To == nil:
+		return fmt.Sprintf("%s cleared (was %s)", c.Field, formatDiffValue(c.From))
+	default:
+		return fmt.Sprintf("%s changed from %s to %s", c.Field, formatDiffValue(c.From), formatDiffValue(c.To))
+	}
+}
+
+// Diff compares two values of the same model type, or pointers to them, and
+// returns the fields that differ in field order. References are compared by
+// ID and type only, times by instant, and nil and empty slices are treated as
+// equal. Included data, pagination and response metadata are not compared.
+// When a and b are of different types a single change with an empty Field is
+// returned.
+func Diff(a, b any) []FieldChange {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Type() != vb.Type() {
+		return []FieldChange{{From: a, To: b}}
+	}
+
+	var changes []FieldChange
+	diffValue(&changes, "", va, vb)
+	return changes
+}
+
+func diffValue(changes *[]FieldChange, path string, a, b reflect.Value) {
+	a, aSet := derefDiffValue(a)
+	b, bSet := derefDiffValue(b)
+	switch {
+	case !aSet && !bSet:
+		return
+	case !aSet || !bSet:
+		*changes = append(*changes, FieldChange{Field: path, From: diffInterface(a, aSet), To: diffInterface(b, bSet)})
+		return
+	case a.Type() != b.Type():
+		// Only possible for fields typed any
+		*changes = append(*changes, FieldChange{Field: path, From: a.Interface(), To: b.Interface()})
+		return
+	}
+
+	switch {
+	case a.Type() == reflect.TypeFor[EntityRef]():
+		ra, rb := a.Interface().(EntityRef), b.Interface().(EntityRef)
+		if ra.ID != rb.ID || ra.Type != rb.Type {
+			*changes = append(*changes, FieldChange{Field: path, From: ra, To: rb})
+		}
+	case a.Type() == reflect.TypeFor[time.Time]():
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) {
+			*changes = append(*changes, FieldChange{Field: path, From: a.Interface(), To: b.Interface()})
+		}
+	case a.Kind() == reflect.Struct:
+		for i := range a.NumField() {
+			field := a.Type().Field(i)
+			if !field.IsExported() || skipDiffType(field.Type) {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if field.Anonymous && name == "" {
+				diffValue(changes, path, a.Field(i), b.Field(i))
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			diffValue(changes, name, a.Field(i), b.Field(i))
+		}
+	case a.Kind() == reflect.Slice && a.Type().Elem() == reflect.TypeFor[EntityRef]():
+		ra, rb := a.Interface().([]EntityRef), b.Interface().([]EntityRef)
+		if !sameRefs(ra, rb) {
+			*changes = append(*changes, FieldChange{Field: path, From: ra, To: rb})
+		}
+	case a.Kind() == reflect.Slice || a.Kind() == reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 {
+			return
+		}
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, FieldChange{Field: path, From: a.Interface(), To: b.Interface()})
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, FieldChange{Field: path, From: a.Interface(), To: b.Interface()})
+		}
+	}
+}
+
+// derefDiffValue follows pointers and interfaces, reporting false for nil
+func derefDiffValue(v reflect.Value) (reflect.Value, bool) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+func diffInterface(v reflect.Value, set bool) any {
+	if !set {
+		return nil
+	}
+	return v.Interface()
+}
+
+// skipDiffType reports whether a field holds response data rather than model
+// state
+func skipDiffType(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeFor[IncludedData](), reflect.TypeFor[Pagination](), reflect.TypeFor[Meta](), reflect.TypeFor[PageMeta]():
+		return true
+	default:
+		return false
+	}
+}
+
+func sameRefs(a, b []EntityRef) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || a[i].Type != b[i].Type {
+			return false
+		}
+	}
+	return true
+}
+
+func formatDiffValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "none"
+	case EntityRef:
+		return fmt.Sprintf("#%d", v.ID)
+	case []EntityRef:
+		ids := make([]string, len(v))
+		for i, ref := range v {
+			ids[i] = fmt.Sprintf("#%d", ref.ID)
+		}
+		return "[" + strings.Join(ids, ", ") + "]"
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
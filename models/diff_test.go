@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	created := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	subject := "Login broken"
+	newSubject := "Login broken on mobile"
+
+	before := TicketResponse{
+		Ticket: Ticket{
+			BaseEntity: BaseEntity{ID: 1, CreatedAt: &created},
+			Subject:    &subject,
+			Priority:   &EntityRef{ID: 1, Type: "ticketpriorities"},
+			Tags:       []EntityRef{{ID: 4, Type: "tags"}},
+			CC:         nil,
+		},
+		Included: IncludedData{Tags: []Tag{{BaseEntity: BaseEntity{ID: 4}}}},
+	}
+
+	sameInstant := created.In(time.FixedZone("CEST", 2*60*60))
+	after := TicketResponse{
+		Ticket: Ticket{
+			BaseEntity: BaseEntity{ID: 1, CreatedAt: &sameInstant},
+			Subject:    &newSubject,
+			// Meta differs but the reference is the same
+			Priority: &EntityRef{ID: 3, Type: "ticketpriorities", Meta: map[string]any{"name": "High"}},
+			Tags:     []EntityRef{{ID: 4, Type: "tags", Meta: map[string]any{"color": "red"}}},
+			CC:       []string{},
+			Agent:    &EntityRef{ID: 9, Type: "users"},
+		},
+	}
+
+	changes := Diff(&before, &after)
+
+	want := []string{
+		`ticket.agent set to #9`,
+		`ticket.priority changed from #1 to #3`,
+		`ticket.subject changed from "Login broken" to "Login broken on mobile"`,
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %v", len(want), len(changes), changes)
+	}
+	for i, c := range changes {
+		if c.String() != want[i] {
+			t.Errorf("change %d: expected %q, got %q", i, want[i], c.String())
+		}
+	}
+
+	if ref, ok := changes[1].To.(EntityRef); !ok || ref.ID != 3 {
+		t.Errorf("expected the new priority as an EntityRef, got %#v", changes[1].To)
+	}
+}
+
+func TestDiffDifferentTypes(t *testing.T) {
+	changes := Diff(Ticket{}, Customer{})
+	if len(changes) != 1 || changes[0].Field != "" {
+		t.Fatalf("expected a single whole-value change, got %v", changes)
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	name := "Acme"
+	if changes := Diff(Company{Name: &name}, Company{Name: &name}); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Entity is a domain model that can be wrapped in the generic Single and List
+// envelopes. EnvelopeKeys returns the JSON keys the API uses for the entity in
+// single and list responses, for example "ticket" and "tickets".
+type Entity interface {
+	EnvelopeKeys() (single, list string)
+}
+
+// Single is the response envelope for a single entity. It encodes to the same
+// JSON as the hand-written <Resource>Response structs, so new resources can
+// declare
+//
+//	type WidgetResponse = Single[Widget]
+//
+// instead of repeating the wrapper.
+type Single[T Entity] struct {
+	Item     T
+	Included IncludedData
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Single[T]) MarshalJSON() ([]byte, error) {
+	key, _ := entityKeys[T]()
+
+	item, err := json.Marshal(s.Item)
+	if err != nil {
+		return nil, err
+	}
+	included, err := json.Marshal(s.Included)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]json.RawMessage{key: item, "included": included})
+}
+
+// EnvelopeKey returns the JSON key the entity is wrapped in, for example
+// "ticket"
+func (Single[T]) EnvelopeKey() string {
+	key, _ := entityKeys[T]()
+	return key
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Single[T]) UnmarshalJSON(data []byte) error {
+	key, _ := entityKeys[T]()
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if v, ok := raw[key]; ok {
+		if err := json.Unmarshal(v, &s.Item); err != nil {
+			return fmt.Errorf("decode %s: %w", key, err)
+		}
+	}
+	if v, ok := raw["included"]; ok {
+		if err := json.Unmarshal(v, &s.Included); err != nil {
+			return fmt.Errorf("decode included: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// List is the response envelope for a page of entities. Like Single, it
+// encodes to the same JSON as the hand-written <Resource>sResponse structs.
+type List[T Entity] struct {
+	Items      []T
+	Included   IncludedData
+	Pagination Pagination
+	Meta       Meta
+}
+
+// MarshalJSON implements json.Marshaler
+func (l List[T]) MarshalJSON() ([]byte, error) {
+	_, key := entityKeys[T]()
+
+	fields := map[string]any{
+		key:          l.Items,
+		"included":   l.Included,
+		"pagination": l.Pagination,
+		"meta":       l.Meta,
+	}
+
+	out := make(map[string]json.RawMessage, len(fields))
+	for k, v := range fields {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = b
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	_, key := entityKeys[T]()
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	fields := map[string]any{
+		key:          &l.Items,
+		"included":   &l.Included,
+		"pagination": &l.Pagination,
+		"meta":       &l.Meta,
+	}
+	for k, dst := range fields {
+		v, ok := raw[k]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(v, dst); err != nil {
+			return fmt.Errorf("decode %s: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+func entityKeys[T Entity]() (string, string) {
+	var zero T
+	return zero.EnvelopeKeys()
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// checkSingle decodes a hand-written single response into Single[T] and checks
+// the entity ID survived, which catches envelope keys that drift from the
+// struct tags
+func checkSingle[T Entity](t *testing.T, resp any, id func(T) int) {
+	t.Helper()
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+
+	var single Single[T]
+	if err := json.Unmarshal(b, &single); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+	if got := id(single.Item); got != 42 {
+		single, _ := entityKeys[T]()
+		t.Errorf("%s: expected ID 42, got %d", single, got)
+	}
+}
+
+func TestSingleMatchesResponseStructs(t *testing.T) {
+	base := BaseEntity{ID: 42}
+
+	checkSingle(t, TicketResponse{Ticket: Ticket{BaseEntity: base}}, func(v Ticket) int { return v.ID })
+	checkSingle(t, CustomerResponse{Customer: Customer{BaseEntity: base}}, func(v Customer) int { return v.ID })
+	checkSingle(t, CompanyResponse{Company: Company{BaseEntity: base}}, func(v Company) int { return v.ID })
+	checkSingle(t, MessageResponse{Message: Message{BaseEntity: base}}, func(v Message) int { return v.ID })
+	checkSingle(t, HelpDocArticleResponse{HelpDocArticle: HelpDocArticle{BaseEntity: base}}, func(v HelpDocArticle) int { return v.ID })
+	checkSingle(t, TicketSourceResponse{TicketSource: TicketSource{BaseEntity: base}}, func(v TicketSource) int { return v.ID })
+	checkSingle(t, TimeLogResponse{TimeLog: TimeLog{BaseEntity: base}}, func(v TimeLog) int { return v.ID })
+	checkSingle(t, UserResponse{User: User{BaseEntity: base}}, func(v User) int { return v.ID })
+}
+
+func TestListRoundTrip(t *testing.T) {
+	want := TicketsResponse{
+		Tickets:    []Ticket{{BaseEntity: BaseEntity{ID: 1}}, {BaseEntity: BaseEntity{ID: 2}}},
+		Included:   IncludedData{Tags: []Tag{{BaseEntity: BaseEntity{ID: 9}}}},
+		Pagination: Pagination{Records: 2, Page: 1, HasMorePages: true},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+
+	var list List[Ticket]
+	if err := json.Unmarshal(b, &list); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+	if len(list.Items) != 2 || list.Items[1].ID != 2 {
+		t.Errorf("expected both tickets, got %+v", list.Items)
+	}
+	if len(list.Included.Tags) != 1 || !list.Pagination.HasMorePages {
+		t.Errorf("expected included tags and pagination, got %+v %+v", list.Included, list.Pagination)
+	}
+
+	b, err = json.Marshal(list)
+	if err != nil {
+		t.Fatalf("failed to encode envelope: %v", err)
+	}
+
+	var got TicketsResponse
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Tickets) != 2 || got.Pagination.Records != 2 {
+		t.Errorf("expected the envelope to encode like TicketsResponse, got %s", b)
+	}
+}
@@ -28,6 +28,12 @@ type File struct {
 
 	// Type is always 'attachment'
 	Type *FileType `json:"type,omitempty"`
+
+	// Size of the file in bytes
+	Size *int64 `json:"size,omitempty"`
+
+	// URL the file contents can be downloaded from
+	URL *string `json:"url,omitempty"`
 }
 
 type FilesResponse struct {
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// HappinessRating is a customer's answer to a happiness survey
+type HappinessRating string
+
+const (
+	// HappinessRatingHappy is a positive rating
+	HappinessRatingHappy HappinessRating = "happy"
+	// HappinessRatingNeutral is a neutral rating
+	HappinessRatingNeutral HappinessRating = "neutral"
+	// HappinessRatingUnhappy is a negative rating
+	HappinessRatingUnhappy HappinessRating = "unhappy"
+)
+
+// Score maps the rating onto 1 (unhappy) to 3 (happy) for averaging, or 0 for
+// an unknown rating
+func (r HappinessRating) Score() int {
+	switch r {
+	case HappinessRatingHappy:
+		return 3
+	case HappinessRatingNeutral:
+		return 2
+	case HappinessRatingUnhappy:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// HappinessSurvey is a customer's answer to the happiness survey sent for a
+// ticket
+type HappinessSurvey struct {
+	BaseEntity
+	Rating   *HappinessRating `json:"rating,omitempty"`
+	Comment  *string          `json:"comment,omitempty"`
+	Ticket   *EntityRef       `json:"ticket,omitempty"`
+	Customer *EntityRef       `json:"customer,omitempty"`
+	// Agent is the agent the ticket was assigned to when the survey was
+	// sent, if any
+	Agent   *EntityRef `json:"agent,omitempty"`
+	RatedAt *time.Time `json:"ratedAt,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (HappinessSurvey) EnvelopeKeys() (string, string) { return "happinessSurvey", "happinessSurveys" }
+
+type HappinessSurveyResponse = Single[HappinessSurvey]
+
+type HappinessSurveysResponse = List[HappinessSurvey]
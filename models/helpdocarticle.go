@@ -1,21 +1,41 @@
 package models
 
+import "time"
+
+// HelpDocArticleStatus is the publishing state of an article
+type HelpDocArticleStatus string
+
+const (
+	HelpDocArticleStatusDraft     HelpDocArticleStatus = "draft"
+	HelpDocArticleStatusPublished HelpDocArticleStatus = "published"
+)
+
 type HelpDocArticle struct {
 	BaseEntity
-	Helpdocsite     EntityRef `json:"helpdocsite"`
-	Title           *string   `json:"title,omitempty"`
-	Slug            *string   `json:"slug,omitempty"`
-	Description     *string   `json:"description,omitempty"`
-	OldURL          *string   `json:"oldURL,omitempty"`
-	Popularity      *int      `json:"popularity,omitempty"`
-	DisqusEnabled   *bool     `json:"disqusEnabled,omitempty"`
-	IsPrivate       *bool     `json:"isPrivate,omitempty"`
-	EditMethod      *string   `json:"editMethod,omitempty"`
-	DisplayOrder    *int      `json:"displayOrder,omitempty"`
-	Status          *string   `json:"status,omitempty"`
-	Contents        *string   `json:"contents,omitempty"`
-	Categories      []int     `json:"categories"`
-	RelatedArticles []int     `json:"relatedArticles,omitempty"`
+	Helpdocsite     EntityRef             `json:"helpdocsite"`
+	Title           *string               `json:"title,omitempty"`
+	Slug            *string               `json:"slug,omitempty"`
+	Description     *string               `json:"description,omitempty"`
+	OldURL          *string               `json:"oldURL,omitempty"`
+	Popularity      *int                  `json:"popularity,omitempty"`
+	DisqusEnabled   *bool                 `json:"disqusEnabled,omitempty"`
+	IsPrivate       *bool                 `json:"isPrivate,omitempty"`
+	EditMethod      *string               `json:"editMethod,omitempty"`
+	DisplayOrder    *int                  `json:"displayOrder,omitempty"`
+	Status          *HelpDocArticleStatus `json:"status,omitempty"`
+	Contents        *string               `json:"contents,omitempty"`
+	Categories      []int                 `json:"categories"`
+	RelatedArticles []int                 `json:"relatedArticles,omitempty"`
+	// PublishedAt is when the article was last published, nil for drafts
+	// that have never been published
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Revision is the number of the article's current revision
+	Revision *int `json:"revision,omitempty"`
+}
+
+// IsPublished reports whether the article is visible on its site
+func (a HelpDocArticle) IsPublished() bool {
+	return a.Status != nil && *a.Status == HelpDocArticleStatusPublished
 }
 
 type HelpDocArticlesResponse struct {
@@ -29,3 +49,22 @@ type HelpDocArticleResponse struct {
 	HelpDocArticle HelpDocArticle `json:"helpDocArticle"`
 	Included       IncludedData   `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (HelpDocArticle) EnvelopeKeys() (string, string) { return "helpDocArticle", "helpdocarticles" }
+
+// HelpDocArticleRevision is a saved version of an article's content
+type HelpDocArticleRevision struct {
+	BaseEntity
+	Article  EntityRef `json:"article"`
+	Revision *int      `json:"revision,omitempty"`
+	Title    *string   `json:"title,omitempty"`
+	Contents *string   `json:"contents,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (HelpDocArticleRevision) EnvelopeKeys() (string, string) {
+	return "helpDocArticleRevision", "helpdocarticlerevisions"
+}
+
+type HelpDocArticleRevisionsResponse = List[HelpDocArticleRevision]
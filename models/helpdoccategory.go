@@ -0,0 +1,53 @@
+package models
+
+// HelpDocCategory groups articles on a help doc site. Categories can be
+// nested.
+type HelpDocCategory struct {
+	BaseEntity
+	Helpdocsite  EntityRef  `json:"helpdocsite"`
+	Name         *string    `json:"name,omitempty"`
+	Slug         *string    `json:"slug,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Parent       *EntityRef `json:"parent,omitempty"`
+	IsPrivate    *bool      `json:"isPrivate,omitempty"`
+	DisplayOrder *int       `json:"displayOrder,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (HelpDocCategory) EnvelopeKeys() (string, string) { return "helpDocCategory", "helpdoccategories" }
+
+type HelpDocCategoryResponse = Single[HelpDocCategory]
+
+type HelpDocCategoriesResponse = List[HelpDocCategory]
+
+// HelpDocCategoryPosition places a category in its site's category list
+type HelpDocCategoryPosition struct {
+	ID           int `json:"id"`
+	DisplayOrder int `json:"displayOrder"`
+}
+
+// HelpDocCommentStatus is the moderation state of an article comment
+type HelpDocCommentStatus string
+
+const (
+	HelpDocCommentStatusPending  HelpDocCommentStatus = "pending"
+	HelpDocCommentStatusApproved HelpDocCommentStatus = "approved"
+	HelpDocCommentStatusSpam     HelpDocCommentStatus = "spam"
+)
+
+// HelpDocComment is a reader's comment on a help doc article
+type HelpDocComment struct {
+	BaseEntity
+	Article     EntityRef             `json:"article"`
+	Body        *string               `json:"body,omitempty"`
+	AuthorName  *string               `json:"authorName,omitempty"`
+	AuthorEmail *string               `json:"authorEmail,omitempty"`
+	Status      *HelpDocCommentStatus `json:"status,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (HelpDocComment) EnvelopeKeys() (string, string) { return "helpDocComment", "helpdoccomments" }
+
+type HelpDocCommentResponse = Single[HelpDocComment]
+
+type HelpDocCommentsResponse = List[HelpDocComment]
@@ -61,3 +61,6 @@ type HelpDocSiteResponse struct {
 	HelpDocSite HelpDocSite  `json:"helpdocssite"`
 	Included    IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (HelpDocSite) EnvelopeKeys() (string, string) { return "helpdocssite", "helpdocssites" }
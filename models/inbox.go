@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Inbox related types
 type Inbox struct {
 	BaseEntity
@@ -38,6 +40,8 @@ type Inbox struct {
 	SMTPSecurity                  *string      `json:"smtpSecurity,omitempty"`
 	SMTPServer                    *string      `json:"smtpServer,omitempty"`
 	SMTPUsername                  *string      `json:"smtpUsername,omitempty"`
+	SpamAction                    *SpamAction  `json:"spamAction,omitempty"`
+	SpamTag                       *EntityRef   `json:"spamTag,omitempty"`
 	SpamThreshold                 *int         `json:"spamThreshold,omitempty"`
 	Starred                       *bool        `json:"starred,omitempty"`
 	SyncAccountID                 any          `json:"syncAccountId"`
@@ -56,6 +60,122 @@ type Inbox struct {
 	Verified                      *bool        `json:"verified,omitempty"`
 }
 
+// SpamAction determines what happens to mail scored at or above an inbox's
+// spam threshold
+type SpamAction string
+
+const (
+	// SpamActionQuarantine holds the ticket in the spam folder
+	SpamActionQuarantine SpamAction = "quarantine"
+	// SpamActionTag accepts the ticket and applies the inbox's spam tag
+	SpamActionTag SpamAction = "tag"
+	// SpamActionReject drops the message without creating a ticket
+	SpamActionReject SpamAction = "reject"
+)
+
+// InboxSpamSettings holds the subset of inbox fields that control spam
+// handling. Nil fields are left unchanged on update.
+type InboxSpamSettings struct {
+	SpamThreshold *int        `json:"spamThreshold,omitempty"`
+	SpamAction    *SpamAction `json:"spamAction,omitempty"`
+	SpamTag       *EntityRef  `json:"spamTag,omitempty"`
+}
+
+// SMTPSecurity is how an inbox secures its connection to a custom SMTP server
+type SMTPSecurity string
+
+const (
+	SMTPSecurityNone     SMTPSecurity = "none"
+	SMTPSecuritySSL      SMTPSecurity = "ssl"
+	SMTPSecurityStartTLS SMTPSecurity = "starttls"
+)
+
+// InboxSendingSettings holds the subset of inbox fields that control how
+// outgoing email is sent: through Teamwork's mail servers, or through the
+// customer's own SMTP server from their own address. Nil fields are left
+// unchanged on update.
+type InboxSendingSettings struct {
+	// SendEmailsFrom is the address replies are sent from
+	SendEmailsFrom *string `json:"sendEmailsFrom,omitempty"`
+	// UseTeamworkMailServer sends through Teamwork's servers when true and
+	// through the SMTP server below when false
+	UseTeamworkMailServer *bool         `json:"useTeamworkMailServer,omitempty"`
+	SMTPProvider          *string       `json:"smtpProvider,omitempty"`
+	SMTPServer            *string       `json:"smtpServer,omitempty"`
+	SMTPPort              *int          `json:"smtpPort,omitempty"`
+	SMTPSecurity          *SMTPSecurity `json:"smtpSecurity,omitempty"`
+	SMTPUsername          *string       `json:"smtpUsername,omitempty"`
+	// SMTPPassword is write-only; the API does not return it
+	SMTPPassword *string `json:"smtpPassword,omitempty"`
+}
+
+// InboxForwardingSettings holds the subset of inbox fields about forwarded
+// email: mail forwarded into Desk from the customer's own mailbox, and
+// tickets forwarded out of Desk. Nil fields are left unchanged on update.
+type InboxForwardingSettings struct {
+	// ForwardingAddress is the address to forward the customer's mailbox to.
+	// It is assigned by Desk and read-only.
+	ForwardingAddress *string `json:"forwardingAddress,omitempty"`
+	// EmailForwardingState reports whether forwarded mail has been seen yet.
+	// It is read-only.
+	EmailForwardingState *string `json:"emailForwardingState,omitempty"`
+	// IncludeTicketHistoryOnForward adds the earlier messages of a ticket
+	// when it is forwarded
+	IncludeTicketHistoryOnForward *bool `json:"includeTicketHistoryOnForward,omitempty"`
+}
+
+// InboxAutoReplySettings holds the subset of inbox fields that control the
+// automatic reply sent to customers when they open a ticket. Nil fields are
+// left unchanged on update.
+type InboxAutoReplySettings struct {
+	AutoReplyEnabled *bool   `json:"autoReplyEnabled,omitempty"`
+	AutoReplySubject *string `json:"autoReplySubject,omitempty"`
+	// AutoReplyMessage is the reply body as HTML
+	AutoReplyMessage *string `json:"autoReplyMessage,omitempty"`
+}
+
+// InboxEmailSettings gathers an inbox's email settings. The SMTP password is
+// never returned by the API, so Sending.SMTPPassword is always nil.
+type InboxEmailSettings struct {
+	Sending    InboxSendingSettings
+	Forwarding InboxForwardingSettings
+	AutoReply  InboxAutoReplySettings
+	// Signature is appended to replies sent from the inbox, as HTML
+	Signature *string
+}
+
+// EmailSettings returns the inbox's email settings
+func (i Inbox) EmailSettings() InboxEmailSettings {
+	var security *SMTPSecurity
+	if i.SMTPSecurity != nil {
+		v := SMTPSecurity(*i.SMTPSecurity)
+		security = &v
+	}
+
+	return InboxEmailSettings{
+		Sending: InboxSendingSettings{
+			SendEmailsFrom:        i.SendEmailsFrom,
+			UseTeamworkMailServer: i.UseTeamworkMailServer,
+			SMTPProvider:          i.SMTPProvider,
+			SMTPServer:            i.SMTPServer,
+			SMTPPort:              i.SMTPPort,
+			SMTPSecurity:          security,
+			SMTPUsername:          i.SMTPUsername,
+		},
+		Forwarding: InboxForwardingSettings{
+			ForwardingAddress:             i.ForwardingAddress,
+			EmailForwardingState:          i.EmailForwardingState,
+			IncludeTicketHistoryOnForward: i.IncludeTicketHistoryOnForward,
+		},
+		AutoReply: InboxAutoReplySettings{
+			AutoReplyEnabled: i.AutoReplyEnabled,
+			AutoReplySubject: i.AutoReplySubject,
+			AutoReplyMessage: i.AutoReplyMessage,
+		},
+		Signature: i.Signature,
+	}
+}
+
 type InboxesResponse struct {
 	Inboxes    []Inbox      `json:"inboxes"`
 	Included   IncludedData `json:"included"`
@@ -73,11 +193,50 @@ type InboxUser struct {
 	Meta InboxMeta `json:"meta"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler
+func (u *InboxUser) UnmarshalJSON(data []byte) error {
+	return unmarshalRefMeta(data, &u.EntityRef, &u.Meta)
+}
+
 type InboxMeta struct {
-	Access  *string `json:"access,omitempty"`
-	IsAdmin *bool   `json:"isAdmin,omitempty"`
-	Starred *bool   `json:"starred,omitempty"`
-	State   *string `json:"state,omitempty"`
+	Access  *InboxAccess `json:"access,omitempty"`
+	IsAdmin *bool        `json:"isAdmin,omitempty"`
+	Starred *bool        `json:"starred,omitempty"`
+	State   *string      `json:"state,omitempty"`
+}
+
+// InboxAccess is the level of access a user has to an inbox
+type InboxAccess string
+
+const (
+	// InboxAccessRead can view tickets in the inbox
+	InboxAccessRead InboxAccess = "read"
+	// InboxAccessWrite can view and reply to tickets in the inbox
+	InboxAccessWrite InboxAccess = "write"
+	// InboxAccessManage can also change the inbox's settings
+	InboxAccessManage InboxAccess = "manage"
+)
+
+// InboxUserAccess is the request body for changing a user's inbox access
+type InboxUserAccess struct {
+	Access InboxAccess `json:"access"`
+}
+
+// InboxStar is the request body for starring or unstarring an inbox for a
+// user
+type InboxStar struct {
+	Starred bool `json:"starred"`
+}
+
+// InboxPosition places an inbox in the inbox list
+type InboxPosition struct {
+	ID           int `json:"id"`
+	DisplayOrder int `json:"displayOrder"`
+}
+
+// InboxUserResponse represents the response for a single inbox user
+type InboxUserResponse struct {
+	User InboxUser `json:"user"`
 }
 
 type Trigger struct {
@@ -87,9 +246,63 @@ type Trigger struct {
 	} `json:"meta"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Trigger) UnmarshalJSON(data []byte) error {
+	return unmarshalRefMeta(data, &t.EntityRef, &t.Meta)
+}
+
 type InboxCname struct {
 	EntityRef
 	Meta struct {
 		Domain *string `json:"domain,omitempty"`
 	} `json:"meta"`
 }
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *InboxCname) UnmarshalJSON(data []byte) error {
+	return unmarshalRefMeta(data, &c.EntityRef, &c.Meta)
+}
+
+// DomainVerificationStatus represents the state of a sending domain check
+type DomainVerificationStatus string
+
+const (
+	DomainVerificationStatusPending  DomainVerificationStatus = "pending"
+	DomainVerificationStatusVerified DomainVerificationStatus = "verified"
+	DomainVerificationStatusFailed   DomainVerificationStatus = "failed"
+)
+
+// DomainRecord is a DNS record that must be published to authenticate mail
+// sent from an inbox's domain
+type DomainRecord struct {
+	Type   *string                   `json:"type,omitempty"`
+	Host   *string                   `json:"host,omitempty"`
+	Value  *string                   `json:"value,omitempty"`
+	Status *DomainVerificationStatus `json:"status,omitempty"`
+}
+
+// InboxDomainVerification describes the authentication state (DKIM, SPF and
+// DMARC) of the domain an inbox sends email from
+type InboxDomainVerification struct {
+	Domain        *string                   `json:"domain,omitempty"`
+	Status        *DomainVerificationStatus `json:"status,omitempty"`
+	DKIM          *DomainRecord             `json:"dkim,omitempty"`
+	SPF           *DomainRecord             `json:"spf,omitempty"`
+	DMARC         *DomainRecord             `json:"dmarc,omitempty"`
+	ReturnPath    *DomainRecord             `json:"returnPath,omitempty"`
+	LastCheckedAt *time.Time                `json:"lastCheckedAt,omitempty"`
+}
+
+// IsVerified reports whether the domain has passed verification
+func (v InboxDomainVerification) IsVerified() bool {
+	return v.Status != nil && *v.Status == DomainVerificationStatusVerified
+}
+
+// InboxDomainVerificationResponse represents the response for an inbox's
+// domain verification status
+type InboxDomainVerificationResponse struct {
+	DomainVerification InboxDomainVerification `json:"domainVerification"`
+}
+
+// EnvelopeKeys implements Entity
+func (Inbox) EnvelopeKeys() (string, string) { return "inbox", "inboxes" }
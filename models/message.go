@@ -5,21 +5,34 @@ import (
 	"time"
 )
 
+// ThreadType distinguishes customer-visible messages from internal notes
+type ThreadType string
+
+const (
+	ThreadTypeMessage ThreadType = "message"
+	ThreadTypeNote    ThreadType = "note"
+)
+
 // Message related types
 type Message struct {
 	BaseEntity
-	AssigningUser      *EntityRef `json:"assigningUser,omitempty"`
-	BCC                []string   `json:"bcc"`
-	CC                 []string   `json:"cc"`
-	Contact            *EntityRef `json:"contact,omitempty"`
-	Delayed            *bool      `json:"delayed,omitempty"`
-	EditMethod         *string    `json:"editMethod,omitempty"`
-	Message            *string    `json:"message,omitempty"`
-	IsPinned           *bool      `json:"isPinned,omitempty"`
-	Status             *EntityRef `json:"status,omitempty"`
-	ThreadType         *string    `json:"threadType,omitempty"`
-	Ticket             EntityRef  `json:"ticket"`
-	ViewedByCustomerAt *time.Time `json:"viewedByCustomerAt"`
+	AssigningUser      *EntityRef  `json:"assigningUser,omitempty"`
+	BCC                []string    `json:"bcc"`
+	CC                 []string    `json:"cc"`
+	Contact            *EntityRef  `json:"contact,omitempty"`
+	Delayed            *bool       `json:"delayed,omitempty"`
+	EditMethod         *string     `json:"editMethod,omitempty"`
+	Files              []EntityRef `json:"files,omitempty"`
+	Message            *string     `json:"message,omitempty"`
+	IsPinned           *bool       `json:"isPinned,omitempty"`
+	Status             *EntityRef  `json:"status,omitempty"`
+	ThreadType         *ThreadType `json:"threadType,omitempty"`
+	Ticket             EntityRef   `json:"ticket"`
+	ViewedByCustomerAt *time.Time  `json:"viewedByCustomerAt"`
+	VisibleInPortal    *bool       `json:"visibleInPortal,omitempty"`
+	// To is only used when forwarding a ticket, for the addresses it is
+	// forwarded to
+	To []string `json:"to,omitempty"`
 }
 
 func (m *Message) UnmarshalJSON(data []byte) error {
@@ -48,3 +61,17 @@ type MessagesResponse struct {
 	Pagination Pagination   `json:"pagination"`
 	Meta       Meta         `json:"meta"`
 }
+
+// MessageSource holds the original email a message was created from,
+// including headers and MIME parts, exactly as it was received
+type MessageSource struct {
+	Source string `json:"source"`
+}
+
+// MessageSourceResponse represents the response for a message's raw source
+type MessageSourceResponse struct {
+	MessageSource MessageSource `json:"messageSource"`
+}
+
+// EnvelopeKeys implements Entity
+func (Message) EnvelopeKeys() (string, string) { return "message", "messages" }
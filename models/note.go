@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Note is an internal comment on a ticket, visible to agents only. Desk
+// stores notes as messages with the note thread type; NoteFromMessage and
+// Note.Message convert between the two.
+type Note struct {
+	ID       int `json:"id"`
+	TicketID int `json:"ticketId"`
+	// Agent is the user who wrote the note
+	Agent     *UserRef    `json:"agent,omitempty"`
+	Body      string      `json:"body"`
+	Files     []EntityRef `json:"files,omitempty"`
+	IsPinned  bool        `json:"isPinned"`
+	CreatedAt *time.Time  `json:"createdAt,omitempty"`
+}
+
+// NoteFromMessage returns the note held by m
+func NoteFromMessage(m Message) Note {
+	n := Note{
+		ID:        m.ID,
+		TicketID:  m.Ticket.ID,
+		Agent:     m.CreatedBy,
+		Files:     m.Files,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.Message != nil {
+		n.Body = *m.Message
+	}
+	if m.IsPinned != nil {
+		n.IsPinned = *m.IsPinned
+	}
+	return n
+}
+
+// Message returns the note as a message to send to the API
+func (n Note) Message() Message {
+	threadType := ThreadTypeNote
+	body := n.Body
+	m := Message{
+		BaseEntity: BaseEntity{ID: n.ID, CreatedAt: n.CreatedAt, CreatedBy: n.Agent},
+		Message:    &body,
+		Files:      n.Files,
+		ThreadType: &threadType,
+		Ticket:     EntityRef{ID: n.TicketID},
+	}
+	if n.IsPinned {
+		m.IsPinned = &n.IsPinned
+	}
+	return m
+}
+
+// IsNote reports whether m is an internal note rather than a message to or
+// from the customer
+func (m Message) IsNote() bool {
+	return m.ThreadType != nil && *m.ThreadType == ThreadTypeNote
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ApplyPatch merges patch, as decoded from JSON, into the model target points
+// to. Only the keys present in patch change; everything else is kept.
+//
+//   - Keys are matched against the target's JSON field names, ignoring case.
+//     An exact match wins over a case-insensitive one. Unknown keys are an
+//     error.
+//   - A null value clears the field.
+//   - An object patches a nested struct field key by key, allocating it if
+//     it was nil, rather than replacing it.
+//   - A reference (EntityRef) can be given as a bare ID, so {"priority": 3}
+//     is the same as {"priority": {"id": 3}}. Lists of references accept
+//     IDs too.
+//   - Any other value, including every slice, replaces the field and is
+//     decoded with encoding/json.
+//
+// All problems are reported together, and fields that could be patched are
+// patched even when others fail.
+func ApplyPatch(target any, patch map[string]any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a non-nil pointer to a struct, got %T", target)
+	}
+
+	return patchStruct(v.Elem(), patch, "")
+}
+
+func patchStruct(v reflect.Value, patch map[string]any, path string) error {
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		value := patch[key]
+		field, ok := patchField(v, key)
+		name := key
+		if path != "" {
+			name = path + "." + key
+		}
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown field", name))
+			continue
+		}
+
+		if err := patchValue(field, value, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func patchValue(field reflect.Value, value any, name string) error {
+	if value == nil {
+		field.SetZero()
+		return nil
+	}
+
+	t := field.Type()
+	elem := t
+	if t.Kind() == reflect.Pointer {
+		elem = t.Elem()
+	}
+
+	switch {
+	case elem == reflect.TypeFor[EntityRef]():
+		if id, ok := patchID(value); ok {
+			value = map[string]any{"id": id}
+		}
+	case t.Kind() == reflect.Slice && t.Elem() == reflect.TypeFor[EntityRef]():
+		if items, ok := value.([]any); ok {
+			refs := make([]any, len(items))
+			for i, item := range items {
+				refs[i] = item
+				if id, ok := patchID(item); ok {
+					refs[i] = map[string]any{"id": id}
+				}
+			}
+			value = refs
+		}
+	}
+
+	if nested, ok := value.(map[string]any); ok && elem.Kind() == reflect.Struct && elem != reflect.TypeFor[time.Time]() {
+		if t.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				field.Set(reflect.New(elem))
+			}
+			field = field.Elem()
+		}
+		return patchStruct(field, nested, name)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	decoded := reflect.New(t)
+	if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	field.Set(decoded.Elem())
+
+	return nil
+}
+
+// patchField finds the settable field of struct v named key in JSON,
+// including fields promoted from embedded structs
+func patchField(v reflect.Value, key string) (reflect.Value, bool) {
+	var fold reflect.Value
+	var found bool
+
+	var walk func(v reflect.Value) bool
+	walk = func(v reflect.Value) bool {
+		for i := range v.NumField() {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+				if walk(v.Field(i)) {
+					return true
+				}
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+
+			if name == key {
+				fold, found = v.Field(i), true
+				return true
+			}
+			if !found && strings.EqualFold(name, key) {
+				fold, found = v.Field(i), true
+			}
+		}
+		return false
+	}
+	walk(v)
+
+	return fold, found
+}
+
+// patchID returns value as an ID when it is a whole number
+func patchID(value any) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return int(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	subject := "Original"
+	preview := "Keep me"
+
+	tests := []struct {
+		name  string
+		patch string
+		check func(t *testing.T, ticket *Ticket)
+	}{
+		{
+			name:  "scalar",
+			patch: `{"subject": "Updated"}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if deref(ticket.Subject) != "Updated" {
+					t.Errorf("subject = %q, want Updated", deref(ticket.Subject))
+				}
+				if deref(ticket.PreviewText) != "Keep me" {
+					t.Errorf("previewText = %q, want it kept", deref(ticket.PreviewText))
+				}
+			},
+		},
+		{
+			name:  "case-insensitive key",
+			patch: `{"Subject": "Updated", "PREVIEWTEXT": "Also updated"}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if deref(ticket.Subject) != "Updated" || deref(ticket.PreviewText) != "Also updated" {
+					t.Errorf("got subject %q and previewText %q", deref(ticket.Subject), deref(ticket.PreviewText))
+				}
+			},
+		},
+		{
+			name:  "embedded base entity",
+			patch: `{"id": 9}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if ticket.ID != 9 {
+					t.Errorf("id = %d, want 9", ticket.ID)
+				}
+			},
+		},
+		{
+			name:  "null clears",
+			patch: `{"subject": null}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if ticket.Subject != nil {
+					t.Errorf("subject = %q, want nil", *ticket.Subject)
+				}
+			},
+		},
+		{
+			name:  "reference by id",
+			patch: `{"priority": 3, "agent": 5}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if ticket.Priority == nil || ticket.Priority.ID != 3 {
+					t.Errorf("priority = %+v, want ID 3", ticket.Priority)
+				}
+				if ticket.Agent == nil || ticket.Agent.ID != 5 {
+					t.Errorf("agent = %+v, want ID 5", ticket.Agent)
+				}
+			},
+		},
+		{
+			name:  "reference object merges",
+			patch: `{"status": {"id": 4}}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if ticket.Status == nil || ticket.Status.ID != 4 || ticket.Status.Type != "ticketstatuses" {
+					t.Errorf("status = %+v, want ID 4 with the type kept", ticket.Status)
+				}
+			},
+		},
+		{
+			name:  "reference list by id",
+			patch: `{"tags": [1, {"id": 2, "type": "tags"}]}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if len(ticket.Tags) != 2 || ticket.Tags[0].ID != 1 || ticket.Tags[1].ID != 2 {
+					t.Errorf("tags = %+v, want IDs 1 and 2", ticket.Tags)
+				}
+			},
+		},
+		{
+			name:  "slice replaces",
+			patch: `{"cc": ["new@example.com"]}`,
+			check: func(t *testing.T, ticket *Ticket) {
+				if len(ticket.CC) != 1 || ticket.CC[0] != "new@example.com" {
+					t.Errorf("cc = %v, want only the new address", ticket.CC)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &Ticket{
+				BaseEntity:  BaseEntity{ID: 1},
+				Subject:     ptr(subject),
+				PreviewText: ptr(preview),
+				Status:      &EntityRef{ID: 1, Type: "ticketstatuses"},
+				CC:          []string{"old@example.com", "other@example.com"},
+			}
+
+			var patch map[string]any
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("invalid test patch: %v", err)
+			}
+
+			if err := ApplyPatch(ticket, patch); err != nil {
+				t.Fatalf("ApplyPatch() returned error: %v", err)
+			}
+
+			tt.check(t, ticket)
+		})
+	}
+}
+
+func TestApplyPatchNestedStruct(t *testing.T) {
+	inbox := &InboxResponse{Inbox: Inbox{Name: ptr("Support")}}
+
+	err := ApplyPatch(inbox, map[string]any{"inbox": map[string]any{"email": "help@example.com"}})
+	if err != nil {
+		t.Fatalf("ApplyPatch() returned error: %v", err)
+	}
+
+	if deref(inbox.Inbox.Name) != "Support" {
+		t.Errorf("name = %q, want it kept", deref(inbox.Inbox.Name))
+	}
+	if deref(inbox.Inbox.Email) != "help@example.com" {
+		t.Errorf("email = %q, want help@example.com", deref(inbox.Inbox.Email))
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	ticket := &Ticket{}
+
+	err := ApplyPatch(ticket, map[string]any{"nope": 1, "subject": 5, "isRead": true})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, want := range []string{"nope: unknown field", "subject:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got %v", want, err)
+		}
+	}
+
+	if ticket.IsRead == nil || !*ticket.IsRead {
+		t.Error("expected valid fields to be patched despite other errors")
+	}
+
+	if err := ApplyPatch(*ticket, nil); err == nil {
+		t.Error("expected an error for a non-pointer target")
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
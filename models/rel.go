@@ -0,0 +1,113 @@
+package models
+
+import "encoding/json"
+
+// UnmarshalJSON implements json.Unmarshaler. Besides the usual
+// {"id":1,"type":"tags"} reference it accepts a bare ID and an embedded
+// object, which some endpoints return in place of a reference depending on
+// the requested includes. Embedded objects whose "type" is not a string leave
+// Type empty instead of failing the whole response.
+func (r *EntityRef) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		*r = EntityRef{ID: id}
+		return nil
+	}
+
+	var obj struct {
+		ID   int             `json:"id"`
+		Type json.RawMessage `json:"type"`
+		Meta map[string]any  `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	ref := EntityRef{ID: obj.ID, Meta: obj.Meta}
+	if len(obj.Type) > 0 {
+		_ = json.Unmarshal(obj.Type, &ref.Type)
+	}
+	*r = ref
+	return nil
+}
+
+// unmarshalRefMeta decodes a reference whose meta has a known shape. Types
+// that embed EntityRef need it because EntityRef.UnmarshalJSON is promoted
+// to them and would otherwise decode meta into EntityRef.Meta only.
+func unmarshalRefMeta(data []byte, ref *EntityRef, meta any) error {
+	if err := ref.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	var obj struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil || len(obj.Meta) == 0 {
+		// A bare ID has no meta
+		return nil
+	}
+	return json.Unmarshal(obj.Meta, meta)
+}
+
+// Rel is a relation to a T that decodes whether the API sent a reference or
+// the full embedded object. Use it for new fields whose shape depends on the
+// includes requested:
+//
+//	if inbox, ok := rel.Get(); ok {
+//		fmt.Println(*inbox.Name)
+//	} else {
+//		fmt.Println(rel.ID())
+//	}
+//
+// It always encodes as a reference.
+type Rel[T any] struct {
+	ref  EntityRef
+	item *T
+}
+
+// RelTo returns a relation to the entity with the given ID
+func RelTo[T any](id int) Rel[T] {
+	return Rel[T]{ref: EntityRef{ID: id}}
+}
+
+// ID returns the ID of the related entity
+func (r Rel[T]) ID() int { return r.ref.ID }
+
+// Ref returns the relation as an EntityRef
+func (r Rel[T]) Ref() EntityRef { return r.ref }
+
+// Get returns the embedded entity and true when the API sent the full
+// object, or nil and false when it only sent a reference
+func (r Rel[T]) Get() (*T, bool) { return r.item, r.item != nil }
+
+// MarshalJSON implements json.Marshaler
+func (r Rel[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.ref)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *Rel[T]) UnmarshalJSON(data []byte) error {
+	var ref EntityRef
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	*r = Rel[T]{ref: ref}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		// A bare ID
+		return nil
+	}
+	for k := range fields {
+		if k != "id" && k != "type" && k != "meta" {
+			var item T
+			if err := json.Unmarshal(data, &item); err != nil {
+				return err
+			}
+			r.item = &item
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRelDecoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		embedded bool
+	}{
+		{"bare ID", `7`, false},
+		{"reference", `{"id":7,"type":"inboxes","meta":{}}`, false},
+		{"embedded object", `{"id":7,"type":"email","name":"Support"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rel Rel[Inbox]
+			if err := json.Unmarshal([]byte(tt.data), &rel); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if rel.ID() != 7 {
+				t.Errorf("expected ID 7, got %d", rel.ID())
+			}
+
+			inbox, ok := rel.Get()
+			if ok != tt.embedded {
+				t.Fatalf("expected embedded=%v, got %v", tt.embedded, ok)
+			}
+			if ok && deref(inbox.Name) != "Support" {
+				t.Errorf("expected embedded inbox name, got %q", deref(inbox.Name))
+			}
+
+			b, err := json.Marshal(rel)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			var ref EntityRef
+			if err := json.Unmarshal(b, &ref); err != nil || ref.ID != 7 {
+				t.Errorf("expected a reference to 7, got %s", b)
+			}
+		})
+	}
+}
+
+func TestEntityRefAcceptsEmbeddedObjects(t *testing.T) {
+	var ticket Ticket
+	data := `{"id":1,"inbox":{"id":3,"type":{"name":"email"},"name":"Support"},"tags":[4,{"id":5,"type":"tags"}]}`
+	if err := json.Unmarshal([]byte(data), &ticket); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if ticket.Inbox == nil || ticket.Inbox.ID != 3 || ticket.Inbox.Type != "" {
+		t.Errorf("expected inbox ref 3 with no type, got %+v", ticket.Inbox)
+	}
+	if len(ticket.Tags) != 2 || ticket.Tags[0].ID != 4 || ticket.Tags[1].Type != "tags" {
+		t.Errorf("expected tag refs 4 and 5, got %+v", ticket.Tags)
+	}
+}
+
+func TestEntityRefEmbeddedMeta(t *testing.T) {
+	var inbox Inbox
+	data := `{"users":[{"id":8,"type":"users","meta":{"access":"manage","isAdmin":true}}],"triggers":[{"id":2,"type":"triggers","meta":{"displayOrder":3}}],"inboxcnames":[{"id":4,"meta":{"domain":"help.example.com"}}]}`
+	if err := json.Unmarshal([]byte(data), &inbox); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if len(inbox.Users) != 1 || inbox.Users[0].ID != 8 || inbox.Users[0].Meta.Access == nil || *inbox.Users[0].Meta.Access != InboxAccessManage {
+		t.Errorf("expected user 8 with manage access, got %+v", inbox.Users)
+	}
+	if len(inbox.Triggers) != 1 || inbox.Triggers[0].Meta.DisplayOrder == nil || *inbox.Triggers[0].Meta.DisplayOrder != 3 {
+		t.Errorf("expected trigger 2 at position 3, got %+v", inbox.Triggers)
+	}
+	if len(inbox.Inboxcnames) != 1 || inbox.Inboxcnames[0].Meta.Domain == nil || *inbox.Inboxcnames[0].Meta.Domain != "help.example.com" {
+		t.Errorf("expected cname 4 for help.example.com, got %+v", inbox.Inboxcnames)
+	}
+
+	var user InboxUser
+	if err := json.Unmarshal([]byte(`8`), &user); err != nil || user.ID != 8 {
+		t.Errorf("expected a bare ID to decode, got %+v, %v", user, err)
+	}
+}
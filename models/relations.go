@@ -0,0 +1,135 @@
+package models
+
+// Relation adds related records to the Included block of a create or update
+// request. The API creates the related records alongside the main one, so a
+// customer can be created with its contacts, or an SLA with its conditions, in
+// a single call.
+type Relation func(*IncludedData)
+
+// Include builds an IncludedData from relations
+//
+//	c.Customers.Create(ctx, &models.CustomerResponse{
+//		Customer: models.Customer{Email: &email},
+//		Included: models.Include(models.WithRelatedContacts(models.EmailContact(email, true))),
+//	})
+func Include(relations ...Relation) IncludedData {
+	var included IncludedData
+	included.Add(relations...)
+	return included
+}
+
+// Add applies relations to the included data
+func (i *IncludedData) Add(relations ...Relation) {
+	for _, r := range relations {
+		if r != nil {
+			r(i)
+		}
+	}
+}
+
+// ContactType is the kind of a customer contact
+type ContactType string
+
+const (
+	// ContactTypeEmail is an email address
+	ContactTypeEmail ContactType = "email"
+	// ContactTypePhone is a phone number
+	ContactTypePhone ContactType = "phone"
+)
+
+// EmailContact returns an email contact for a customer. A customer should
+// have exactly one main email contact, which is where replies are sent.
+func EmailContact(email string, main bool) Contact {
+	return Contact{BaseEntity: BaseEntity{Type: string(ContactTypeEmail)}, Value: &email, IsMain: &main}
+}
+
+// PhoneContact returns a phone contact for a customer
+func PhoneContact(number string) Contact {
+	main := false
+	return Contact{BaseEntity: BaseEntity{Type: string(ContactTypePhone)}, Value: &number, IsMain: &main}
+}
+
+// WithRelatedContacts creates the contacts together with a customer
+func WithRelatedContacts(contacts ...Contact) Relation {
+	return func(i *IncludedData) {
+		i.Contacts = append(i.Contacts, contacts...)
+	}
+}
+
+// WithDomains creates the domains together with a company. Customers whose
+// email address is on one of the domains are added to the company.
+func WithDomains(names ...string) Relation {
+	return func(i *IncludedData) {
+		for _, name := range names {
+			i.Domains = append(i.Domains, Domain{Name: &name})
+		}
+	}
+}
+
+// WithSLAInboxes makes an SLA apply to tickets in (or, with
+// SLAConditionOptionNotEqual, outside) the inboxes
+func WithSLAInboxes(condition SLAConditionOption, inboxIDs ...int) Relation {
+	return func(i *IncludedData) {
+		for _, id := range inboxIDs {
+			i.SLAInboxes = append(i.SLAInboxes, SLAInbox{Inbox: &EntityRef{ID: id}, Condition: &condition})
+		}
+	}
+}
+
+// WithSLACompanies makes an SLA apply to tickets from customers of the
+// companies
+func WithSLACompanies(condition SLAConditionOption, companyIDs ...int) Relation {
+	return func(i *IncludedData) {
+		for _, id := range companyIDs {
+			i.SLACompanies = append(i.SLACompanies, SLACompany{Company: &EntityRef{ID: id}, Condition: &condition})
+		}
+	}
+}
+
+// WithSLACustomers makes an SLA apply to tickets from the customers
+func WithSLACustomers(condition SLAConditionOption, customerIDs ...int) Relation {
+	return func(i *IncludedData) {
+		for _, id := range customerIDs {
+			i.SLACustomers = append(i.SLACustomers, SLACustomer{Customer: &EntityRef{ID: id}, Condition: &condition})
+		}
+	}
+}
+
+// WithSLATags makes an SLA apply to tickets with the tags
+func WithSLATags(condition SLAConditionOption, tagIDs ...int) Relation {
+	return func(i *IncludedData) {
+		for _, id := range tagIDs {
+			i.SLATags = append(i.SLATags, SLATag{Tag: &EntityRef{ID: id}, Condition: &condition})
+		}
+	}
+}
+
+// WithSLAPriority sets an SLA's target time for tickets of a priority. A
+// priorityID of 0 sets the target for tickets without a priority.
+func WithSLAPriority(priorityID, hours, minutes int, description string) Relation {
+	return func(i *IncludedData) {
+		p := SLATicketPriority{Hours: &hours, Minutes: &minutes, Description: &description}
+		if priorityID > 0 {
+			p.TicketPriority = &EntityRef{ID: priorityID}
+		}
+		i.SLAPriorities = append(i.SLAPriorities, p)
+	}
+}
+
+// WithSLANotification notifies the assigned agent when an SLA target is about
+// to be missed or has been breached. duration is in minutes before the target
+// for warnings and is ignored for breaches.
+func WithSLANotification(condition SLANotificationConditionType, typ SLANotificationType, duration int) Relation {
+	return func(i *IncludedData) {
+		if condition == SLANotificationConditionTypeBreach {
+			duration = 0
+		}
+		notify := true
+		i.SLANotifications = append(i.SLANotifications, SLANotification{
+			Condition:          &condition,
+			Type:               &typ,
+			Duration:           &duration,
+			NotifyAssignedUser: &notify,
+		})
+	}
+}
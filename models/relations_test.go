@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInclude(t *testing.T) {
+	included := Include(
+		WithRelatedContacts(EmailContact("jane@example.com", true), PhoneContact("+353 1 234 5678")),
+		WithDomains("example.com", "example.org"),
+		WithSLANotification(SLANotificationConditionTypeBreach, SLANotificationTypeFirstResponse, 15),
+		nil,
+	)
+
+	if len(included.Contacts) != 2 {
+		t.Fatalf("expected 2 contacts, got %d", len(included.Contacts))
+	}
+	if included.Contacts[0].Type != "email" || !deref(included.Contacts[0].IsMain) {
+		t.Errorf("expected a main email contact, got %+v", included.Contacts[0])
+	}
+
+	if len(included.Domains) != 2 || deref(included.Domains[1].Name) != "example.org" {
+		t.Errorf("expected both domains, got %+v", included.Domains)
+	}
+
+	if got := deref(included.SLANotifications[0].Duration); got != 0 {
+		t.Errorf("expected breach duration to be 0, got %d", got)
+	}
+
+	included.Add(WithSLAPriority(0, 4, 30, "No priority"))
+	b, err := json.Marshal(included.SLAPriorities[0])
+	if err != nil {
+		t.Fatalf("failed to encode priority: %v", err)
+	}
+	if !strings.Contains(string(b), `"priority":null`) {
+		t.Errorf("expected no priority reference, got %s", b)
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// ReportInterval is the period each row of a time series report covers
+type ReportInterval string
+
+const (
+	ReportIntervalDay   ReportInterval = "day"
+	ReportIntervalWeek  ReportInterval = "week"
+	ReportIntervalMonth ReportInterval = "month"
+)
+
+// TicketVolumeRow is one period of the ticket volume report
+type TicketVolumeRow struct {
+	// Date is the start of the period
+	Date     time.Time `json:"date"`
+	Created  int       `json:"created"`
+	Solved   int       `json:"solved"`
+	Closed   int       `json:"closed"`
+	Reopened int       `json:"reopened"`
+}
+
+// AgentPerformanceRow is one agent's line of the agent performance report.
+// Times are medians over the report's range.
+type AgentPerformanceRow struct {
+	Agent                 EntityRef `json:"agent"`
+	TicketsAssigned       int       `json:"ticketsAssigned"`
+	TicketsSolved         int       `json:"ticketsSolved"`
+	Replies               int       `json:"replies"`
+	FirstResponseTimeMins float64   `json:"firstResponseTimeMins"`
+	ResolutionTimeMins    float64   `json:"resolutionTimeMins"`
+}
+
+// HappinessSummaryRow is one period of the happiness summary report
+type HappinessSummaryRow struct {
+	// Date is the start of the period
+	Date    time.Time `json:"date"`
+	Happy   int       `json:"happy"`
+	Neutral int       `json:"neutral"`
+	Unhappy int       `json:"unhappy"`
+}
+
+// Total returns the number of ratings in the period
+func (r HappinessSummaryRow) Total() int {
+	return r.Happy + r.Neutral + r.Unhappy
+}
+
+// Score returns the average rating in the period on the same 1 to 3 scale as
+// HappinessRating.Score, or 0 when there were no ratings
+func (r HappinessSummaryRow) Score() float64 {
+	total := r.Total()
+	if total == 0 {
+		return 0
+	}
+	sum := r.Happy*HappinessRatingHappy.Score() + r.Neutral*HappinessRatingNeutral.Score() + r.Unhappy*HappinessRatingUnhappy.Score()
+	return float64(sum) / float64(total)
+}
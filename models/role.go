@@ -0,0 +1,39 @@
+package models
+
+import "slices"
+
+// Permission is a single capability granted by a role, such as
+// "tickets.delete"
+type Permission string
+
+const (
+	PermissionTicketsDelete   Permission = "tickets.delete"
+	PermissionTicketsMerge    Permission = "tickets.merge"
+	PermissionCustomersManage Permission = "customers.manage"
+	PermissionHelpDocsManage  Permission = "helpdocs.manage"
+	PermissionReportsView     Permission = "reports.view"
+	PermissionSettingsManage  Permission = "settings.manage"
+	PermissionUsersManage     Permission = "users.manage"
+)
+
+// Role is a named set of permissions that can be given to agents
+type Role struct {
+	BaseEntity
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// IsBuiltIn marks the roles Desk ships with
+	IsBuiltIn   *bool        `json:"isBuiltIn,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (Role) EnvelopeKeys() (string, string) { return "role", "roles" }
+
+type RoleResponse = Single[Role]
+
+type RolesResponse = List[Role]
+
+// HasPermission reports whether the role grants p
+func (r Role) HasPermission(p Permission) bool {
+	return slices.Contains(r.Permissions, p)
+}
@@ -93,3 +93,6 @@ type SLAResponse struct {
 	SLA      SLA          `json:"sla"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (SLA) EnvelopeKeys() (string, string) { return "sla", "slas" }
@@ -1,11 +1,20 @@
 package models
 
+// SpamlistType is whether matching senders are always allowed or always
+// rejected
+type SpamlistType string
+
+const (
+	SpamlistTypeWhitelist SpamlistType = "whitelist"
+	SpamlistTypeBlacklist SpamlistType = "blacklist"
+)
+
 // Spamlist represents a spamlist entry.  Term can be an email address, domain,
 // or IP address.  Type is whitelist or blacklist.
 type Spamlist struct {
 	BaseEntity
-	Term *string `json:"term,omitempty"`
-	Type *string `json:"type,omitempty"`
+	Term *string       `json:"term,omitempty"`
+	Type *SpamlistType `json:"type,omitempty"`
 }
 
 // SpamlistsResponse represents the response for a list of spam lists
@@ -20,3 +29,6 @@ type SpamlistResponse struct {
 	Spamlist Spamlist     `json:"spamlist"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (Spamlist) EnvelopeKeys() (string, string) { return "spamlist", "spamlists" }
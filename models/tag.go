@@ -19,3 +19,6 @@ type TagResponse struct {
 	Tag      Tag          `json:"tag"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (Tag) EnvelopeKeys() (string, string) { return "tag", "tags" }
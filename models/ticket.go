@@ -12,6 +12,7 @@ type Ticket struct {
 	CC                    []string    `json:"cc,omitempty"`
 	Contact               *EntityRef  `json:"contact,omitempty"`
 	Customer              *EntityRef  `json:"customer,omitempty"`
+	ExternalID            *string     `json:"externalId,omitempty"`
 	Files                 []EntityRef `json:"files,omitempty"`
 	HappinessSurveySentAt *time.Time  `json:"happinessSurveySentAt"`
 	ImagesHidden          *bool       `json:"imagesHidden,omitempty"`
@@ -36,6 +37,12 @@ type Ticket struct {
 	Tasks                 []Task      `json:"tasks,omitempty"`
 	Timelogs              []EntityRef `json:"timelogs,omitempty"`
 	Type                  *EntityRef  `json:"type,omitempty"`
+	VisibleInPortal       *bool       `json:"visibleInPortal,omitempty"`
+	// CustomFieldValues holds the ticket's custom field values by field
+	// handle
+	CustomFieldValues CustomFieldValues `json:"customFieldValues,omitempty"`
+	// SnoozedUntil is when a snoozed ticket comes back to the inbox
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
 }
 
 // Response types for tickets
@@ -51,6 +58,33 @@ type TicketResponse struct {
 	Included IncludedData `json:"included"`
 }
 
+// ParticipantType identifies how a participant receives copies of a ticket
+// thread
+type ParticipantType string
+
+const (
+	ParticipantTypeCC  ParticipantType = "cc"
+	ParticipantTypeBCC ParticipantType = "bcc"
+)
+
+// TicketParticipants holds the CC and BCC recipients of a ticket thread
+type TicketParticipants struct {
+	CC  []string `json:"cc"`
+	BCC []string `json:"bcc"`
+}
+
+// TicketParticipantsResponse represents the response for a ticket's
+// participants
+type TicketParticipantsResponse struct {
+	Participants TicketParticipants `json:"participants"`
+}
+
+// TicketParticipant represents a single participant being added to a ticket
+type TicketParticipant struct {
+	Email string          `json:"email"`
+	Type  ParticipantType `json:"type"`
+}
+
 type CustomFieldsSearch []CustomFieldSearch
 
 type CustomFieldSearch struct {
@@ -99,6 +133,8 @@ type SearchTicketsFilter struct {
 	OmitMerged            bool               `qs:"omitMerged"`
 	OnlyUntagged          bool               `qs:"onlyUntagged"`
 	OnlyWithAttachment    bool               `qs:"onlyWithAttachment"`
+	Page                  int                `qs:"page,omitempty"`
+	PageSize              int                `qs:"pageSize,omitempty"`
 	Priorities            []int64            `qs:"priorities"`
 	ProjectID             *int64             `qs:"project,omitempty"`
 	RequireAllTags        bool               `qs:"tagRequireAll"`
@@ -117,3 +153,20 @@ type SearchTicketsFilter struct {
 	Types                 []int64            `qs:"types"`
 	Unassigned            bool               `qs:"unassigned"`
 }
+
+// EnvelopeKeys implements Entity
+func (Ticket) EnvelopeKeys() (string, string) { return "ticket", "tickets" }
+
+// TicketBulkChanges are the changes applied to every ticket in a bulk update.
+// Unset fields are left as they are. Only the ID of each reference is used.
+type TicketBulkChanges struct {
+	Agent    *EntityRef `json:"agent,omitempty"`
+	Inbox    *EntityRef `json:"inbox,omitempty"`
+	Priority *EntityRef `json:"priority,omitempty"`
+	Status   *EntityRef `json:"status,omitempty"`
+	Type     *EntityRef `json:"type,omitempty"`
+	// AddTags and RemoveTags add tags to and remove tags from each ticket,
+	// keeping its other tags
+	AddTags    []EntityRef `json:"addTags,omitempty"`
+	RemoveTags []EntityRef `json:"removeTags,omitempty"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 // TicketActivity related types
 type TicketActivity struct {
 	BaseEntity
@@ -11,4 +13,45 @@ type TicketActivity struct {
 	Inbox       any        `json:"inbox"`
 	OldInbox    any        `json:"oldInbox"`
 	Status      *EntityRef `json:"status,omitempty"`
+	// OldStatus is the status before a status change
+	OldStatus *EntityRef `json:"oldStatus,omitempty"`
+	// MergedTicket is the other ticket of a merge
+	MergedTicket *EntityRef `json:"mergedTicket,omitempty"`
+}
+
+// TicketActivityKind groups activity log entries by what they record
+type TicketActivityKind string
+
+const (
+	TicketActivityKindStatusChange TicketActivityKind = "statusChange"
+	TicketActivityKindAssignment   TicketActivityKind = "assignment"
+	TicketActivityKindMerge        TicketActivityKind = "merge"
+	TicketActivityKindInboxChange  TicketActivityKind = "inboxChange"
+	TicketActivityKindSLA          TicketActivityKind = "sla"
+	TicketActivityKindOther        TicketActivityKind = "other"
+)
+
+// Kind classifies the entry. The activity log does not have a fixed set of
+// event types, so entries are classified by the fields they carry and,
+// failing that, by name.
+func (a TicketActivity) Kind() TicketActivityKind {
+	var eventType string
+	if a.EventType != nil {
+		eventType = strings.ToLower(*a.EventType)
+	}
+
+	switch {
+	case a.MergedTicket != nil || strings.Contains(eventType, "merge"):
+		return TicketActivityKindMerge
+	case strings.Contains(eventType, "sla"):
+		return TicketActivityKindSLA
+	case a.TargetAgent != nil || strings.Contains(eventType, "assign"):
+		return TicketActivityKindAssignment
+	case a.OldInbox != nil || strings.Contains(eventType, "inbox"):
+		return TicketActivityKindInboxChange
+	case strings.Contains(eventType, "status"):
+		return TicketActivityKindStatusChange
+	default:
+		return TicketActivityKindOther
+	}
 }
@@ -0,0 +1,26 @@
+package models
+
+// TicketFilter is a saved ticket view, such as "My tickets" or a custom view
+// an agent has set up
+type TicketFilter struct {
+	BaseEntity
+	Name *string `json:"name,omitempty"`
+	// Filter holds the view's conditions in the same format as the filter
+	// query parameter of ticket lists
+	Filter *string `json:"filter,omitempty"`
+	// IsDefault marks the views Desk ships with
+	IsDefault *bool `json:"isDefault,omitempty"`
+	// IsShared views are visible to every agent, others only to Agent
+	IsShared     *bool      `json:"isShared,omitempty"`
+	Agent        *EntityRef `json:"agent,omitempty"`
+	DisplayOrder *int       `json:"displayOrder,omitempty"`
+	// TicketCount is the number of tickets in the view when it was fetched
+	TicketCount *int `json:"ticketCount,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (TicketFilter) EnvelopeKeys() (string, string) { return "ticketFilter", "ticketFilters" }
+
+type TicketFilterResponse = Single[TicketFilter]
+
+type TicketFiltersResponse = List[TicketFilter]
@@ -19,3 +19,6 @@ type TicketPriorityResponse struct {
 	TicketPriority TicketPriority `json:"ticketpriority"`
 	Included       IncludedData   `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (TicketPriority) EnvelopeKeys() (string, string) { return "ticketpriority", "ticketpriorities" }
@@ -9,6 +9,15 @@ type TicketSource struct {
 	IsCustom     *bool   `json:"isCustom,omitempty"`
 }
 
+// TicketSourceCount is the number of tickets received through a source, such
+// as email, a form, the API or chat
+type TicketSourceCount struct {
+	Source TicketSource `json:"source"`
+	Count  int          `json:"count"`
+	// Share is Count as a fraction of all tickets counted, between 0 and 1
+	Share float64 `json:"share"`
+}
+
 type TicketSourcesResponse struct {
 	TicketSources []TicketSource `json:"ticketSources"`
 	Meta          Meta           `json:"meta"`
@@ -20,3 +29,6 @@ type TicketSourceResponse struct {
 	TicketSource TicketSource `json:"ticketSource"`
 	Included     IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (TicketSource) EnvelopeKeys() (string, string) { return "ticketSource", "ticketSources" }
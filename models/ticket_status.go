@@ -22,3 +22,6 @@ type TicketStatusResponse struct {
 	TicketStatus TicketStatus `json:"ticketstatus"`
 	Included     IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (TicketStatus) EnvelopeKeys() (string, string) { return "ticketstatus", "ticketstatuses" }
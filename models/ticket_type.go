@@ -21,3 +21,6 @@ type TicketTypeResponse struct {
 	TicketType TicketType   `json:"tickettype"`
 	Included   IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (TicketType) EnvelopeKeys() (string, string) { return "tickettype", "tickettypes" }
@@ -13,7 +13,9 @@ type TimeLog struct {
 	TimelogsID          any        `json:"timelogs_id"`
 	AssignToCurrentUser *bool      `json:"assignToCurrentUser,omitempty"`
 	Ticket              EntityRef  `json:"ticket"`
-	User                EntityRef  `json:"user"`
+	// User is who the time is logged for. Leave it nil to let the API pick,
+	// or set AssignToCurrentUser to log it for the API key's user.
+	User *EntityRef `json:"user,omitempty"`
 }
 
 type TimeLogsResponse struct {
@@ -27,3 +29,6 @@ type TimeLogResponse struct {
 	TimeLog  TimeLog      `json:"timeLog"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (TimeLog) EnvelopeKeys() (string, string) { return "timeLog", "timeLogs" }
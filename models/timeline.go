@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// TimelineEventType identifies what a timeline entry represents
+type TimelineEventType string
+
+const (
+	TimelineEventTypeMessage      TimelineEventType = "message"
+	TimelineEventTypeNote         TimelineEventType = "note"
+	TimelineEventTypeStatusChange TimelineEventType = "statusChange"
+	TimelineEventTypeSLA          TimelineEventType = "sla"
+	TimelineEventTypeActivity     TimelineEventType = "activity"
+)
+
+// TimelineEvent is a single entry in a ticket timeline. Exactly one of
+// Message or Activity is set, depending on Type.
+type TimelineEvent struct {
+	Type     TimelineEventType `json:"type"`
+	At       time.Time         `json:"at"`
+	Message  *Message          `json:"message,omitempty"`
+	Activity *TicketActivity   `json:"activity,omitempty"`
+}
+
+// Timeline is everything that happened on a ticket, oldest event first
+type Timeline struct {
+	TicketID int             `json:"ticketId"`
+	Events   []TimelineEvent `json:"events"`
+}
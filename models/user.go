@@ -1,5 +1,7 @@
 package models
 
+import "slices"
+
 // User related types
 type User struct {
 	BaseEntity
@@ -21,6 +23,23 @@ type User struct {
 	ProjectsCompanyID        *int       `json:"projectsCompanyId,omitempty"`
 	IsAppOwner               *bool      `json:"isAppOwner,omitempty"`
 	LdKey                    *string    `json:"ldKey,omitempty"`
+	// AgentRole is the role that sets the user's permissions. Role is the
+	// older coarse account type, such as "admin", and is kept as it is.
+	AgentRole *EntityRef `json:"agentRole,omitempty"`
+	// Permissions are the permissions the user has through AgentRole. They
+	// are read-only; change them through the role.
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// IsActive reports whether the user can log in. Users whose state was not
+// returned are assumed to be active.
+func (u User) IsActive() bool {
+	return u.State == nil || *u.State == StateActive
+}
+
+// HasPermission reports whether the user has p through their role
+func (u User) HasPermission(p Permission) bool {
+	return slices.Contains(u.Permissions, p)
 }
 
 type UsersResponse struct {
@@ -34,3 +53,6 @@ type UserResponse struct {
 	User     User         `json:"user"`
 	Included IncludedData `json:"included"`
 }
+
+// EnvelopeKeys implements Entity
+func (User) EnvelopeKeys() (string, string) { return "user", "users" }
@@ -0,0 +1,24 @@
+package models
+
+// Webhook is a subscription that has Desk POST events to an integration's
+// endpoint
+type Webhook struct {
+	BaseEntity
+	// URL is the endpoint events are delivered to
+	URL *string `json:"url,omitempty"`
+	// Events are the event types the webhook is subscribed to, for example
+	// "ticket.created"
+	Events []string `json:"events,omitempty"`
+	// Secret signs each delivery so the receiver can check it came from Desk.
+	// It is write-only and not returned by the API.
+	Secret      *string `json:"secret,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
+
+// EnvelopeKeys implements Entity
+func (Webhook) EnvelopeKeys() (string, string) { return "webhook", "webhooks" }
+
+type WebhookResponse = Single[Webhook]
+
+type WebhooksResponse = List[Webhook]
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WebURL returns the Desk UI page for a resource, derived from the API base
+// URL a client was created with (for example
+// https://example.teamwork.com/desk/api/v2). resource is the plural resource
+// name, such as "tickets" or "helpdocarticles"; the CLI's short names
+// ("statuses", "types", ...) are accepted too.
+func WebURL(apiBaseURL, resource string, id int) (string, error) {
+	if id <= 0 {
+		return "", fmt.Errorf("id must be greater than 0")
+	}
+
+	page, ok := webPath(strings.ToLower(resource))
+	if !ok {
+		return "", fmt.Errorf("no web page for resource %q", resource)
+	}
+
+	u, err := url.Parse(apiBaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q", apiBaseURL)
+	}
+
+	// The API lives under the app root, e.g. /desk/api/v2 under /desk
+	root, _, found := strings.Cut(u.Path, "/api")
+	if !found || root == "" {
+		root = "/desk"
+	}
+
+	web := url.URL{Scheme: u.Scheme, Host: u.Host, Path: fmt.Sprintf("%s/%s/%d", strings.TrimSuffix(root, "/"), page, id)}
+	return web.String(), nil
+}
+
+// webPath maps a resource name to its page in the Desk UI, relative to the
+// app root
+func webPath(resource string) (string, bool) {
+	switch resource {
+	case "tickets":
+		return "tickets", true
+	case "customers":
+		return "customers", true
+	case "companies":
+		return "companies", true
+	case "users":
+		return "settings/users", true
+	case "inboxes":
+		return "settings/inboxes", true
+	case "tags":
+		return "settings/tags", true
+	case "statuses", "ticketstatuses":
+		return "settings/statuses", true
+	case "types", "tickettypes":
+		return "settings/types", true
+	case "priorities", "ticketpriorities":
+		return "settings/priorities", true
+	case "sources", "ticketsources":
+		return "settings/sources", true
+	case "spamlists":
+		return "settings/spam", true
+	case "slas":
+		return "settings/slas", true
+	case "businesshours":
+		return "settings/businesshours", true
+	case "helpdocsites":
+		return "helpdocs/sites", true
+	case "helpdocarticles":
+		return "helpdocs/articles", true
+	default:
+		return "", false
+	}
+}
+
+// WebURL returns the ticket's page in the Desk UI. apiBaseURL is the base URL
+// the client was created with.
+func (t *Ticket) WebURL(apiBaseURL string) (string, error) {
+	return WebURL(apiBaseURL, "tickets", t.ID)
+}
+
+// WebURL returns the customer's page in the Desk UI. apiBaseURL is the base
+// URL the client was created with.
+func (c *Customer) WebURL(apiBaseURL string) (string, error) {
+	return WebURL(apiBaseURL, "customers", c.ID)
+}
+
+// WebURL returns the company's page in the Desk UI. apiBaseURL is the base
+// URL the client was created with.
+func (c *Company) WebURL(apiBaseURL string) (string, error) {
+	return WebURL(apiBaseURL, "companies", c.ID)
+}
@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		resource string
+		want     string
+	}{
+		{"ticket", "https://acme.teamwork.com/desk/api/v2", "tickets", "https://acme.teamwork.com/desk/tickets/42"},
+		{"trailing slash", "https://acme.teamwork.com/desk/api/v2/", "customers", "https://acme.teamwork.com/desk/customers/42"},
+		{"settings page", "https://acme.teamwork.com/desk/api/v2", "ticketstatuses", "https://acme.teamwork.com/desk/settings/statuses/42"},
+		{"cli alias", "https://acme.teamwork.com/desk/api/v2", "Statuses", "https://acme.teamwork.com/desk/settings/statuses/42"},
+		{"custom domain", "https://support.example.com/api/v2", "companies", "https://support.example.com/desk/companies/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WebURL(tt.baseURL, tt.resource, 42)
+			if err != nil {
+				t.Fatalf("WebURL() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("WebURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := WebURL("https://acme.teamwork.com/desk/api/v2", "messages", 42); err == nil {
+		t.Error("expected error for a resource without a page")
+	}
+
+	if _, err := WebURL("not a url", "tickets", 42); err == nil {
+		t.Error("expected error for an invalid base URL")
+	}
+}
+
+func TestTicketWebURL(t *testing.T) {
+	ticket := Ticket{BaseEntity: BaseEntity{ID: 7}}
+
+	got, err := ticket.WebURL("https://acme.teamwork.com/desk/api/v2")
+	if err != nil {
+		t.Fatalf("WebURL() returned error: %v", err)
+	}
+
+	if want := "https://acme.teamwork.com/desk/tickets/7"; got != want {
+		t.Errorf("WebURL() = %s, want %s", got, want)
+	}
+}
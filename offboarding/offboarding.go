@@ -0,0 +1,112 @@
+// Package offboarding moves a departing agent's work to other agents before
+// their account is deactivated.
+package offboarding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+	"github.com/teamwork/desksdkgo/routing"
+)
+
+// Options configures ReassignTickets
+type Options struct {
+	// AgentIDs are the agents taking over the tickets. A single agent
+	// receives all of them; with several, such as the members of the
+	// departing agent's team, the tickets are shared in rotation.
+	AgentIDs []int
+
+	// Weighted shares tickets by each agent's current open ticket count
+	// instead of strict rotation
+	Weighted bool
+
+	// OpenStatuses are the status IDs of tickets that still need an owner.
+	// Tickets in other statuses stay with the departing agent.
+	OpenStatuses []int64
+
+	// DryRun reports the planned reassignments without applying them
+	DryRun bool
+}
+
+// Report is the outcome of ReassignTickets
+type Report struct {
+	UserID      int
+	DryRun      bool
+	Assignments []routing.Assignment
+}
+
+// Failed returns the assignments that could not be applied
+func (r *Report) Failed() []routing.Assignment {
+	var failed []routing.Assignment
+	for _, a := range r.Assignments {
+		if a.Err != nil {
+			failed = append(failed, a)
+		}
+	}
+	return failed
+}
+
+// ReassignTickets finds the open tickets assigned to userID and reassigns
+// them to opts.AgentIDs. Run it before deactivating the user with
+// Users.Deactivate, and check Report.Failed before going ahead. Failures
+// on individual tickets are recorded in the report rather than aborting the
+// run.
+func ReassignTickets(ctx context.Context, c *client.Client, userID int, opts Options) (*Report, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("userID must be greater than 0")
+	}
+	if len(opts.AgentIDs) == 0 {
+		return nil, fmt.Errorf("opts.AgentIDs is required")
+	}
+	if slices.Contains(opts.AgentIDs, userID) {
+		return nil, fmt.Errorf("opts.AgentIDs must not include the user being offboarded")
+	}
+	if len(opts.OpenStatuses) == 0 {
+		return nil, fmt.Errorf("opts.OpenStatuses is required")
+	}
+
+	ticketIDs, err := openTickets(ctx, c, userID, opts.OpenStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := routing.RoundRobin(ctx, c, ticketIDs, opts.AgentIDs, routing.RoundRobinOptions{
+		Weighted:     opts.Weighted,
+		OpenStatuses: opts.OpenStatuses,
+		DryRun:       opts.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Report{UserID: userID, DryRun: opts.DryRun, Assignments: assignments}, nil
+}
+
+func openTickets(ctx context.Context, c *client.Client, userID int, statuses []int64) ([]int, error) {
+	filter := &models.SearchTicketsFilter{
+		Agents:     []int64{int64(userID)},
+		Statuses:   statuses,
+		OmitMerged: true,
+		Page:       1,
+	}
+
+	var ids []int
+	for {
+		resp, err := c.Tickets.Search(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+
+		for _, t := range resp.Tickets {
+			ids = append(ids, t.ID)
+		}
+
+		if !resp.Pagination.HasMorePages {
+			return ids, nil
+		}
+		filter.Page++
+	}
+}
@@ -0,0 +1,57 @@
+package offboarding
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestReassignTicketsDryRun(t *testing.T) {
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 1}},
+			{BaseEntity: models.BaseEntity{ID: 2}},
+			{BaseEntity: models.BaseEntity{ID: 3}},
+		},
+	})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	report, err := ReassignTickets(context.Background(), c, 7, Options{
+		AgentIDs:     []int{10, 20},
+		OpenStatuses: []int64{1},
+		DryRun:       true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []int{10, 20, 10}
+	if len(report.Assignments) != len(want) {
+		t.Fatalf("expected %d assignments, got %d", len(want), len(report.Assignments))
+	}
+	for i, a := range report.Assignments {
+		if a.AgentID != want[i] || a.Applied {
+			t.Errorf("ticket %d: got agent %d (applied %v), want agent %d not applied", a.TicketID, a.AgentID, a.Applied, want[i])
+		}
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected only the search request on a dry run, got %d requests", len(requests))
+	}
+	if got := requests[0].URL.Query().Get("agents"); got != "7" {
+		t.Errorf("expected search for agent 7, got %q", got)
+	}
+}
+
+func TestReassignTicketsRejectsSelf(t *testing.T) {
+	_, err := ReassignTickets(context.Background(), nil, 7, Options{AgentIDs: []int{7}, OpenStatuses: []int64{1}})
+	if err == nil {
+		t.Fatal("expected error when reassigning to the user being offboarded")
+	}
+}
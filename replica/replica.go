@@ -0,0 +1,134 @@
+// Package replica helps keep local copies of Desk data, such as a reporting
+// database or search index, in step with the API. Changed records can be
+// picked up by polling for recent updates (see client.Realtime), but a
+// replica never hears about records that disappear. Desk has no tombstone or
+// changes feed, so deletions are found in two ways: DeletedTickets and
+// DeletedCustomers list records moved to the trash since a point in time,
+// and Reconcile compares local IDs with a full remote listing to catch
+// records that were purged for good.
+package replica
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// DeletedTickets returns the IDs of tickets deleted since the given time.
+// Deleting a ticket updates it, so this lists deleted tickets updated since
+// then; tickets that were later purged from the trash are not included.
+func DeletedTickets(ctx context.Context, c *client.Client, since time.Time) ([]int, error) {
+	return deletedSince(ctx, since, func(ctx context.Context, params url.Values) ([]int, models.Pagination, error) {
+		resp, err := c.Tickets.ListDeleted(ctx, params)
+		if err != nil {
+			return nil, models.Pagination{}, err
+		}
+
+		ids := make([]int, 0, len(resp.Tickets))
+		for _, t := range resp.Tickets {
+			ids = append(ids, t.ID)
+		}
+		return ids, resp.Pagination, nil
+	})
+}
+
+// DeletedCustomers returns the IDs of customers deleted since the given time,
+// with the same caveats as DeletedTickets
+func DeletedCustomers(ctx context.Context, c *client.Client, since time.Time) ([]int, error) {
+	return deletedSince(ctx, since, func(ctx context.Context, params url.Values) ([]int, models.Pagination, error) {
+		resp, err := c.Customers.ListDeleted(ctx, params)
+		if err != nil {
+			return nil, models.Pagination{}, err
+		}
+
+		ids := make([]int, 0, len(resp.Customers))
+		for _, cu := range resp.Customers {
+			ids = append(ids, cu.ID)
+		}
+		return ids, resp.Pagination, nil
+	})
+}
+
+func deletedSince(ctx context.Context, since time.Time, list func(context.Context, url.Values) ([]int, models.Pagination, error)) ([]int, error) {
+	if since.IsZero() {
+		return nil, fmt.Errorf("since is required")
+	}
+
+	filter, err := client.NewFilter().Gte("updatedAt", since.UTC().Format(time.RFC3339)).Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("filter", filter)
+		params.Set("page", strconv.Itoa(page))
+
+		pageIDs, pagination, err := list(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("list deleted page %d: %w", page, err)
+		}
+		ids = append(ids, pageIDs...)
+
+		if !pagination.HasMorePages {
+			return ids, nil
+		}
+	}
+}
+
+// Diff is the difference between a replica and Desk
+type Diff struct {
+	// Stale holds local IDs that no longer exist in Desk and should be
+	// deleted from the replica
+	Stale []int
+	// Missing holds IDs that exist in Desk but not in the replica
+	Missing []int
+}
+
+// Reconcile lists every ID of a resource in Desk, for example with
+// c.Tickets.Service, and compares them with the IDs held locally. params
+// narrows the listing; records outside it are reported as stale, so use the
+// same scope the replica was built with. Both slices in the result are
+// sorted.
+func Reconcile[T, L any](ctx context.Context, s *client.Service[T, L], params url.Values, local []int) (*Diff, error) {
+	remote, err := s.ListIDs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("list remote IDs: %w", err)
+	}
+
+	return diff(local, remote), nil
+}
+
+func diff(local, remote []int) *Diff {
+	inRemote := make(map[int]bool, len(remote))
+	for _, id := range remote {
+		inRemote[id] = true
+	}
+	inLocal := make(map[int]bool, len(local))
+	for _, id := range local {
+		inLocal[id] = true
+	}
+
+	d := &Diff{}
+	for id := range inLocal {
+		if !inRemote[id] {
+			d.Stale = append(d.Stale, id)
+		}
+	}
+	for id := range inRemote {
+		if !inLocal[id] {
+			d.Missing = append(d.Missing, id)
+		}
+	}
+	slices.Sort(d.Stale)
+	slices.Sort(d.Missing)
+
+	return d
+}
@@ -0,0 +1,47 @@
+package replica
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+)
+
+func TestDeletedTickets(t *testing.T) {
+	mock := client.NewMockRoundTripper()
+	mock.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, `{"tickets":[{"id":4},{"id":9}],"pagination":{}}`)
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mock}))
+
+	ids, err := DeletedTickets(context.Background(), c, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("DeletedTickets() returned error: %v", err)
+	}
+	if want := []int{4, 9}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+
+	filter := mock.GetRequests()[0].URL.Query().Get("filter")
+	for _, want := range []string{`"state":{"$eq":"deleted"}`, `"updatedAt":{"$gte":"2024-05-01T00:00:00Z"}`} {
+		if !strings.Contains(filter, want) {
+			t.Errorf("expected filter %s to contain %s", filter, want)
+		}
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	mock := client.NewMockRoundTripper()
+	mock.AddResponse(http.MethodGet, "/tickets.json", http.StatusOK, `{"tickets":[{"id":1},{"id":2},{"id":5}],"pagination":{}}`)
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mock}))
+
+	d, err := Reconcile(context.Background(), c.Tickets.Service, nil, []int{3, 1, 2, 4})
+	if err != nil {
+		t.Fatalf("Reconcile() returned error: %v", err)
+	}
+	if want := (&Diff{Stale: []int{3, 4}, Missing: []int{5}}); !reflect.DeepEqual(d, want) {
+		t.Errorf("expected %+v, got %+v", want, d)
+	}
+}
@@ -0,0 +1,289 @@
+// Package retention enforces data retention policies by deleting or
+// anonymizing tickets and customers that have been inactive for longer than a
+// policy window.
+package retention
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Action is what happens to a record that falls outside the retention window
+type Action string
+
+const (
+	// ActionDelete deletes the record
+	ActionDelete Action = "delete"
+	// ActionAnonymize keeps the record but overwrites its personal data
+	ActionAnonymize Action = "anonymize"
+)
+
+const defaultBatchSize = 50
+
+// Policy describes which records are affected and what happens to them
+type Policy struct {
+	// MaxAge is the retention window. Records last updated before
+	// now-MaxAge are affected.
+	MaxAge time.Duration
+
+	// ClosedStatuses are the ticket status IDs considered closed. Tickets in
+	// other statuses are never touched. Required when TicketAction is set.
+	ClosedStatuses []int64
+
+	// TicketAction is applied to expired tickets. Empty skips tickets.
+	// Anonymizing a ticket redacts its subject and preview; message bodies
+	// are left as they are.
+	TicketAction Action
+
+	// CustomerAction is applied to expired customers. Empty skips customers.
+	// Anonymizing a customer also deletes their other email addresses and
+	// phone numbers.
+	CustomerAction Action
+
+	// BatchSize is the number of records changed between pauses. Defaults
+	// to 50.
+	BatchSize int
+
+	// BatchPause is how long to wait between batches
+	BatchPause time.Duration
+
+	// DryRun reports what would change without changing anything
+	DryRun bool
+}
+
+// Record is a single entry in the audit report
+type Record struct {
+	Resource     string    `json:"resource"`
+	ID           int       `json:"id"`
+	Action       Action    `json:"action"`
+	LastActivity time.Time `json:"lastActivity"`
+	Applied      bool      `json:"applied"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// Report is the audit trail of an Enforce run
+type Report struct {
+	Cutoff  time.Time `json:"cutoff"`
+	DryRun  bool      `json:"dryRun"`
+	Records []Record  `json:"records"`
+}
+
+// WriteCSV writes the report's records as CSV with a header row
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"resource", "id", "action", "last_activity", "applied", "error"}); err != nil {
+		return err
+	}
+
+	for _, rec := range r.Records {
+		if err := cw.Write([]string{
+			rec.Resource,
+			strconv.Itoa(rec.ID),
+			string(rec.Action),
+			rec.LastActivity.Format(time.RFC3339),
+			strconv.FormatBool(rec.Applied),
+			rec.Error,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Enforce applies policy and returns an audit report. Failures on individual
+// records are recorded in the report and do not stop the run.
+func Enforce(ctx context.Context, c *client.Client, policy Policy) (*Report, error) {
+	return enforce(ctx, c, policy, time.Now())
+}
+
+func enforce(ctx context.Context, c *client.Client, policy Policy, now time.Time) (*Report, error) {
+	if policy.MaxAge <= 0 {
+		return nil, fmt.Errorf("policy.MaxAge must be greater than 0")
+	}
+	if policy.TicketAction != "" && len(policy.ClosedStatuses) == 0 {
+		return nil, fmt.Errorf("policy.ClosedStatuses is required when TicketAction is set")
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = defaultBatchSize
+	}
+
+	report := &Report{Cutoff: now.Add(-policy.MaxAge), DryRun: policy.DryRun}
+
+	if policy.TicketAction != "" {
+		records, err := expiredTickets(ctx, c, policy, report.Cutoff)
+		if err != nil {
+			return nil, err
+		}
+		report.Records = append(report.Records, records...)
+	}
+
+	if policy.CustomerAction != "" {
+		records, err := expiredCustomers(ctx, c, policy, report.Cutoff)
+		if err != nil {
+			return nil, err
+		}
+		report.Records = append(report.Records, records...)
+	}
+
+	if policy.DryRun {
+		return report, nil
+	}
+
+	for i := range report.Records {
+		if i > 0 && i%policy.BatchSize == 0 && policy.BatchPause > 0 {
+			select {
+			case <-time.After(policy.BatchPause):
+			case <-ctx.Done():
+				return report, ctx.Err()
+			}
+		}
+
+		rec := &report.Records[i]
+		if err := apply(ctx, c, rec); err != nil {
+			rec.Error = err.Error()
+			continue
+		}
+		rec.Applied = true
+	}
+
+	return report, nil
+}
+
+func expiredTickets(ctx context.Context, c *client.Client, policy Policy, cutoff time.Time) ([]Record, error) {
+	filter := &models.SearchTicketsFilter{Statuses: policy.ClosedStatuses, Page: 1}
+
+	var records []Record
+	for {
+		resp, err := c.Tickets.Search(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("search tickets: %w", err)
+		}
+
+		for _, t := range resp.Tickets {
+			if last, ok := lastActivity(t.BaseEntity); ok && last.Before(cutoff) {
+				records = append(records, Record{Resource: "ticket", ID: t.ID, Action: policy.TicketAction, LastActivity: last})
+			}
+		}
+
+		if !resp.Pagination.HasMorePages {
+			return records, nil
+		}
+		filter.Page++
+	}
+}
+
+func expiredCustomers(ctx context.Context, c *client.Client, policy Policy, cutoff time.Time) ([]Record, error) {
+	params := (&client.ListOptions{PerPage: policy.BatchSize}).Values()
+
+	var records []Record
+	err := c.Customers.ListAllFunc(ctx, params, func(page *models.CustomersResponse) error {
+		for _, cu := range page.Customers {
+			if last, ok := lastActivity(cu.BaseEntity); ok && last.Before(cutoff) {
+				records = append(records, Record{Resource: "customer", ID: cu.ID, Action: policy.CustomerAction, LastActivity: last})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list customers: %w", err)
+	}
+
+	return records, nil
+}
+
+func lastActivity(e models.BaseEntity) (time.Time, bool) {
+	switch {
+	case e.UpdatedAt != nil:
+		return *e.UpdatedAt, true
+	case e.CreatedAt != nil:
+		return *e.CreatedAt, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func apply(ctx context.Context, c *client.Client, rec *Record) error {
+	switch {
+	case rec.Resource == "ticket" && rec.Action == ActionDelete:
+		return c.Tickets.Delete(ctx, rec.ID)
+	case rec.Resource == "ticket" && rec.Action == ActionAnonymize:
+		_, err := c.Tickets.Update(ctx, rec.ID, &models.TicketResponse{Ticket: anonymizedTicket()})
+		return err
+	case rec.Resource == "customer" && rec.Action == ActionDelete:
+		return c.Customers.Delete(ctx, rec.ID)
+	case rec.Resource == "customer" && rec.Action == ActionAnonymize:
+		customer := anonymizedCustomer(rec.ID)
+		if _, err := c.Customers.Update(ctx, rec.ID, &models.CustomerResponse{Customer: customer}); err != nil {
+			return err
+		}
+		return deleteContacts(ctx, c, rec.ID, *customer.Email)
+	default:
+		return fmt.Errorf("unsupported action %q for %s", rec.Action, rec.Resource)
+	}
+}
+
+// deleteContacts deletes every contact of the customer except the one for
+// keep, the anonymized email address, since the customer update leaves the
+// other contacts as they are
+func deleteContacts(ctx context.Context, c *client.Client, customerID int, keep string) error {
+	var ids []int
+	for page := 1; ; page++ {
+		resp, err := c.Contacts.ListForCustomer(ctx, customerID, (&client.ListOptions{Page: page}).Values())
+		if err != nil {
+			return fmt.Errorf("list contacts: %w", err)
+		}
+		for _, contact := range resp.Items {
+			if contact.Value == nil || *contact.Value != keep {
+				ids = append(ids, contact.ID)
+			}
+		}
+		if !resp.Pagination.HasMorePages {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		if err := c.Contacts.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete contact %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func anonymizedTicket() models.Ticket {
+	return models.Ticket{
+		Subject:           ptr("[redacted]"),
+		PreviewText:       ptr(""),
+		OriginalRecipient: ptr(""),
+	}
+}
+
+func anonymizedCustomer(id int) models.Customer {
+	return models.Customer{
+		FirstName:     ptr("Anonymized"),
+		LastName:      ptr("Customer"),
+		Email:         ptr(fmt.Sprintf("anonymized-%d@example.invalid", id)),
+		Organization:  ptr(""),
+		ExtraData:     ptr(""),
+		Notes:         ptr(""),
+		LinkedinURL:   ptr(""),
+		FacebookURL:   ptr(""),
+		TwitterHandle: ptr(""),
+		Phone:         ptr(""),
+		Mobile:        ptr(""),
+		Address:       ptr(""),
+		AvatarURL:     ptr(""),
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
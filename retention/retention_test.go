@@ -0,0 +1,120 @@
+package retention
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestEnforce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-3, 0, 0)
+	recent := now.AddDate(0, -1, 0)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/search/tickets.json", http.StatusOK, models.TicketsResponse{
+		Tickets: []models.Ticket{
+			{BaseEntity: models.BaseEntity{ID: 1, UpdatedAt: &old}},
+			{BaseEntity: models.BaseEntity{ID: 2, UpdatedAt: &recent}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, models.CustomersResponse{
+		Customers: []models.Customer{
+			{BaseEntity: models.BaseEntity{ID: 7, CreatedAt: &old}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodDelete, "/tickets/1.json", http.StatusNoContent, "")
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	report, err := enforce(context.Background(), c, Policy{
+		MaxAge:         2 * 365 * 24 * time.Hour,
+		ClosedStatuses: []int64{5},
+		TicketAction:   ActionDelete,
+		CustomerAction: ActionAnonymize,
+	}, now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(report.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(report.Records))
+	}
+
+	if rec := report.Records[0]; rec.Resource != "ticket" || rec.ID != 1 || !rec.Applied {
+		t.Errorf("expected ticket 1 to be deleted, got %+v", rec)
+	}
+
+	// No response is registered for the customer update, so it fails and is
+	// recorded rather than aborting the run.
+	if rec := report.Records[1]; rec.Resource != "customer" || rec.Applied || rec.Error == "" {
+		t.Errorf("expected customer 7 to record a failure, got %+v", rec)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("expected no error writing CSV, got %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 3 {
+		t.Errorf("expected header and 2 rows, got %d lines", len(lines))
+	}
+}
+
+func TestEnforceAnonymizeDeletesContacts(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-3, 0, 0)
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/customers.json", http.StatusOK, models.CustomersResponse{
+		Customers: []models.Customer{
+			{BaseEntity: models.BaseEntity{ID: 7, CreatedAt: &old}},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPut, "/customers/7.json", http.StatusOK, models.CustomerResponse{
+		Customer: models.Customer{BaseEntity: models.BaseEntity{ID: 7}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/customers/7/contacts.json", http.StatusOK, models.ContactsResponse{
+		Items: []models.Contact{
+			{BaseEntity: models.BaseEntity{ID: 20}, Value: ptr("anonymized-7@example.invalid")},
+			{BaseEntity: models.BaseEntity{ID: 21}, Value: ptr("+353 1 555 0100")},
+		},
+	})
+	mockTransport.AddResponse(http.MethodDelete, "/contacts/21.json", http.StatusNoContent, "")
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	report, err := enforce(context.Background(), c, Policy{
+		MaxAge:         2 * 365 * 24 * time.Hour,
+		CustomerAction: ActionAnonymize,
+	}, now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(report.Records) != 1 || !report.Records[0].Applied {
+		t.Fatalf("expected customer 7 to be anonymized, got %+v", report.Records)
+	}
+
+	var deleted []string
+	for _, req := range mockTransport.GetRequests() {
+		if req.Method == http.MethodDelete {
+			deleted = append(deleted, req.URL.Path)
+		}
+	}
+	if len(deleted) != 1 || deleted[0] != "/contacts/21.json" {
+		t.Errorf("expected only the phone contact to be deleted, got %v", deleted)
+	}
+}
+
+func TestEnforceRequiresClosedStatuses(t *testing.T) {
+	_, err := Enforce(context.Background(), nil, Policy{MaxAge: time.Hour, TicketAction: ActionDelete})
+	if err == nil {
+		t.Fatal("expected error when closed statuses are missing")
+	}
+}
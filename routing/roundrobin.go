@@ -0,0 +1,139 @@
+// Package routing distributes tickets across agents for teams that do not use
+// Desk's built-in routing.
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// RoundRobinOptions configures RoundRobin
+type RoundRobinOptions struct {
+	// Weighted balances by each agent's current open ticket count instead of
+	// strict rotation, so agents with lighter queues receive more tickets
+	Weighted bool
+
+	// OpenStatuses are the status IDs counted as open when Weighted is set.
+	// When empty, tickets in any status count towards an agent's load.
+	OpenStatuses []int64
+
+	// DryRun plans the assignments without applying them
+	DryRun bool
+}
+
+// Assignment is the planned or applied assignment of a single ticket
+type Assignment struct {
+	TicketID int
+	AgentID  int
+	Applied  bool
+	Err      error
+}
+
+// RoundRobin distributes ticketIDs across agentIDs and assigns them, with one
+// bulk update per agent. Failures on individual tickets are recorded on the
+// returned assignments rather than aborting the run.
+func RoundRobin(ctx context.Context, c *client.Client, ticketIDs, agentIDs []int, opts RoundRobinOptions) ([]Assignment, error) {
+	if len(agentIDs) == 0 {
+		return nil, fmt.Errorf("at least one agent is required")
+	}
+
+	var loads map[int]int
+	if opts.Weighted {
+		var err error
+		loads, err = openCounts(ctx, c, agentIDs, opts.OpenStatuses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assignments := plan(ticketIDs, agentIDs, loads)
+	if opts.DryRun {
+		return assignments, nil
+	}
+
+	var agents []int
+	byAgent := map[int][]int{}
+	for i, a := range assignments {
+		if _, ok := byAgent[a.AgentID]; !ok {
+			agents = append(agents, a.AgentID)
+		}
+		byAgent[a.AgentID] = append(byAgent[a.AgentID], i)
+	}
+
+	for _, agentID := range agents {
+		assign(ctx, c, assignments, agentID, byAgent[agentID])
+	}
+
+	return assignments, nil
+}
+
+// plan assigns tickets in order. Without loads it rotates through agents;
+// with loads each ticket goes to the least loaded agent, ties broken by the
+// order of agentIDs.
+func plan(ticketIDs, agentIDs []int, loads map[int]int) []Assignment {
+	assignments := make([]Assignment, 0, len(ticketIDs))
+	for i, ticketID := range ticketIDs {
+		agentID := agentIDs[i%len(agentIDs)]
+		if loads != nil {
+			agentID = agentIDs[0]
+			for _, id := range agentIDs[1:] {
+				if loads[id] < loads[agentID] {
+					agentID = id
+				}
+			}
+			loads[agentID]++
+		}
+
+		assignments = append(assignments, Assignment{TicketID: ticketID, AgentID: agentID})
+	}
+
+	return assignments
+}
+
+func openCounts(ctx context.Context, c *client.Client, agentIDs []int, statuses []int64) (map[int]int, error) {
+	loads := make(map[int]int, len(agentIDs))
+	for _, agentID := range agentIDs {
+		count, err := c.Tickets.Count(ctx, &models.SearchTicketsFilter{
+			Agents:   []int64{int64(agentID)},
+			Statuses: statuses,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("count open tickets for agent %d: %w", agentID, err)
+		}
+		loads[agentID] = count
+	}
+
+	return loads, nil
+}
+
+// assign gives agentID the tickets of assignments[idx] with one bulk update,
+// which only sends the agent so the rest of each ticket is left as it is
+func assign(ctx context.Context, c *client.Client, assignments []Assignment, agentID int, idx []int) {
+	ids := make([]int, len(idx))
+	for i, j := range idx {
+		ids[i] = assignments[j].TicketID
+	}
+
+	// An error stops the remaining chunks, so tickets returned from earlier
+	// chunks are still applied
+	updated, err := c.Tickets.BulkUpdate(ctx, ids, models.TicketBulkChanges{
+		Agent: &models.EntityRef{ID: agentID, Type: "users"},
+	})
+	done := make(map[int]bool, len(updated))
+	for _, t := range updated {
+		done[t.ID] = true
+	}
+	for _, j := range idx {
+		switch {
+		case done[assignments[j].TicketID]:
+			assignments[j].Applied = true
+		case err != nil:
+			assignments[j].Err = err
+		default:
+			assignments[j].Err = fmt.Errorf("ticket %d was not returned by the bulk update", assignments[j].TicketID)
+		}
+	}
+}
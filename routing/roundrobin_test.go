@@ -0,0 +1,100 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestPlanRotates(t *testing.T) {
+	assignments := plan([]int{1, 2, 3, 4, 5}, []int{10, 20}, nil)
+
+	want := []int{10, 20, 10, 20, 10}
+	for i, a := range assignments {
+		if a.AgentID != want[i] {
+			t.Errorf("ticket %d: got agent %d, want %d", a.TicketID, a.AgentID, want[i])
+		}
+	}
+}
+
+func TestPlanWeighted(t *testing.T) {
+	loads := map[int]int{10: 3, 20: 0, 30: 1}
+	assignments := plan([]int{1, 2, 3, 4}, []int{10, 20, 30}, loads)
+
+	want := []int{20, 20, 30, 20}
+	for i, a := range assignments {
+		if a.AgentID != want[i] {
+			t.Errorf("ticket %d: got agent %d, want %d", a.TicketID, a.AgentID, want[i])
+		}
+	}
+}
+
+func TestRoundRobinRequiresAgents(t *testing.T) {
+	if _, err := RoundRobin(context.Background(), nil, []int{1}, nil, RoundRobinOptions{}); err == nil {
+		t.Fatal("expected error when no agents are given")
+	}
+}
+
+func TestRoundRobinBulkUpdates(t *testing.T) {
+	type bulkBody struct {
+		IDs    []int                      `json:"ids"`
+		Ticket map[string]json.RawMessage `json:"ticket"`
+	}
+
+	var bodies []bulkBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/tickets/bulk.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+
+		var body bulkBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		bodies = append(bodies, body)
+
+		var resp models.TicketsResponse
+		for _, id := range body.IDs {
+			resp.Tickets = append(resp.Tickets, models.Ticket{BaseEntity: models.BaseEntity{ID: id}})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c := client.NewClient(srv.URL)
+
+	assignments, err := RoundRobin(context.Background(), c, []int{1, 2, 3, 4, 5}, []int{10, 20}, RoundRobinOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, a := range assignments {
+		if !a.Applied || a.Err != nil {
+			t.Errorf("expected ticket %d to be assigned, got %+v", a.TicketID, a)
+		}
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected one bulk update per agent, got %d", len(bodies))
+	}
+	for i, want := range []struct {
+		agent int
+		ids   []int
+	}{{10, []int{1, 3, 5}}, {20, []int{2, 4}}} {
+		var agent models.EntityRef
+		_ = json.Unmarshal(bodies[i].Ticket["agent"], &agent)
+		if agent.ID != want.agent || !reflect.DeepEqual(bodies[i].IDs, want.ids) {
+			t.Errorf("update %d: expected tickets %v for agent %d, got %v for agent %d", i, want.ids, want.agent, bodies[i].IDs, agent.ID)
+		}
+		if len(bodies[i].Ticket) != 1 {
+			t.Errorf("update %d: expected only the agent to be sent, got %v", i, bodies[i].Ticket)
+		}
+	}
+}
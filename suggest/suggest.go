@@ -0,0 +1,154 @@
+// Package suggest connects reply-drafting assistants, such as LLM
+// integrations, to Desk tickets. Implementations only need to turn a
+// transcript into a draft; fetching the conversation and saving the draft is
+// handled here.
+package suggest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Entry is a single message or note in a ticket transcript
+type Entry struct {
+	MessageID  int
+	ThreadType models.ThreadType
+	// AuthorType is the type of the author reference as reported by the API,
+	// for example "users" for agents or "customers"
+	AuthorType string
+	AuthorID   int
+	Body       string
+	CreatedAt  time.Time
+}
+
+// Transcript is the conversation on a ticket, oldest entry first
+type Transcript struct {
+	Ticket  models.Ticket
+	Entries []Entry
+}
+
+// Suggestion is a drafted reply
+type Suggestion struct {
+	// Reply is the drafted reply body as HTML
+	Reply string
+	// Confidence is how sure the suggester is about the draft, between 0 and 1
+	Confidence float64
+}
+
+// Suggester drafts a reply from a ticket transcript
+type Suggester interface {
+	Suggest(ctx context.Context, transcript *Transcript) (*Suggestion, error)
+}
+
+// SuggesterFunc adapts a function to the Suggester interface
+type SuggesterFunc func(ctx context.Context, transcript *Transcript) (*Suggestion, error)
+
+// Suggest implements Suggester
+func (f SuggesterFunc) Suggest(ctx context.Context, transcript *Transcript) (*Suggestion, error) {
+	return f(ctx, transcript)
+}
+
+// Options configures DraftReply
+type Options struct {
+	// MinConfidence discards suggestions below this confidence. Discarded
+	// suggestions are still returned but not saved to the ticket.
+	MinConfidence float64
+
+	// IncludeNotes passes internal notes to the suggester as well as
+	// customer-visible messages
+	IncludeNotes bool
+}
+
+// Draft is the outcome of DraftReply
+type Draft struct {
+	Suggestion Suggestion
+	// Note is the note the draft was saved as, nil when it was discarded
+	Note *models.Message
+}
+
+// FetchTranscript loads a ticket and all of its messages. Notes are only
+// included when includeNotes is set.
+func FetchTranscript(ctx context.Context, c *client.Client, ticketID int, includeNotes bool) (*Transcript, error) {
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+
+	ticket, err := c.Tickets.Get(ctx, ticketID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	messages, err := c.Messages.Thread(ctx, ticketID, includeNotes)
+	if err != nil {
+		return nil, fmt.Errorf("list messages: %w", err)
+	}
+
+	transcript := &Transcript{Ticket: ticket.Ticket}
+	for _, m := range messages {
+		entry := Entry{MessageID: m.ID, ThreadType: models.ThreadTypeMessage}
+		if m.ThreadType != nil {
+			entry.ThreadType = *m.ThreadType
+		}
+		if m.CreatedBy != nil {
+			entry.AuthorType = m.CreatedBy.Type
+			entry.AuthorID = m.CreatedBy.ID
+		}
+		if m.Message != nil {
+			entry.Body = *m.Message
+		}
+		if m.CreatedAt != nil {
+			entry.CreatedAt = *m.CreatedAt
+		}
+		transcript.Entries = append(transcript.Entries, entry)
+	}
+
+	sort.SliceStable(transcript.Entries, func(i, j int) bool {
+		return transcript.Entries[i].CreatedAt.Before(transcript.Entries[j].CreatedAt)
+	})
+
+	return transcript, nil
+}
+
+// DraftReply fetches the ticket transcript, asks s for a reply and saves the
+// suggestion on the ticket as a note for an agent to review. Nothing is ever
+// sent to the customer.
+func DraftReply(ctx context.Context, c *client.Client, s Suggester, ticketID int, opts Options) (*Draft, error) {
+	if s == nil {
+		return nil, fmt.Errorf("suggester is required")
+	}
+
+	transcript, err := FetchTranscript(ctx, c, ticketID, opts.IncludeNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion, err := s.Suggest(ctx, transcript)
+	if err != nil {
+		return nil, fmt.Errorf("suggest reply: %w", err)
+	}
+	if suggestion == nil {
+		return nil, fmt.Errorf("suggester returned no suggestion")
+	}
+
+	draft := &Draft{Suggestion: *suggestion}
+	if suggestion.Reply == "" || suggestion.Confidence < opts.MinConfidence {
+		return draft, nil
+	}
+
+	body := fmt.Sprintf("<p><strong>Suggested reply</strong> (confidence %.0f%%)</p>%s", suggestion.Confidence*100, suggestion.Reply)
+	note := models.ThreadTypeNote
+	resp, err := c.Messages.CreateForTicket(ctx, ticketID, &models.MessageResponse{
+		Message: models.Message{Message: &body, ThreadType: &note},
+	})
+	if err != nil {
+		return draft, fmt.Errorf("save draft: %w", err)
+	}
+
+	draft.Note = &resp.Message
+	return draft, nil
+}
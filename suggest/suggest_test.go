@@ -0,0 +1,78 @@
+package suggest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func newTestClient(t *testing.T) (*client.Client, *client.MockRoundTripper) {
+	t.Helper()
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/7.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 7}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/tickets/7/messages.json", http.StatusOK, map[string]any{
+		"messages": []map[string]any{
+			{"id": 2, "threadType": "note", "htmlBody": "<p>VIP customer</p>", "createdAt": "2024-06-10T10:00:00Z"},
+			{"id": 1, "threadType": "message", "htmlBody": "<p>Where is my order?</p>", "createdAt": "2024-06-10T09:00:00Z"},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPost, "/tickets/7/messages.json", http.StatusCreated, models.MessageResponse{
+		Message: models.Message{BaseEntity: models.BaseEntity{ID: 3}},
+	})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	return c, mockTransport
+}
+
+func TestDraftReply(t *testing.T) {
+	c, mockTransport := newTestClient(t)
+
+	var got *Transcript
+	s := SuggesterFunc(func(_ context.Context, transcript *Transcript) (*Suggestion, error) {
+		got = transcript
+		return &Suggestion{Reply: "<p>It ships tomorrow.</p>", Confidence: 0.9}, nil
+	})
+
+	draft, err := DraftReply(context.Background(), c, s, 7, Options{MinConfidence: 0.5})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got.Entries) != 1 || got.Entries[0].MessageID != 1 {
+		t.Errorf("expected only the customer message in the transcript, got %+v", got.Entries)
+	}
+	if draft.Note == nil || draft.Note.ID != 3 {
+		t.Errorf("expected draft to be saved as note 3, got %+v", draft.Note)
+	}
+	if n := len(mockTransport.GetRequests()); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestDraftReplyBelowConfidence(t *testing.T) {
+	c, mockTransport := newTestClient(t)
+
+	s := SuggesterFunc(func(_ context.Context, _ *Transcript) (*Suggestion, error) {
+		return &Suggestion{Reply: "<p>Maybe?</p>", Confidence: 0.2}, nil
+	})
+
+	draft, err := DraftReply(context.Background(), c, s, 7, Options{MinConfidence: 0.5, IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if draft.Note != nil {
+		t.Errorf("expected low-confidence draft not to be saved")
+	}
+	for _, req := range mockTransport.GetRequests() {
+		if req.Method == http.MethodPost {
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	}
+}
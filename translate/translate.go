@@ -0,0 +1,164 @@
+// Package translate adds vendor-neutral translation to ticket conversations.
+// Callers plug in their own Translator (a cloud translation API, a local
+// model, ...) and the helpers take care of calling it on message bodies and
+// keeping both the original and translated text on the ticket as notes.
+package translate
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Translation is the result of translating a piece of text
+type Translation struct {
+	Text string
+	// SourceLang is the detected language of the input, as reported by the
+	// translator. It may be empty if the translator does not detect languages.
+	SourceLang string
+	TargetLang string
+}
+
+// Translator translates text into targetLang, detecting the source language.
+// Language codes are whatever the implementation understands; BCP 47 tags
+// such as "en" or "pt-BR" are recommended.
+type Translator interface {
+	Translate(ctx context.Context, text, targetLang string) (*Translation, error)
+}
+
+// TranslatorFunc adapts a function to the Translator interface
+type TranslatorFunc func(ctx context.Context, text, targetLang string) (*Translation, error)
+
+// Translate implements Translator
+func (f TranslatorFunc) Translate(ctx context.Context, text, targetLang string) (*Translation, error) {
+	return f(ctx, text, targetLang)
+}
+
+// TranslateTicket translates every message on a ticket into targetLang and
+// posts each translation as a note holding both the original and the
+// translated text. Notes and messages already written in targetLang are
+// skipped. The created notes are returned.
+func TranslateTicket(ctx context.Context, c *client.Client, tr Translator, ticketID int, targetLang string) ([]models.Message, error) {
+	if tr == nil {
+		return nil, fmt.Errorf("translator is required")
+	}
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+	if targetLang == "" {
+		return nil, fmt.Errorf("targetLang is required")
+	}
+
+	messages, err := c.Messages.Thread(ctx, ticketID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list messages: %w", err)
+	}
+
+	var notes []models.Message
+	for _, m := range messages {
+		if m.Message == nil || strings.TrimSpace(*m.Message) == "" {
+			continue
+		}
+
+		t, err := tr.Translate(ctx, *m.Message, targetLang)
+		if err != nil {
+			return notes, fmt.Errorf("translate message %d: %w", m.ID, err)
+		}
+		if sameLang(t.SourceLang, targetLang) {
+			continue
+		}
+
+		note, err := addNote(ctx, c, ticketID, noteBody(fmt.Sprintf("Translation of message #%d", m.ID), *m.Message, t))
+		if err != nil {
+			return notes, err
+		}
+		notes = append(notes, *note)
+	}
+
+	return notes, nil
+}
+
+// Reply translates an agent's reply into the customer's language and sends it
+// on the ticket. The untranslated reply is kept alongside the translation in
+// a note so other agents can read what was sent. When the translator reports
+// that body is already in customerLang no note is added.
+func Reply(ctx context.Context, c *client.Client, tr Translator, ticketID int, body, customerLang string) (*models.MessageResponse, error) {
+	if tr == nil {
+		return nil, fmt.Errorf("translator is required")
+	}
+	if ticketID <= 0 {
+		return nil, fmt.Errorf("ticketID must be greater than 0")
+	}
+	if customerLang == "" {
+		return nil, fmt.Errorf("customerLang is required")
+	}
+
+	t, err := tr.Translate(ctx, body, customerLang)
+	if err != nil {
+		return nil, fmt.Errorf("translate reply: %w", err)
+	}
+
+	if sameLang(t.SourceLang, customerLang) {
+		return c.Messages.CreateForTicket(ctx, ticketID, &models.MessageResponse{
+			Message: models.Message{Message: &body, ThreadType: ptr(models.ThreadTypeMessage)},
+		})
+	}
+
+	reply, err := c.Messages.CreateForTicket(ctx, ticketID, &models.MessageResponse{
+		Message: models.Message{Message: &t.Text, ThreadType: ptr(models.ThreadTypeMessage)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := addNote(ctx, c, ticketID, noteBody("Reply sent in translation", body, t)); err != nil {
+		return reply, err
+	}
+
+	return reply, nil
+}
+
+func addNote(ctx context.Context, c *client.Client, ticketID int, body string) (*models.Message, error) {
+	resp, err := c.Messages.CreateForTicket(ctx, ticketID, &models.MessageResponse{
+		Message: models.Message{Message: &body, ThreadType: ptr(models.ThreadTypeNote)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add note: %w", err)
+	}
+	return &resp.Message, nil
+}
+
+// noteBody formats the original and translated text as an HTML note. The
+// original is already HTML, the translation is whatever the translator
+// returned and is therefore not escaped either.
+func noteBody(heading, original string, t *Translation) string {
+	from := t.SourceLang
+	if from == "" {
+		from = "unknown"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p><strong>%s (%s → %s)</strong></p>", html.EscapeString(heading), html.EscapeString(from), html.EscapeString(t.TargetLang))
+	fmt.Fprintf(&b, "<p><strong>Original</strong></p>%s", original)
+	fmt.Fprintf(&b, "<p><strong>Translation</strong></p>%s", t.Text)
+	return b.String()
+}
+
+// sameLang compares the primary subtags of two language codes, so "en" and
+// "en-GB" are considered the same language
+func sameLang(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	primary := func(s string) string {
+		s, _, _ = strings.Cut(strings.ReplaceAll(s, "_", "-"), "-")
+		return strings.ToLower(s)
+	}
+	return primary(a) == primary(b)
+}
+
+func ptr[T any](v T) *T { return &v }
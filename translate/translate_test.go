@@ -0,0 +1,86 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestTranslateTicket(t *testing.T) {
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/5/messages.json", http.StatusOK, map[string]any{
+		"messages": []map[string]any{
+			{"id": 1, "threadType": "message", "htmlBody": "<p>Hola, mi pedido no ha llegado</p>"},
+			{"id": 2, "threadType": "note", "htmlBody": "<p>internal</p>"},
+		},
+	})
+	mockTransport.AddResponse(http.MethodPost, "/tickets/5/messages.json", http.StatusCreated, models.MessageResponse{})
+
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	var calls int
+	tr := TranslatorFunc(func(_ context.Context, text, targetLang string) (*Translation, error) {
+		calls++
+		return &Translation{Text: "<p>Hello, my order has not arrived</p>", SourceLang: "es", TargetLang: targetLang}, nil
+	})
+
+	notes, err := TranslateTicket(context.Background(), c, tr, 5, "en")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected notes to be skipped, translator called %d times", calls)
+	}
+	if len(notes) != 1 {
+		t.Fatalf("expected 1 note, got %d", len(notes))
+	}
+
+	requests := mockTransport.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+
+	b, err := io.ReadAll(requests[1].Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Message    string `json:"message"`
+		ThreadType string `json:"threadType"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ThreadType != string(models.ThreadTypeNote) {
+		t.Errorf("expected a note, got thread type %q", body.ThreadType)
+	}
+	if !strings.Contains(body.Message, "mi pedido") || !strings.Contains(body.Message, "my order") {
+		t.Errorf("expected note to hold original and translation, got %q", body.Message)
+	}
+}
+
+func TestSameLang(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"en", "en", true},
+		{"en-GB", "EN", true},
+		{"pt_BR", "pt", true},
+		{"es", "en", false},
+		{"", "en", false},
+	}
+
+	for _, tt := range tests {
+		if got := sameLang(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameLang(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,10 @@ import (
 )
 
 // MergeJSONData merges JSON data into a struct
+//
+// Deprecated: use models.ApplyPatch, which documents how nested structs,
+// slices and references are merged, matches keys case-insensitively and
+// returns an error instead of exiting.
 func MergeJSONData(target interface{}, data map[string]interface{}) {
 	// Convert target to JSON
 	jsonData, err := json.Marshal(target)
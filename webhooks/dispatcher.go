@@ -0,0 +1,262 @@
+// Package webhooks processes events delivered by Desk webhooks.
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWorkers     = 4
+	defaultQueueSize   = 100
+	defaultMaxAttempts = 3
+	defaultRetryDelay  = time.Second
+)
+
+// Event is a single webhook delivery
+type Event struct {
+	// ID uniquely identifies the delivery
+	ID string `json:"id"`
+	// Type is the event name, for example "ticket.created"
+	Type string `json:"type"`
+	// Payload is the event data as sent by Desk
+	Payload json.RawMessage `json:"payload"`
+	// ReceivedAt is when the event was received
+	ReceivedAt time.Time `json:"receivedAt"`
+	// Data is the typed payload set by ParseEvent, nil for event types
+	// without one. See DecodePayload.
+	Data any `json:"-"`
+}
+
+// Handler processes a webhook event
+type Handler interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+// HandlerFunc adapts a function to the Handler interface
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Handle implements Handler
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Dispatcher queues events and processes them in the background, so the
+// webhook endpoint can respond to Desk straight away instead of timing out
+// on slow handlers. Events of the same type are always handled by the same
+// worker, one at a time, in the order they were enqueued; events of
+// different types are handled concurrently.
+type Dispatcher struct {
+	handler     Handler
+	workers     int
+	queueSize   int
+	maxAttempts int
+	retryDelay  time.Duration
+	onRetry     func(ctx context.Context, event Event, attempt int, err error)
+	onDead      func(ctx context.Context, event Event, err error)
+
+	mu      sync.RWMutex
+	queues  []chan Event
+	started bool
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// DispatcherOption configures a Dispatcher
+type DispatcherOption func(*Dispatcher)
+
+// WithWorkers sets the number of workers. Defaults to 4.
+func WithWorkers(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = n
+	}
+}
+
+// WithQueueSize sets how many events each worker can have waiting. Defaults
+// to 100.
+func WithQueueSize(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queueSize = n
+	}
+}
+
+// WithRetry sets how many times an event is attempted before it is dead
+// lettered, and the delay between attempts. Defaults to 3 attempts, one
+// second apart.
+func WithRetry(maxAttempts int, delay time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.retryDelay = delay
+	}
+}
+
+// WithRetryHook registers a function called after each failed attempt that
+// will be retried
+func WithRetryHook(fn func(ctx context.Context, event Event, attempt int, err error)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.onRetry = fn
+	}
+}
+
+// WithDeadLetter registers a function called with events that failed on
+// every attempt, for example to store them for manual replay. Without it
+// such events are dropped.
+func WithDeadLetter(fn func(ctx context.Context, event Event, err error)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.onDead = fn
+	}
+}
+
+// NewDispatcher creates a dispatcher for handler. Call Start before
+// enqueueing events.
+func NewDispatcher(handler Handler, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		handler:     handler,
+		workers:     defaultWorkers,
+		queueSize:   defaultQueueSize,
+		maxAttempts: defaultMaxAttempts,
+		retryDelay:  defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.workers = max(d.workers, 1)
+	d.queueSize = max(d.queueSize, 1)
+	d.maxAttempts = max(d.maxAttempts, 1)
+
+	return d
+}
+
+// Start launches the workers. Cancelling ctx stops them after the event in
+// progress; use Shutdown to finish the queued events first.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started {
+		return fmt.Errorf("dispatcher already started")
+	}
+	d.started = true
+
+	d.queues = make([]chan Event, d.workers)
+	for i := range d.queues {
+		d.queues[i] = make(chan Event, d.queueSize)
+		d.wg.Add(1)
+		go d.work(ctx, d.queues[i])
+	}
+
+	return nil
+}
+
+// Enqueue queues event for processing. It never blocks: when the event's
+// worker is full it returns an error, and the webhook endpoint should
+// respond with an error status so Desk redelivers the event later.
+func (d *Dispatcher) Enqueue(event Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if !d.started {
+		return fmt.Errorf("dispatcher not started")
+	}
+	if d.closed {
+		return fmt.Errorf("dispatcher is shut down")
+	}
+
+	if event.ReceivedAt.IsZero() {
+		event.ReceivedAt = time.Now()
+	}
+
+	select {
+	case d.queues[d.worker(event.Type)] <- event:
+		return nil
+	default:
+		return fmt.Errorf("queue full for event type %q", event.Type)
+	}
+}
+
+// Shutdown stops accepting events and waits for the queued ones to be
+// processed, or for ctx to be done
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed && d.started {
+		for _, q := range d.queues {
+			close(q)
+		}
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// worker picks the queue for an event type, so all events of one type are
+// handled in order by the same worker
+func (d *Dispatcher) worker(eventType string) int {
+	h := fnv.New32a()
+	h.Write([]byte(eventType))
+	return int(h.Sum32() % uint32(len(d.queues)))
+}
+
+func (d *Dispatcher) work(ctx context.Context, queue <-chan Event) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case event, ok := <-queue:
+			if !ok {
+				return
+			}
+			d.process(ctx, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) process(ctx context.Context, event Event) {
+	for attempt := 1; ; attempt++ {
+		err := d.handler.Handle(ctx, event)
+		if err == nil {
+			return
+		}
+
+		if attempt >= d.maxAttempts {
+			d.deadLetter(ctx, event, err)
+			return
+		}
+
+		if d.onRetry != nil {
+			d.onRetry(ctx, event, attempt, err)
+		}
+
+		select {
+		case <-time.After(d.retryDelay):
+		case <-ctx.Done():
+			d.deadLetter(ctx, event, err)
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, event Event, err error) {
+	if d.onDead != nil {
+		d.onDead(ctx, event, err)
+	}
+}
@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatcherOrdersEventsPerType(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen = map[string][]string{}
+	)
+	handler := HandlerFunc(func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[event.Type] = append(seen[event.Type], event.ID)
+		return nil
+	})
+
+	d := NewDispatcher(handler, WithWorkers(3))
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned error: %v", err)
+	}
+
+	types := []string{"ticket.created", "ticket.updated", "customer.created"}
+	for i := range 30 {
+		event := Event{ID: strconv.Itoa(i), Type: types[i%len(types)]}
+		if err := d.Enqueue(event); err != nil {
+			t.Fatalf("Enqueue() returned error: %v", err)
+		}
+	}
+
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() returned error: %v", err)
+	}
+
+	for i, typ := range types {
+		ids := seen[typ]
+		if len(ids) != 10 {
+			t.Fatalf("%s: expected 10 events, got %d", typ, len(ids))
+		}
+		for j, id := range ids {
+			if want := strconv.Itoa(i + j*len(types)); id != want {
+				t.Errorf("%s: event %d out of order, expected %s, got %s", typ, j, want, id)
+			}
+		}
+	}
+
+	if err := d.Enqueue(Event{ID: "late"}); err == nil {
+		t.Error("expected error when enqueueing after shutdown")
+	}
+}
+
+func TestDispatcherRetriesAndDeadLetters(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		retries  []int
+		dead     []string
+	)
+	handler := HandlerFunc(func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return errors.New("boom")
+	})
+
+	d := NewDispatcher(handler,
+		WithRetry(3, time.Millisecond),
+		WithRetryHook(func(ctx context.Context, event Event, attempt int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			retries = append(retries, attempt)
+		}),
+		WithDeadLetter(func(ctx context.Context, event Event, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			dead = append(dead, event.ID)
+		}),
+	)
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned error: %v", err)
+	}
+
+	if err := d.Enqueue(Event{ID: "1", Type: "ticket.created"}); err != nil {
+		t.Fatalf("Enqueue() returned error: %v", err)
+	}
+
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() returned error: %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if len(retries) != 2 || retries[0] != 1 || retries[1] != 2 {
+		t.Errorf("expected retry hook for attempts [1 2], got %v", retries)
+	}
+	if len(dead) != 1 || dead[0] != "1" {
+		t.Errorf("expected event 1 to be dead lettered, got %v", dead)
+	}
+}
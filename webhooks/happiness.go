@@ -0,0 +1,87 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// EventHappinessRated is the event type sent when a customer answers a
+// happiness survey
+const EventHappinessRated = "happiness.rated"
+
+// HappinessPayload is the payload of a happiness.rated event
+type HappinessPayload struct {
+	// ID identifies the survey response
+	ID       int                    `json:"id"`
+	Rating   models.HappinessRating `json:"rating"`
+	Comment  string                 `json:"comment,omitempty"`
+	Ticket   models.EntityRef       `json:"ticket"`
+	Customer models.EntityRef       `json:"customer"`
+	// Agent is the agent the ticket was assigned to when the survey was
+	// sent, if any
+	Agent   *models.EntityRef `json:"agent,omitempty"`
+	RatedAt time.Time         `json:"ratedAt"`
+}
+
+// ParseHappiness decodes the payload of a happiness.rated event
+func ParseHappiness(event Event) (*HappinessPayload, error) {
+	if event.Type != EventHappinessRated {
+		return nil, fmt.Errorf("event type is %q, not %q", event.Type, EventHappinessRated)
+	}
+
+	var p HappinessPayload
+	if err := json.Unmarshal(event.Payload, &p); err != nil {
+		return nil, fmt.Errorf("decode happiness payload: %w", err)
+	}
+	if p.Ticket.ID <= 0 {
+		return nil, fmt.Errorf("happiness payload has no ticket")
+	}
+
+	return &p, nil
+}
+
+// HappinessDetails is a happiness rating together with the ticket and agent
+// it is about
+type HappinessDetails struct {
+	Rating HappinessPayload
+	Ticket models.Ticket
+	// Agent is nil when the ticket is unassigned
+	Agent *models.User
+}
+
+// LookupHappiness loads the ticket and agent a rating refers to, so alerting
+// integrations can say who was rated on what. The agent comes from the
+// payload, falling back to the ticket's current assignee.
+func LookupHappiness(ctx context.Context, c *client.Client, p *HappinessPayload) (*HappinessDetails, error) {
+	if p == nil {
+		return nil, fmt.Errorf("payload is required")
+	}
+
+	ticket, err := c.Tickets.Get(ctx, p.Ticket.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get ticket: %w", err)
+	}
+
+	details := &HappinessDetails{Rating: *p, Ticket: ticket.Ticket}
+
+	agent := p.Agent
+	if agent == nil {
+		agent = ticket.Ticket.Agent
+	}
+	if agent == nil || agent.ID <= 0 {
+		return details, nil
+	}
+
+	user, err := c.Users.Get(ctx, agent.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get agent: %w", err)
+	}
+	details.Agent = &user.User
+
+	return details, nil
+}
@@ -0,0 +1,50 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/client"
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func TestLookupHappiness(t *testing.T) {
+	event := Event{
+		ID:      "1",
+		Type:    EventHappinessRated,
+		Payload: []byte(`{"id":9,"rating":"unhappy","comment":"Too slow","ticket":{"id":42,"type":"tickets"},"customer":{"id":7,"type":"customers"}}`),
+	}
+
+	p, err := ParseHappiness(event)
+	if err != nil {
+		t.Fatalf("ParseHappiness() returned error: %v", err)
+	}
+	if p.Rating != models.HappinessRatingUnhappy || p.Rating.Score() != 1 {
+		t.Errorf("expected an unhappy rating, got %q", p.Rating)
+	}
+
+	mockTransport := client.NewMockRoundTripper()
+	mockTransport.AddResponse(http.MethodGet, "/tickets/42.json", http.StatusOK, models.TicketResponse{
+		Ticket: models.Ticket{BaseEntity: models.BaseEntity{ID: 42}, Agent: &models.EntityRef{ID: 3}},
+	})
+	mockTransport.AddResponse(http.MethodGet, "/users/3.json", http.StatusOK, models.UserResponse{
+		User: models.User{BaseEntity: models.BaseEntity{ID: 3}},
+	})
+	c := client.NewClient("https://example.com", client.WithHTTPClient(&http.Client{Transport: mockTransport}))
+
+	details, err := LookupHappiness(context.Background(), c, p)
+	if err != nil {
+		t.Fatalf("LookupHappiness() returned error: %v", err)
+	}
+	if details.Ticket.ID != 42 {
+		t.Errorf("expected ticket 42, got %d", details.Ticket.ID)
+	}
+	if details.Agent == nil || details.Agent.ID != 3 {
+		t.Errorf("expected the ticket's agent, got %+v", details.Agent)
+	}
+
+	if _, err := ParseHappiness(Event{Type: "ticket.created"}); err == nil {
+		t.Error("expected an error for another event type")
+	}
+}
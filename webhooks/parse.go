@@ -0,0 +1,152 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+// Event types with a typed payload. See DecodePayload.
+const (
+	EventTicketCreated   = "ticket.created"
+	EventTicketUpdated   = "ticket.updated"
+	EventTicketDeleted   = "ticket.deleted"
+	EventMessageCreated  = "message.created"
+	EventCustomerCreated = "customer.created"
+	EventCustomerUpdated = "customer.updated"
+)
+
+// SignatureHeader is the header Desk signs deliveries in. Its value is
+// "sha256=" followed by the hex encoded HMAC-SHA256 of the raw body, keyed
+// with the webhook's secret.
+const SignatureHeader = "X-Desk-Signature"
+
+// MaxBodySize caps the size of a delivery read by ParseEvent
+const MaxBodySize = 1 << 20
+
+// ErrInvalidSignature is returned when a delivery's signature is missing or
+// does not match its body. Check for it with errors.Is.
+const ErrInvalidSignature = signatureError("invalid webhook signature")
+
+type signatureError string
+
+func (e signatureError) Error() string { return string(e) }
+
+// ParseEvent reads a webhook delivery, checks its signature against secret
+// and decodes it. Event.Data holds the typed payload for the event types
+// DecodePayload knows about. Respond with 401 when the error is
+// ErrInvalidSignature and 400 otherwise.
+//
+//	event, err := webhooks.ParseEvent(r, secret)
+//	if err != nil {
+//		...
+//	}
+//	switch data := event.Data.(type) {
+//	case *models.Ticket:
+//		...
+//	}
+func ParseEvent(r *http.Request, secret string) (*Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read webhook body: %w", err)
+	}
+	if len(body) > MaxBodySize {
+		return nil, fmt.Errorf("webhook body is larger than %d bytes", MaxBodySize)
+	}
+
+	if err := VerifySignature(body, r.Header.Get(SignatureHeader), secret); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("decode webhook event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook event has no type")
+	}
+	if event.ReceivedAt.IsZero() {
+		event.ReceivedAt = time.Now()
+	}
+
+	event.Data, err = DecodePayload(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// VerifySignature checks signature, the value of the SignatureHeader, against
+// body. The comparison is constant time.
+func VerifySignature(body []byte, signature, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("webhook secret is required")
+	}
+
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(got, sign(body, secret)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body, for testing receivers and
+// for relaying deliveries to other services
+func Sign(body []byte, secret string) string {
+	return "sha256=" + hex.EncodeToString(sign(body, secret))
+}
+
+func sign(body []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// DecodePayload decodes an event's payload according to its type:
+//
+//   - ticket.created, ticket.updated, ticket.deleted: *models.Ticket
+//   - message.created: *models.Message
+//   - customer.created, customer.updated: *models.Customer
+//   - happiness.rated: *HappinessPayload
+//
+// Other event types decode to nil without an error, so receivers keep working
+// when Desk adds events.
+func DecodePayload(event Event) (any, error) {
+	switch event.Type {
+	case EventTicketCreated, EventTicketUpdated, EventTicketDeleted:
+		return decodePayload[models.Ticket](event, "ticket")
+	case EventMessageCreated:
+		return decodePayload[models.Message](event, "message")
+	case EventCustomerCreated, EventCustomerUpdated:
+		return decodePayload[models.Customer](event, "customer")
+	case EventHappinessRated:
+		return ParseHappiness(event)
+	default:
+		return nil, nil
+	}
+}
+
+func decodePayload[T any](event Event, name string) (*T, error) {
+	var v T
+	if err := json.Unmarshal(event.Payload, &v); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", name, err)
+	}
+	return &v, nil
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/teamwork/desksdkgo/models"
+)
+
+func newDelivery(body, signature string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	if signature != "" {
+		r.Header.Set(SignatureHeader, signature)
+	}
+	return r
+}
+
+func TestParseEvent(t *testing.T) {
+	const secret = "s3cret"
+	body := `{"id":"evt-1","type":"ticket.created","payload":{"id":42,"subject":"Help"}}`
+
+	event, err := ParseEvent(newDelivery(body, Sign([]byte(body), secret)), secret)
+	if err != nil {
+		t.Fatalf("ParseEvent() returned error: %v", err)
+	}
+	if event.ID != "evt-1" || event.ReceivedAt.IsZero() {
+		t.Errorf("unexpected event %+v", event)
+	}
+	ticket, ok := event.Data.(*models.Ticket)
+	if !ok || ticket.ID != 42 || *ticket.Subject != "Help" {
+		t.Fatalf("expected the ticket payload, got %#v", event.Data)
+	}
+
+	unknown := `{"id":"evt-2","type":"inbox.renamed","payload":{}}`
+	event, err = ParseEvent(newDelivery(unknown, Sign([]byte(unknown), secret)), secret)
+	if err != nil || event.Data != nil {
+		t.Errorf("expected unknown events to parse without data, got %v, %v", event, err)
+	}
+}
+
+func TestParseEventRejectsBadSignatures(t *testing.T) {
+	const secret = "s3cret"
+	body := `{"id":"evt-1","type":"ticket.created","payload":{"id":42}}`
+
+	tests := map[string]string{
+		"missing":      "",
+		"no prefix":    strings.TrimPrefix(Sign([]byte(body), secret), "sha256="),
+		"not hex":      "sha256=zz",
+		"wrong secret": Sign([]byte(body), "other"),
+		"other body":   Sign([]byte(body+" "), secret),
+	}
+	for name, signature := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseEvent(newDelivery(body, signature), secret)
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}